@@ -3,6 +3,7 @@ module github.com/rocket-pool/smartnode
 go 1.13
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
 	github.com/blang/semver/v4 v4.0.0
 	github.com/btcsuite/btcd v0.21.0-beta
@@ -33,7 +34,7 @@ require (
 	github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4 v1.1.1
 	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83
 	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a
-	golang.org/x/sys v0.0.0-20210305230114-8fe3ee5dd75b // indirect
+	golang.org/x/sys v0.0.0-20210305230114-8fe3ee5dd75b
 	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
 	google.golang.org/grpc v1.29.1
 	gopkg.in/yaml.v2 v2.3.0
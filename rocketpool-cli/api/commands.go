@@ -0,0 +1,24 @@
+package api
+
+import (
+    "github.com/urfave/cli"
+)
+
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:      name,
+        Aliases:   aliases,
+        Usage:     "Run a read-only Rocket Pool API query and print the raw JSON response",
+        UsageText: "rocketpool api command subcommand [arguments...]\n\n   e.g. rocketpool api node status",
+        SkipFlagParsing: true,
+        Action: func(c *cli.Context) error {
+
+            // Run
+            return runQuery(c, c.Args())
+
+        },
+    })
+}
+
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func runQuery(c *cli.Context, args []string) error {
+
+    // Check args
+    if len(args) < 2 {
+        return errors.New("Please specify an API command and subcommand, e.g. 'rocketpool api node status'.")
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Run query
+    response, err := rp.CallReadOnlyAPI(args)
+    if err != nil { return err }
+
+    // Print raw response
+    fmt.Println(string(response))
+    return nil
+
+}
+
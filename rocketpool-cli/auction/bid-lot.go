@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -38,7 +39,7 @@ func bidOnLot(c *cli.Context) error {
 
     // Check for open lots
     if len(openLots) == 0 {
-        fmt.Println("No lots can be bid on.")
+        fmt.Fprintln(rp.Output(), "No lots can be bid on.")
         return nil
     }
 
@@ -128,20 +129,19 @@ func bidOnLot(c *cli.Context) error {
         return err
     }
     if !canBid.CanBid {
-        fmt.Println("Cannot bid on lot:")
+        fmt.Fprintln(rp.Output(), "Cannot bid on lot:")
         if canBid.BidOnLotDisabled {
-            fmt.Println("Bidding on lots is currently disabled.")
+            fmt.Fprintln(rp.Output(), "Bidding on lots is currently disabled.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canBid.GasInfo)
+    if err := rp.PrintGasInfo(canBid.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to bid %.6f ETH on lot %d? Bids are final and non-refundable.", math.RoundDown(eth.WeiToEth(amountWei), 6), selectedLot.Details.Index))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Bid on lot
@@ -150,14 +150,14 @@ func bidOnLot(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Bidding on lot...\n")
+    fmt.Fprintf(rp.Output(), "Bidding on lot...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully bid %.6f ETH on lot %d.\n", math.RoundDown(eth.WeiToEth(amountWei), 6), selectedLot.Details.Index)
+    fmt.Fprintf(rp.Output(), "Successfully bid %.6f ETH on lot %d.\n", math.RoundDown(eth.WeiToEth(amountWei), 6), selectedLot.Details.Index)
     return nil
 
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -38,7 +39,7 @@ func claimFromLot(c *cli.Context) error {
 
     // Check for claimable lots
     if len(claimableLots) == 0 {
-        fmt.Println("No lots are available for RPL claims.")
+        fmt.Fprintln(rp.Output(), "No lots are available for RPL claims.")
         return nil
     }
 
@@ -95,7 +96,7 @@ func claimFromLot(c *cli.Context) error {
     for _, lot := range selectedLots {
         canResponse, err := rp.CanClaimFromLot(lot.Details.Index)
         if err != nil {
-            fmt.Printf("WARNING: Couldn't get gas price for claim transaction (%s)", err)
+            fmt.Fprintf(rp.Output(), "WARNING: Couldn't get gas price for claim transaction (%s)", err)
             break
         } else {
             gasInfo = canResponse.GasInfo
@@ -105,28 +106,27 @@ func claimFromLot(c *cli.Context) error {
     gasInfo.EstGasLimit = totalGas
 
     // Display gas estimate
-    rp.PrintGasInfo(gasInfo)
+    if err := rp.PrintGasInfo(gasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to claim %d lots?", len(selectedLots)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Claim RPL from lots
     for _, lot := range selectedLots {
         response, err := rp.ClaimFromLot(lot.Details.Index)
         if err != nil {
-            fmt.Printf("Could not claim RPL from lot %d: %s.\n", lot.Details.Index, err)
+            fmt.Fprintf(rp.Output(), "Could not claim RPL from lot %d: %s.\n", lot.Details.Index, err)
             continue
         }
 
-        fmt.Printf("Claiming from lot %d...\n", lot.Details.Index)
+        fmt.Fprintf(rp.Output(), "Claiming from lot %d...\n", lot.Details.Index)
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-            fmt.Printf("Could not claim RPL from lot %d: %s.\n", lot.Details.Index, err)
+            fmt.Fprintf(rp.Output(), "Could not claim RPL from lot %d: %s.\n", lot.Details.Index, err)
         } else {
-            fmt.Printf("Successfully claimed RPL from lot %d.\n", lot.Details.Index)
+            fmt.Fprintf(rp.Output(), "Successfully claimed RPL from lot %d.\n", lot.Details.Index)
         }
     }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -23,23 +24,22 @@ func createLot(c *cli.Context) error {
         return err
     }
     if !canCreate.CanCreate {
-        fmt.Println("Cannot create lot:")
+        fmt.Fprintln(rp.Output(), "Cannot create lot:")
         if canCreate.InsufficientBalance {
-            fmt.Println("The auction contract does not have a sufficient RPL balance to create a lot.")
+            fmt.Fprintln(rp.Output(), "The auction contract does not have a sufficient RPL balance to create a lot.")
         }
         if canCreate.CreateLotDisabled {
-            fmt.Println("Lot creation is currently disabled.")
+            fmt.Fprintln(rp.Output(), "Lot creation is currently disabled.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canCreate.GasInfo)
+    if err := rp.PrintGasInfo(canCreate.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to create this lot?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Create lot
@@ -48,14 +48,14 @@ func createLot(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Creating lot...\n")
+    fmt.Fprintf(rp.Output(), "Creating lot...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully created a new lot with ID %d.\n", response.LotId)
+    fmt.Fprintf(rp.Output(), "Successfully created a new lot with ID %d.\n", response.LotId)
     return nil
 
 }
@@ -1,5 +1,12 @@
 #!/bin/bash
 
-GOOS=linux GOARCH=amd64 go build -o rocketpool-cli-linux-amd64 rocketpool-cli.go
-GOOS=darwin GOARCH=amd64 go build -o rocketpool-cli-darwin-amd64 rocketpool-cli.go
-GOOS=windows GOARCH=amd64 go build -o rocketpool-cli-windows-amd64.exe rocketpool-cli.go
+# The hex-encoded ed25519 public key that release image manifests are signed with, used by 'service start --verify-images'
+if [ -z "$IMAGE_MANIFEST_PUBLIC_KEY" ]; then
+    echo "IMAGE_MANIFEST_PUBLIC_KEY must be set to the hex-encoded image manifest signing key." >&2
+    exit 1
+fi
+LDFLAGS="-X github.com/rocket-pool/smartnode/shared/services/rocketpool.ImageManifestPublicKey=$IMAGE_MANIFEST_PUBLIC_KEY"
+
+GOOS=linux GOARCH=amd64 go build -ldflags "$LDFLAGS" -o rocketpool-cli-linux-amd64 rocketpool-cli.go
+GOOS=darwin GOARCH=amd64 go build -ldflags "$LDFLAGS" -o rocketpool-cli-darwin-amd64 rocketpool-cli.go
+GOOS=windows GOARCH=amd64 go build -ldflags "$LDFLAGS" -o rocketpool-cli-windows-amd64.exe rocketpool-cli.go
@@ -0,0 +1,73 @@
+package minipool
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+
+func checkKeys(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Compare minipools & validator keys
+    response, err := rp.MinipoolCheckKeys()
+    if err != nil {
+        return err
+    }
+
+    // Print counts
+    colorReset := "\033[0m"
+    colorRed := "\033[31m"
+    colorYellow := "\033[33m"
+    fmt.Printf("The node has %d minipool(s) and %d validator key(s) stored on disk for the %s validator client.\n", response.MinipoolCount, response.StoredKeyCount, response.ValidatorClient)
+    fmt.Println("NOTE: the keys the running validator client has loaded can't be checked, as the validator clients don't report them; a stored key is only loaded when the validator client starts and accepts it.")
+    fmt.Println("")
+
+    // Print minipools without a stored key
+    // Only minipools whose validator has been or will be deposited to the beacon chain need a key
+    missingActive := 0
+    if len(response.MissingKeys) > 0 {
+        fmt.Printf("%d minipool(s) have no validator key stored for the validator client:\n", len(response.MissingKeys))
+        for _, minipool := range response.MissingKeys {
+            color := colorYellow
+            if minipool.MinipoolStatus == types.Initialized || minipool.MinipoolStatus == types.Prelaunch || minipool.MinipoolStatus == types.Staking {
+                color = colorRed
+                missingActive++
+            }
+            fmt.Printf("%s- %s (%s, pubkey %s)%s\n", color, minipool.Address.Hex(), minipool.MinipoolStatus.String(), hex.AddPrefix(minipool.ValidatorPubkey.Hex()), colorReset)
+        }
+        fmt.Println("")
+    }
+
+    // Print stored keys without a minipool
+    if len(response.UnmatchedKeys) > 0 {
+        fmt.Printf("%d stored validator key(s) do not belong to any of the node's minipools:\n", len(response.UnmatchedKeys))
+        for _, pubkey := range response.UnmatchedKeys {
+            fmt.Printf("%s- %s%s\n", colorYellow, hex.AddPrefix(pubkey.Hex()), colorReset)
+        }
+        fmt.Println("These keys will be loaded by the validator client when it starts; make sure they are not running anywhere else.")
+        fmt.Println("")
+    }
+
+    // Return
+    if missingActive > 0 {
+        fmt.Printf("%s%d active minipool(s) have no stored key, so the validator client can't attest for them. Run 'rocketpool wallet rebuild' to restore their keys and restart the validator client.%s\n\n", colorRed, missingActive, colorReset)
+        return errors.New("Validator keys are missing for active minipools.")
+    }
+    if len(response.MissingKeys) == 0 && len(response.UnmatchedKeys) == 0 {
+        fmt.Println("Every minipool has a stored validator key, and every stored key belongs to a minipool.")
+        fmt.Println("If keys were stored since the validator client was last started, restart it to load them.")
+    }
+    return nil
+
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -39,7 +40,7 @@ func closeMinipools(c *cli.Context) error {
 
     // Check for closable minipools
     if len(closableMinipools) == 0 {
-        fmt.Println("No minipools can be closed.")
+        fmt.Fprintln(rp.Output(), "No minipools can be closed.")
         return nil
     }
 
@@ -88,7 +89,7 @@ func closeMinipools(c *cli.Context) error {
     for _, minipool := range selectedMinipools {
         canResponse, err := rp.CanCloseMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("WARNING: Couldn't get gas price for close transaction (%s)", err)
+            fmt.Fprintf(rp.Output(), "WARNING: Couldn't get gas price for close transaction (%s)", err)
             break
         } else {
             gasInfo = canResponse.GasInfo
@@ -98,12 +99,11 @@ func closeMinipools(c *cli.Context) error {
     gasInfo.EstGasLimit = totalGas
 
     // Display gas estimate
-    rp.PrintGasInfo(gasInfo)
+    if err := rp.PrintGasInfo(gasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to close %d minipools?", len(selectedMinipools)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Close minipools
@@ -111,32 +111,32 @@ func closeMinipools(c *cli.Context) error {
 
         canResponse, err := rp.CanCloseMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("Could not check closing status for minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not check closing status for minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         }
         if !canResponse.CanClose {
-            fmt.Printf("Cannot close minipool %s:\n", minipool.Address.Hex())
+            fmt.Fprintf(rp.Output(), "Cannot close minipool %s:\n", minipool.Address.Hex())
             if canResponse.InvalidStatus {
-                fmt.Println("The minipool is not in a closeable state.")
+                fmt.Fprintln(rp.Output(), "The minipool is not in a closeable state.")
             }
             if !canResponse.InConsensus {
-                fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+                fmt.Fprintln(rp.Output(), "The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
             }
             continue
         }
 
         response, err := rp.CloseMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         }
 
-        fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
+        fmt.Fprintf(rp.Output(), "Closing minipool %s...\n", minipool.Address.Hex())
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-            fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
-            fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
+            fmt.Fprintf(rp.Output(), "Successfully closed minipool %s.\n", minipool.Address.Hex())
         }
     }
 
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"errors"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -18,18 +20,106 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "status",
                 Aliases:   []string{"s"},
                 Usage:     "Get a list of the node's minipools",
-                UsageText: "rocketpool minipool status",
+                UsageText: "rocketpool minipool status [options]",
+                Flags: []cli.Flag{
+                    cli.StringSliceFlag{
+                        Name:  "filter, f",
+                        Usage: "Only show minipools with the given status (initialized, prelaunch, staking, withdrawable, dissolved) or available action (refund, close); may be comma-separated or defined multiple times",
+                    },
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "Observe the minipools of the node at this address instead of the node wallet's address (does not require a wallet)",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
                     // Run
                     return getStatus(c)
 
                 },
             },
 
+            cli.Command{
+                Name:      "lookup",
+                Aliases:   []string{"l"},
+                Usage:     "Look up a minipool by its address or validator pubkey",
+                UsageText: "rocketpool minipool lookup minipool-address|validator-pubkey",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Run
+                    return lookupMinipool(c, c.Args().Get(0))
+
+                },
+            },
+
+            cli.Command{
+                Name:      "check-keys",
+                Usage:     "Compare the node's minipools against the validator keys stored on disk for the validator client (not the keys it has loaded), reporting minipools with missing keys and keys without a minipool",
+                UsageText: "rocketpool minipool check-keys",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return checkKeys(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "export-history",
+                Aliases:   []string{"x"},
+                Usage:     "Export the balance & reward events of the node's minipools within a block range as CSV",
+                UsageText: "rocketpool minipool export-history [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "from-block",
+                        Usage: "The first block to export events from",
+                    },
+                    cli.Uint64Flag{
+                        Name:  "to-block",
+                        Usage: "The last block to export events from (default: the latest block)",
+                    },
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "The `file` to write the CSV to (default: stdout)",
+                    },
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "Export the minipools of the node at this address instead of the node wallet's address (does not require a wallet)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+                    if c.IsSet("to-block") && c.Uint64("to-block") < c.Uint64("from-block") {
+                        return errors.New("The '--to-block' must not be before the '--from-block'.")
+                    }
+
+                    // Run
+                    return exportHistory(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "refund",
                 Aliases:   []string{"r"},
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -40,7 +41,7 @@ func dissolveMinipools(c *cli.Context) error {
 
     // Check for initialized minipools
     if len(initializedMinipools) == 0 {
-        fmt.Println("No minipools can be dissolved.")
+        fmt.Fprintln(rp.Output(), "No minipools can be dissolved.")
         return nil
     }
 
@@ -89,7 +90,7 @@ func dissolveMinipools(c *cli.Context) error {
     for _, minipool := range selectedMinipools {
         canResponse, err := rp.CanDissolveMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("WARNING: Couldn't get gas price for dissolve transaction (%s)", err)
+            fmt.Fprintf(rp.Output(), "WARNING: Couldn't get gas price for dissolve transaction (%s)", err)
             break
         } else {
             gasInfo = canResponse.GasInfo
@@ -99,43 +100,42 @@ func dissolveMinipools(c *cli.Context) error {
     gasInfo.EstGasLimit = totalGas
 
     // Display gas estimate
-    rp.PrintGasInfo(gasInfo)
+    if err := rp.PrintGasInfo(gasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to dissolve %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Dissolve and close minipools
     for _, minipool := range selectedMinipools {
         response, err := rp.DissolveMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         }
 
-        fmt.Printf("Dissolving minipool %s...\n", minipool.Address.Hex())
+        fmt.Fprintf(rp.Output(), "Dissolving minipool %s...\n", minipool.Address.Hex())
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-            fmt.Printf("Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not dissolve minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         } else {
-            fmt.Printf("Successfully dissolved minipool %s.\n", minipool.Address.Hex())
+            fmt.Fprintf(rp.Output(), "Successfully dissolved minipool %s.\n", minipool.Address.Hex())
         }
 
         closeResponse, err := rp.CloseMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         }
 
-        fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
+        fmt.Fprintf(rp.Output(), "Closing minipool %s...\n", minipool.Address.Hex())
         cliutils.PrintTransactionHash(rp, closeResponse.TxHash)
         if _, err = rp.WaitForTransaction(closeResponse.TxHash); err != nil {
-            fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not close minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
-            fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
+            fmt.Fprintf(rp.Output(), "Successfully closed minipool %s.\n", minipool.Address.Hex())
         }
     }
 
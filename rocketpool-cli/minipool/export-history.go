@@ -0,0 +1,104 @@
+package minipool
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Config
+const HistoryTimeFormat = time.RFC3339
+
+
+func exportHistory(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get minipool history
+    var nodeAddress *common.Address
+    if c.String("node-address") != "" {
+        address := common.HexToAddress(c.String("node-address"))
+        nodeAddress = &address
+    }
+    history, err := rp.MinipoolHistory(nodeAddress, c.Uint64("from-block"), c.Uint64("to-block"))
+    if err != nil {
+        return err
+    }
+
+    // Get output
+    var output io.Writer = os.Stdout
+    if c.String("output") != "" {
+        file, err := os.Create(c.String("output"))
+        if err != nil {
+            return fmt.Errorf("Could not create output file: %w", err)
+        }
+        defer file.Close()
+        output = file
+    }
+
+    // Write CSV
+    writer := csv.NewWriter(output)
+    if err := writer.Write([]string{"Minipool", "Block", "Timestamp", "Transaction", "Event", "Amount (ETH)", "Node Amount (ETH)", "User Amount (ETH)", "Minipool Balance (ETH)"}); err != nil {
+        return err
+    }
+    balanceErrors := 0
+    for _, event := range history.Events {
+        balance := ""
+        if event.Balance != nil {
+            balance = formatWeiAsEth(event.Balance)
+        } else {
+            balanceErrors++
+        }
+        if err := writer.Write([]string{
+            event.Address.Hex(),
+            strconv.FormatUint(event.Block, 10),
+            time.Unix(int64(event.Time), 0).UTC().Format(HistoryTimeFormat),
+            event.TxHash.Hex(),
+            event.Event,
+            formatWeiAsEth(event.Amount),
+            formatWeiAsEth(event.NodeAmount),
+            formatWeiAsEth(event.UserAmount),
+            balance,
+        }); err != nil {
+            return err
+        }
+    }
+    writer.Flush()
+    if err := writer.Error(); err != nil {
+        return fmt.Errorf("Could not write minipool history: %w", err)
+    }
+
+    // Log & return
+    if balanceErrors > 0 {
+        fmt.Fprintf(os.Stderr, "WARNING: The minipool balance could not be read for %d event(s), so it was left empty. Historical balances require an archive eth1 node.\n", balanceErrors)
+        for _, event := range history.Events {
+            if event.BalanceError != "" {
+                fmt.Fprintf(os.Stderr, "First error (minipool %s): %s\n", event.Address.Hex(), event.BalanceError)
+                break
+            }
+        }
+    }
+    if c.String("output") != "" {
+        fmt.Printf("Exported %d minipool event(s) from blocks %d to %d to %s.\n", len(history.Events), history.FromBlock, history.ToBlock, c.String("output"))
+    }
+    return nil
+
+}
+
+
+// Format a wei amount as an exact decimal ETH string
+func formatWeiAsEth(wei *big.Int) string {
+    return new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+}
@@ -0,0 +1,54 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+
+func lookupMinipool(c *cli.Context, value string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Look up minipool by address or validator pubkey
+    var response api.MinipoolLookupResponse
+    if common.IsHexAddress(value) {
+        minipoolAddress, err := cliutils.ValidateAddress("minipool address", value)
+        if err != nil { return err }
+        response, err = rp.GetMinipoolPubkey(minipoolAddress)
+        if err != nil { return err }
+        if !response.Exists {
+            fmt.Printf("Address %s is not a Rocket Pool minipool.\n", minipoolAddress.Hex())
+            return nil
+        }
+    } else {
+        pubkey, err := cliutils.ValidatePubkey("validator pubkey", value)
+        if err != nil { return err }
+        response, err = rp.GetMinipoolByPubkey(pubkey)
+        if err != nil { return err }
+        if !response.Exists {
+            fmt.Printf("Validator pubkey %s does not belong to a Rocket Pool minipool.\n", hex.AddPrefix(pubkey.Hex()))
+            return nil
+        }
+    }
+
+    // Print minipool details
+    fmt.Printf("Address:          %s\n", response.Address.Hex())
+    fmt.Printf("Validator pubkey: %s\n", hex.AddPrefix(response.ValidatorPubkey.Hex()))
+    fmt.Printf("Status:           %s\n", response.MinipoolStatus.String())
+
+    // Return
+    return nil
+
+}
+
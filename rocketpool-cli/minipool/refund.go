@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -39,7 +40,7 @@ func refundMinipools(c *cli.Context) error {
 
     // Check for refundable minipools
     if len(refundableMinipools) == 0 {
-        fmt.Println("No minipools have refunds available.")
+        fmt.Fprintln(rp.Output(), "No minipools have refunds available.")
         return nil
     }
 
@@ -88,7 +89,7 @@ func refundMinipools(c *cli.Context) error {
     for _, minipool := range selectedMinipools {
         canResponse, err := rp.CanRefundMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("WARNING: Couldn't get gas price for refund transaction (%s)", err)
+            fmt.Fprintf(rp.Output(), "WARNING: Couldn't get gas price for refund transaction (%s)", err)
             break
         } else {
             gasInfo = canResponse.GasInfo
@@ -98,28 +99,27 @@ func refundMinipools(c *cli.Context) error {
     gasInfo.EstGasLimit = totalGas
 
     // Display gas estimate
-    rp.PrintGasInfo(gasInfo)
+    if err := rp.PrintGasInfo(gasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to refund %d minipools?", len(selectedMinipools)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Refund minipools
     for _, minipool := range selectedMinipools {
         response, err := rp.RefundMinipool(minipool.Address)
         if err != nil {
-            fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err)
             continue
         }
     
-        fmt.Printf("Refunding minipool %s...\n", minipool.Address.Hex())
+        fmt.Fprintf(rp.Output(), "Refunding minipool %s...\n", minipool.Address.Hex())
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-            fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err)
+            fmt.Fprintf(rp.Output(), "Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err)
         } else {
-            fmt.Printf("Successfully refunded ETH from minipool %s.\n", minipool.Address.Hex())
+            fmt.Fprintf(rp.Output(), "Successfully refunded ETH from minipool %s.\n", minipool.Address.Hex())
         }
     }
 
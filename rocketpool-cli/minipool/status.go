@@ -2,7 +2,9 @@ package minipool
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -14,19 +16,56 @@ import (
 )
 
 
+// Minipool filters for actionable minipools
+const (
+    RefundFilter = "refund"
+    CloseFilter = "close"
+)
+
+
 func getStatus(c *cli.Context) error {
 
+    // Get minipool filters
+    filters, err := getStatusFilters(c.StringSlice("filter"))
+    if err != nil { return err }
+
     // Get RP client
     rp, err := rocketpool.NewClientFromCtx(c)
     if err != nil { return err }
     defer rp.Close()
 
     // Get minipool statuses
-    status, err := rp.MinipoolStatus()
+    var status api.MinipoolStatusResponse
+    if c.String("node-address") != "" {
+        status, err = rp.ObservedMinipoolStatus(common.HexToAddress(c.String("node-address")))
+    } else {
+        status, err = rp.MinipoolStatus()
+    }
     if err != nil {
         return err
     }
 
+    // Filter minipools
+    if len(filters) > 0 {
+        if len(status.Minipools) == 0 {
+            fmt.Println("The node does not have any minipools yet.")
+            return nil
+        }
+        filteredMinipools := []api.MinipoolDetails{}
+        for _, minipool := range status.Minipools {
+            if filters[strings.ToLower(minipool.Status.Status.String())] ||
+               (filters[RefundFilter] && minipool.RefundAvailable) ||
+               (filters[CloseFilter] && minipool.CloseAvailable) {
+                filteredMinipools = append(filteredMinipools, minipool)
+            }
+        }
+        if len(filteredMinipools) == 0 {
+            fmt.Println("No minipools match the specified filter.")
+            return nil
+        }
+        status.Minipools = filteredMinipools
+    }
+
     // Get minipools by status
     statusMinipools := map[string][]api.MinipoolDetails{}
     refundableMinipools := []api.MinipoolDetails{}
@@ -65,7 +104,7 @@ func getStatus(c *cli.Context) error {
         // Minipool status count & description
         fmt.Printf("%d %s minipool(s):\n", len(minipools), statusName)
         if statusName == "Withdrawable" {
-            fmt.Println("(Withdrawal may not be available until after withdrawal delay)")
+            fmt.Println("(Withdrawal is not available until after the withdrawal delay)")
         }
         fmt.Println("")
 
@@ -110,7 +149,12 @@ func getStatus(c *cli.Context) error {
             // Withdrawal details - withdrawable minipools
             if minipool.Status.Status == types.Withdrawable {
             fmt.Printf("Final balance:        %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Staking.EndBalance), 6))
+                if minipool.WithdrawalAvailable {
             fmt.Printf("Withdrawal available: yes\n")
+                } else {
+            fmt.Printf("Withdrawal available: no, %d block(s) of withdrawal delay remaining\n", minipool.WithdrawalDelayRemaining)
+            fmt.Printf("Available from:       block %d (approx. %s)\n", minipool.WithdrawalAvailableBlock, minipool.WithdrawalAvailableTime.Format(TimeFormat))
+                }
             }
 
             fmt.Printf("\n")
@@ -140,3 +184,29 @@ func getStatus(c *cli.Context) error {
 
 }
 
+
+// Parse & validate minipool status filters; filters may be repeated or comma-separated
+func getStatusFilters(values []string) (map[string]bool, error) {
+    validFilters := map[string]bool{RefundFilter: true, CloseFilter: true}
+    for _, statusName := range types.MinipoolStatuses {
+        validFilters[strings.ToLower(statusName)] = true
+    }
+    filters := map[string]bool{}
+    for _, value := range values {
+        for _, filter := range strings.Split(value, ",") {
+            filter = strings.ToLower(strings.TrimSpace(filter))
+            if filter == "" { continue }
+            if !validFilters[filter] {
+                validNames := []string{}
+                for _, statusName := range types.MinipoolStatuses {
+                    validNames = append(validNames, strings.ToLower(statusName))
+                }
+                validNames = append(validNames, RefundFilter, CloseFilter)
+                return nil, fmt.Errorf("Invalid minipool filter '%s' - valid filters are: %s", filter, strings.Join(validNames, ", "))
+            }
+            filters[filter] = true
+        }
+    }
+    return filters, nil
+}
+
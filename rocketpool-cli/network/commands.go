@@ -1,6 +1,8 @@
 package network
 
 import (
+    "fmt"
+
     "github.com/urfave/cli"
 
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -47,6 +49,50 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "stats",
+                Aliases:   []string{"s"},
+                Usage:     "Get a summary of network-wide Rocket Pool statistics",
+                UsageText: "rocketpool network stats",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getStats(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "eth1-latency",
+                Aliases:   []string{"l"},
+                Usage:     "Measure the round-trip latency of your eth1 client, to compare providers",
+                UsageText: "rocketpool network eth1-latency [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "count, n",
+                        Usage: "The number of eth_blockNumber requests to make",
+                        Value: 20,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.Uint64("count") == 0 || c.Uint64("count") > MaxLatencyRequests {
+                        return fmt.Errorf("Invalid request count '%d' - must be between 1 and %d", c.Uint64("count"), MaxLatencyRequests)
+                    }
+
+                    // Run
+                    return getEth1Latency(c)
+
+                },
+            },
+
         },
     })
 }
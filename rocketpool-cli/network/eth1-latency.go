@@ -0,0 +1,48 @@
+package network
+
+import (
+    "errors"
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// Config
+const MaxLatencyRequests = 1000
+
+
+func getEth1Latency(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Measure eth1 latency
+    fmt.Printf("Making %d eth_blockNumber requests to your eth1 client...\n", c.Uint64("count"))
+    response, err := rp.Eth1Latency(c.Uint64("count"))
+    if err != nil {
+        return err
+    }
+
+    // Print errors
+    for _, message := range response.Errors {
+        fmt.Printf("Request failed: %s\n", message)
+    }
+    successes := response.Requests - len(response.Errors)
+    fmt.Printf("%d of %d requests succeeded.\n", successes, response.Requests)
+    if successes == 0 {
+        return errors.New("No requests to the eth1 client succeeded.")
+    }
+
+    // Print latencies
+    fmt.Printf("Min latency:  %s\n", response.MinLatency.String())
+    fmt.Printf("Avg latency:  %s\n", response.AvgLatency.String())
+    fmt.Printf("P95 latency:  %s\n", response.P95Latency.String())
+    fmt.Printf("Throughput:   %.2f requests/s\n", response.Throughput)
+    return nil
+
+}
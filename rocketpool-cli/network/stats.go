@@ -0,0 +1,45 @@
+package network
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+
+func getStats(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get network stats
+    response, err := rp.NetworkStats()
+    if err != nil {
+        return err
+    }
+
+    // Get latest block
+    block, err := rp.GetLatestBlock()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    fmt.Printf("Latest block:              %d\n", block.BlockNumber)
+    fmt.Printf("Registered nodes:          %d\n", response.NodeCount)
+    fmt.Printf("Minipools:                 %d\n", response.MinipoolCount)
+    fmt.Printf("Minipool queue length:     %d\n", response.MinipoolQueueLength)
+    fmt.Printf("Deposit pool balance:      %.6f ETH\n", math.RoundDown(eth.WeiToEth(response.DepositPoolBalance), 6))
+    fmt.Printf("Total RPL staked:          %.6f RPL\n", math.RoundDown(eth.WeiToEth(response.TotalRplStake), 6))
+    fmt.Printf("Total effective RPL stake: %.6f RPL\n", math.RoundDown(eth.WeiToEth(response.TotalEffectiveRplStake), 6))
+    fmt.Printf("RPL price:                 %.6f ETH\n", math.RoundDown(eth.WeiToEth(response.RplPrice), 6))
+    return nil
+
+}
+
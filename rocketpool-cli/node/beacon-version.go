@@ -0,0 +1,34 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+func getBeaconClientVersion(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get beacon client version
+    version, err := rp.NodeBeaconClientVersion()
+    if err != nil {
+        return err
+    }
+
+    // Print version
+    fmt.Printf("Eth 2.0 beacon client: %s\n", version.ClientName)
+    fmt.Printf("Version:               %s\n", version.Version)
+    fmt.Printf("Full version string:   %s\n", version.RawVersion)
+
+    // Return
+    return nil
+
+}
+
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -28,23 +29,20 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
         return err
     }
     if !canBurn.CanBurn {
-        fmt.Println("Cannot burn tokens:")
-        if canBurn.InsufficientBalance {
-            fmt.Printf("The node's %s balance is insufficient.\n", token)
-        }
+        fmt.Fprintln(rp.Output(), "Cannot burn tokens:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canBurn.InsufficientBalance, fmt.Sprintf("The node's %s balance is insufficient.", token))
         if canBurn.InsufficientCollateral {
-            fmt.Printf("There is insufficient ETH collateral to trade %s for.\n", token)
+            fmt.Fprintf(rp.Output(), "There is insufficient ETH collateral to trade %s for.\n", token)
         }
-        return nil
+        return balanceErr
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canBurn.GasInfo)
+    if err := rp.PrintGasInfo(canBurn.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to burn %.6f %s for ETH?", math.RoundDown(eth.WeiToEth(amountWei), 6), token))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Burn tokens
@@ -53,14 +51,14 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
         return err
     }
 
-    fmt.Printf("Burning tokens...\n")
+    fmt.Fprintf(rp.Output(), "Burning tokens...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully burned %.6f %s for ETH.\n", math.RoundDown(eth.WeiToEth(amountWei), 6), token)
+    fmt.Fprintf(rp.Output(), "Successfully burned %.6f %s for ETH.\n", math.RoundDown(eth.WeiToEth(amountWei), 6), token)
     return nil
 
 }
@@ -0,0 +1,121 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+
+func checkDuplicateKeys(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Check for other validator clients running on this host
+    suspected := false
+    colorReset := "\033[0m"
+    colorRed := "\033[31m"
+    colorYellow := "\033[33m"
+    projects, err := rp.GetOtherValidatorProjects()
+    if err != nil {
+        fmt.Printf("%sWARNING: Could not check for other validator clients on this host: %s%s\n\n", colorYellow, err.Error(), colorReset)
+    } else if len(projects) > 0 {
+        suspected = true
+        fmt.Printf("%sAnother validator client is running on this host in project(s): %s.\n", colorRed, strings.Join(projects, ", "))
+        fmt.Printf("If it was set up with this node's wallet or validator keys, stop it immediately to avoid being slashed.%s\n\n", colorReset)
+    }
+
+    // Check whether the local validator client is running
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    validatorService := cfg.GetValidatorServiceName()
+    localRunning := true
+    stopped, err := rp.GetStoppedServices(c.StringSlice("compose-file"))
+    if err != nil {
+        fmt.Printf("%sWARNING: Could not check whether the local validator client is running: %s%s\n\n", colorYellow, err.Error(), colorReset)
+    }
+    for _, service := range stopped {
+        if service == validatorService {
+            localRunning = false
+        }
+    }
+
+    // Get the time the local validator client stopped, so its own attestations aren't counted
+    var stoppedAt uint64
+    if !localRunning {
+        finishedAt, err := rp.GetServiceFinishedAt(c.StringSlice("compose-file"), validatorService)
+        if err != nil {
+            fmt.Printf("%sWARNING: Could not get the time the local validator client stopped: %s%s\n\n", colorYellow, err.Error(), colorReset)
+        } else if finishedAt.Unix() > 0 {
+            stoppedAt = uint64(finishedAt.Unix())
+        }
+    }
+    stoppedAtKnown := (stoppedAt > 0)
+
+    // Get validator attestation activity
+    response, err := rp.NodeCheckDuplicateKeys(stoppedAt)
+    if err != nil {
+        return err
+    }
+    if response.WaitEpochs > 0 {
+        fmt.Printf("The local validator client stopped too recently for its own attestations to have settled; wait %d more epoch(s) and run this command again.\n", response.WaitEpochs)
+        return nil
+    }
+    if len(response.Validators) == 0 {
+        fmt.Println("The node does not have any validators on the beacon chain yet.")
+        return nil
+    }
+    active := 0
+    attesting := []api.DuplicateKeyValidator{}
+    for _, validator := range response.Validators {
+        if validator.Active {
+            active++
+        }
+        if validator.Attesting {
+            attesting = append(attesting, validator)
+        }
+    }
+
+    // Print attestation activity
+    fmt.Printf("%d of the node's %d active validator(s) gained balance over the last %d epochs (up to epoch %d).\n", len(attesting), active, response.CheckEpochs, response.CurrentEpoch)
+    if !localRunning && len(attesting) > 0 && !stoppedAtKnown {
+        suspected = true
+        fmt.Printf("%sThe local validator client is stopped, but these validators gained balance:\n", colorRed)
+        for _, validator := range attesting {
+            fmt.Printf("- %s (index %d, +%d gwei)\n", hex.AddPrefix(validator.Pubkey.Hex()), validator.Index, validator.BalanceChange)
+        }
+        fmt.Printf("If the local validator client stopped less than %d epochs ago, this may be from its own last attestations; otherwise their keys are being run elsewhere.\n", response.CheckEpochs + 2)
+        fmt.Printf("Do NOT start the local validator client until you are sure no other one is running these keys, or you will be slashed.%s\n", colorReset)
+    } else if !localRunning && len(attesting) > 0 {
+        suspected = true
+        fmt.Printf("%sThe local validator client is stopped, but these validators are attesting, so their keys are being run elsewhere:\n", colorRed)
+        for _, validator := range attesting {
+            fmt.Printf("- %s (index %d, +%d gwei)\n", hex.AddPrefix(validator.Pubkey.Hex()), validator.Index, validator.BalanceChange)
+        }
+        fmt.Printf("Do NOT start the local validator client until the other one has been stopped, or you will be slashed.%s\n", colorReset)
+    } else if localRunning {
+        fmt.Println("The local validator client is running, so attestations from another source can't be told apart from its own.")
+        fmt.Printf("To check for keys running elsewhere, stop the validator client for at least %d epochs and run this command again.\n", response.CheckEpochs + 1)
+    } else {
+        fmt.Println("The local validator client is stopped and none of the node's validators are attesting, so no other source is running their keys.")
+    }
+
+    // Return
+    if suspected {
+        fmt.Println("")
+        return errors.New("Duplicate validator keys are suspected.")
+    }
+    return nil
+
+}
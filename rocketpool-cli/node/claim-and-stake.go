@@ -0,0 +1,130 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+
+func nodeClaimAndStakeRpl(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // If a custom nonce is set, print the multi-transaction warning
+    if c.GlobalUint64("nonce") != 0 {
+        cliutils.PrintMultiTransactionNonceWarning(rp)
+    }
+
+    // Check for rewards
+    canClaim, err := rp.CanNodeClaimRpl()
+    if err != nil {
+        return err
+    }
+    if !canClaim.CanClaim {
+        fmt.Fprintln(rp.Output(), "The node does not have any available RPL rewards to claim.")
+        return nil
+    }
+
+    // Get stake amount
+    stakeAmountWei := new(big.Int).Mul(canClaim.RplAmount, new(big.Int).SetUint64(c.Uint64("percent")))
+    stakeAmountWei.Div(stakeAmountWei, big.NewInt(100))
+    fmt.Fprintf(rp.Output(), "%.6f RPL is available to claim, of which %d%% (%.6f RPL) will be staked.\n", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6), c.Uint64("percent"), math.RoundDown(eth.WeiToEth(stakeAmountWei), 6))
+    if stakeAmountWei.Sign() == 0 {
+        fmt.Fprintln(rp.Output(), "The amount to stake is too small; please use 'rocketpool node claim-rpl' to claim your rewards without staking.")
+        return nil
+    }
+
+    // Check RPL can be staked; the node's RPL balance is only sufficient once the rewards are claimed
+    canStake, err := rp.CanNodeStakeRpl(stakeAmountWei)
+    if err != nil {
+        return err
+    }
+    if !canStake.InConsensus {
+        fmt.Fprintln(rp.Output(), "Cannot stake RPL:")
+        fmt.Fprintln(rp.Output(), "The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+        return nil
+    }
+
+    // Display gas estimate for the claim, approve & stake transactions
+    if err := rp.PrintMultiTxGasInfo(canClaim.GasInfo, canStake.GasInfo, canStake.StakeGasInfo); err != nil { return err }
+    rp.PrintMultiTxWarning()
+
+    // Prompt for confirmation
+    if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to claim %.6f RPL and stake %.6f RPL? Staked RPL can only be withdrawn after a delay.", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6), math.RoundDown(eth.WeiToEth(stakeAmountWei), 6)))) {
+        return exit.ErrCancelled
+    }
+
+    // Claim rewards
+    claimResponse, err := rp.NodeClaimRpl()
+    if err != nil {
+        return err
+    }
+    fmt.Fprintf(rp.Output(), "Claiming RPL...\n")
+    cliutils.PrintTransactionHash(rp, claimResponse.TxHash)
+    if _, err = rp.WaitForTransaction(claimResponse.TxHash); err != nil {
+        return err
+    }
+    fmt.Fprintf(rp.Output(), "Successfully claimed %.6f RPL in rewards.\n", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6))
+    fmt.Fprintln(rp.Output(), "")
+
+    // If a custom nonce is set, increment it for the next transaction
+    if c.GlobalUint64("nonce") != 0 {
+        rp.IncrementCustomNonce()
+    }
+
+    // Stake claimed RPL
+    stakeTxHash, err := stakeClaimedRpl(c, rp, stakeAmountWei)
+    if err != nil {
+        return fmt.Errorf("Your RPL rewards were claimed (transaction %s), but staking them failed: %w\nThe claimed RPL is in your node wallet; run 'rocketpool node stake-rpl' to stake it.", claimResponse.TxHash.Hex(), err)
+    }
+
+    // Log & return
+    fmt.Fprintf(rp.Output(), "Successfully staked %.6f RPL.\n", math.RoundDown(eth.WeiToEth(stakeAmountWei), 6))
+    fmt.Fprintf(rp.Output(), "Claim transaction: %s\nStake transaction: %s\n", claimResponse.TxHash.Hex(), stakeTxHash.Hex())
+    return nil
+
+}
+
+
+// Approve & stake RPL after it has been claimed
+func stakeClaimedRpl(c *cli.Context, rp *rocketpool.Client, amountWei *big.Int) (common.Hash, error) {
+
+    // Approve RPL for staking
+    response, err := rp.NodeStakeRplApprove(amountWei)
+    if err != nil {
+        return common.Hash{}, err
+    }
+    hash := response.ApproveTxHash
+    fmt.Fprintf(rp.Output(), "Approving RPL for staking...\n")
+    cliutils.PrintTransactionHashNoCancel(rp, hash)
+
+    // If a custom nonce is set, increment it for the next transaction
+    if c.GlobalUint64("nonce") != 0 {
+        rp.IncrementCustomNonce()
+    }
+
+    // Stake RPL
+    stakeResponse, err := rp.NodeStakeRpl(amountWei, hash)
+    if err != nil {
+        return common.Hash{}, err
+    }
+    fmt.Fprintf(rp.Output(), "Staking RPL...\n")
+    cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
+    if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
+        return common.Hash{}, err
+    }
+    return stakeResponse.StakeTxHash, nil
+
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -26,19 +27,18 @@ func nodeClaimRpl(c *cli.Context) error {
         return err
     }
     if canClaim.RplAmount.Cmp(big.NewInt(0)) == 0 {
-        fmt.Println("The node does not have any available RPL rewards to claim.")
+        fmt.Fprintln(rp.Output(), "The node does not have any available RPL rewards to claim.")
         return nil
     } else {
-        fmt.Printf("%.6f RPL is available to claim.\n", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6))
+        fmt.Fprintf(rp.Output(), "%.6f RPL is available to claim.\n", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6))
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canClaim.GasInfo)
+    if err := rp.PrintGasInfo(canClaim.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to claim your RPL?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Claim rewards
@@ -47,14 +47,14 @@ func nodeClaimRpl(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Claiming RPL...\n")
+    fmt.Fprintf(rp.Output(), "Claiming RPL...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully claimed %.6f RPL in rewards.", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6))
+    fmt.Fprintf(rp.Output(), "Successfully claimed %.6f RPL in rewards.", math.RoundDown(eth.WeiToEth(canClaim.RplAmount), 6))
     return nil
 
 }
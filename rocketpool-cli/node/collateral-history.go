@@ -0,0 +1,74 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Config
+const CollateralHistoryTimeFormat = "2006-01-02 15:04"
+
+
+func getCollateralHistory(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get collateral history
+    history, err := rp.NodeCollateralHistory(c.Uint64("days"), c.Uint64("samples"))
+    if err != nil {
+        return err
+    }
+    if len(history.Samples) == 0 {
+        fmt.Println("No collateral history is available.")
+        return nil
+    }
+
+    // Print samples
+    fmt.Printf("%-17s  %-10s  %-14s  %-18s  %-9s  %s\n", "Time", "Block", "RPL Price", "RPL Stake", "Minipools", "Collateral")
+    for _, sample := range history.Samples {
+        collateral := "n/a"
+        if sample.MinipoolCount > 0 {
+            collateral = fmt.Sprintf("%.2f%%", sample.CollateralRatio * 100)
+        }
+        fmt.Printf("%-17s  %-10d  %-14.6f  %-18.6f  %-9d  %s\n",
+            time.Unix(sample.Time, 0).Format(CollateralHistoryTimeFormat),
+            sample.Block,
+            math.RoundDown(eth.WeiToEth(sample.RplPrice), 6),
+            math.RoundDown(eth.WeiToEth(sample.RplStake), 6),
+            sample.MinipoolCount,
+            collateral)
+    }
+    fmt.Println("")
+
+    // Print trend
+    first := history.Samples[0]
+    latest := history.Samples[len(history.Samples) - 1]
+    if latest.MinipoolCount == 0 {
+        fmt.Println("The node does not currently have any minipools to collateralize.")
+        return nil
+    }
+    if first.MinipoolCount > 0 {
+        fmt.Printf("The node's collateral ratio has changed by %+.2f%% since %s.\n", (latest.CollateralRatio - first.CollateralRatio) * 100, time.Unix(first.Time, 0).Format(CollateralHistoryTimeFormat))
+    }
+    if latest.CollateralRatio >= history.MinimumCollateralRatio {
+        fmt.Printf("The node is currently %.2f%% above the minimum collateral ratio of %.2f%%.\n", (latest.CollateralRatio - history.MinimumCollateralRatio) * 100, history.MinimumCollateralRatio * 100)
+    } else {
+        colorReset := "\033[0m"
+        colorRed := "\033[31m"
+        fmt.Printf("%sThe node is below the minimum collateral ratio of %.2f%% and cannot claim RPL rewards until it stakes more RPL.%s\n", colorRed, history.MinimumCollateralRatio * 100, colorReset)
+    }
+
+    // Return
+    return nil
+
+}
+
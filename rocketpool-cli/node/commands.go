@@ -1,6 +1,8 @@
 package node
 
 import (
+	"errors"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -18,12 +20,33 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "status",
                 Aliases:   []string{"s"},
                 Usage:     "Get the node's status",
-                UsageText: "rocketpool node status",
+                UsageText: "rocketpool node status [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "Observe the node at this address instead of the node wallet's address (does not require a wallet)",
+                    },
+                    cli.BoolFlag{
+                        Name:  "address-book, b",
+                        Usage: "Label known addresses using the address book in the Rocket Pool config",
+                    },
+                    cli.StringFlag{
+                        Name:  "format",
+                        Usage: "The output format: 'text' or 'markdown' (a table of the key fields, for support posts)",
+                        Value: "text",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+                    if _, err := cliutils.ValidateStatusFormat("format", c.String("format")); err != nil { return err }
+
                     // Run
                     return getStatus(c)
 
@@ -34,7 +57,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "sync",
                 Aliases:   []string{"y"},
                 Usage:     "Get the sync progress of the eth1 and eth2 clients",
-                UsageText: "rocketpool node sync",
+                UsageText: "rocketpool node sync [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "sync-retries",
+                        Usage: "The number of times to retry a failed client sync check (default: 3, at most 10)",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
@@ -46,6 +75,163 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "health",
+                Usage:     "Check the node's sync state, RPL collateral, disk space and service containers",
+                UsageText: "rocketpool node health [options]",
+                Flags: []cli.Flag{
+                    cli.Float64Flag{
+                        Name:  "min-disk-space",
+                        Usage: "Fail the disk space check if free disk space in GB on the Rocket Pool host is below this",
+                        Value: DefaultHealthMinDiskSpaceGb,
+                    },
+                    cli.Float64Flag{
+                        Name:  "warn-disk-space",
+                        Usage: "Warn if free disk space in GB on the Rocket Pool host is below this",
+                        Value: DefaultHealthWarnDiskSpaceGb,
+                    },
+                    cli.StringSliceFlag{
+                        Name:  "compose-file, f",
+                        Usage: "Optional compose files used to start the Rocket Pool service, to check their containers are running; this flag may be defined multiple times",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getHealth(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "check-duplicate-keys",
+                Usage:     "Check for the node's validator keys being run by another validator client, which risks slashing",
+                UsageText: "rocketpool node check-duplicate-keys [options]",
+                Flags: []cli.Flag{
+                    cli.StringSliceFlag{
+                        Name:  "compose-file, f",
+                        Usage: "Optional compose files used to start the Rocket Pool service, to check whether the validator client is running; this flag may be defined multiple times",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return checkDuplicateKeys(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "test-connectivity",
+                Usage:     "Test connectivity to the eth1 and eth2 providers",
+                UsageText: "rocketpool node test-connectivity",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return testConnectivity(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "collateral-history",
+                Usage:     "Show the node's RPL collateral ratio over time",
+                UsageText: "rocketpool node collateral-history [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "days, d",
+                        Usage: "The number of days of history to sample",
+                        Value: 7,
+                    },
+                    cli.Uint64Flag{
+                        Name:  "samples, s",
+                        Usage: "The number of samples to take over the history window",
+                        Value: 8,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.Uint64("days") == 0 || c.Uint64("samples") == 0 {
+                        return errors.New("The number of days and samples must be greater than 0.")
+                    }
+
+                    // Run
+                    return getCollateralHistory(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "rpl-required",
+                Usage:     "Calculate the additional RPL to stake to run a number of additional minipools",
+                UsageText: "rocketpool node rpl-required minipools",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    minipools, err := cliutils.ValidatePositiveUint("minipool count", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    return getRplRequired(c, minipools)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "registration",
+                Usage:     "Show the block, time & transaction in which the node was registered",
+                UsageText: "rocketpool node registration [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "Find the registration of the node at this address instead of the node wallet's address (does not require a wallet)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
+                    // Run
+                    return getRegistration(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "beacon-version",
+                Usage:     "Get the eth2 beacon client name and version",
+                UsageText: "rocketpool node beacon-version",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getBeaconClientVersion(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "register",
                 Aliases:   []string{"r"},
@@ -87,6 +273,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "force",
                         Usage: "Force update the withdrawal address, bypassing the 'pending' state that requires a confirmation transaction from the new address",
                     },
+                    cli.BoolFlag{
+                        Name:  "dry-run",
+                        Usage: "Simulate setting the withdrawal address and report whether it would succeed & take effect immediately or be pending, without sending a transaction",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -212,6 +402,37 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "claim-and-stake",
+                Usage:     "Claim available RPL rewards and stake a portion of them",
+                UsageText: "rocketpool node claim-and-stake [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "percent, p",
+                        Usage: "The percentage of the claimed RPL to stake",
+                        Value: 100,
+                    },
+                    cli.BoolFlag{
+                        Name:  "yes, y",
+                        Usage: "Automatically confirm RPL claim & stake",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.Uint64("percent") == 0 || c.Uint64("percent") > 100 {
+                        return errors.New("The percentage to stake must be between 1 and 100.")
+                    }
+
+                    // Run
+                    return nodeClaimAndStakeRpl(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "withdraw-rpl",
                 Aliases:   []string{"i"},
@@ -261,6 +482,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "yes, y",
                         Usage: "Automatically confirm deposit",
                     },
+                    cli.BoolFlag{
+                        Name:  "assign-queue",
+                        Usage: "Preview the deposit without making it: show its gas estimate, the deposit pool & minipool queue, and whether it will be assigned ETH immediately or queued",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -284,7 +509,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
             cli.Command{
                 Name:      "send",
                 Aliases:   []string{"n"},
-                Usage:     "Send ETH or tokens from the node account to an address",
+                Usage:     "Send ETH or tokens from the node account to an address; token may be ETH, RPL, fsRPL, rETH or an ERC-20 token contract address",
                 UsageText: "rocketpool node send [options] amount token to",
                 Flags: []cli.Flag{
                     cli.BoolFlag{
@@ -309,6 +534,44 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "pending-transactions",
+                Usage:     "List the node account's pending transactions",
+                UsageText: "rocketpool node pending-transactions",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    return getPendingTransactions(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "cancel-transaction",
+                Usage:     "Cancel a pending transaction by replacing it with a zero-value transaction to the node account at a higher gas price",
+                UsageText: "rocketpool node cancel-transaction [options] nonce",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "yes, y",
+                        Usage: "Automatically confirm transaction cancellation",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    return cancelTransaction(c, nonce)
+
+                },
+            },
+
             cli.Command{
                 Name:      "burn",
                 Aliases:   []string{"b"},
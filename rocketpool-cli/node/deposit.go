@@ -1,14 +1,19 @@
 package node
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -68,9 +73,9 @@ func nodeDeposit(c *cli.Context) error {
         return err
     }
 
-    // Get minimum node fee
+    // Get minimum node fee; previews use the default max slippage unless it is set
     var minNodeFee float64
-    if c.String("max-slippage") == "auto" {
+    if c.String("max-slippage") == "auto" || (c.Bool("assign-queue") && c.String("max-slippage") == "") {
 
         // Use default max slippage
         minNodeFee = nodeFees.NodeFee - DefaultMaxNodeFeeSlippage
@@ -102,26 +107,33 @@ func nodeDeposit(c *cli.Context) error {
         return err
     }
     if !canDeposit.CanDeposit {
-        fmt.Println("Cannot make node deposit:")
-        if canDeposit.InsufficientBalance {
-            fmt.Println("The node's ETH balance is insufficient.")
-        }
+        fmt.Fprintln(rp.Output(), "Cannot make node deposit:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canDeposit.InsufficientBalance, "The node's ETH balance is insufficient.")
         if canDeposit.InsufficientRplStake {
-            fmt.Println("The node has not staked enough RPL to collateralize a new minipool.")
+            fmt.Fprintln(rp.Output(), "The node has not staked enough RPL to collateralize a new minipool.")
         }
         if canDeposit.InvalidAmount {
-            fmt.Println("The deposit amount is invalid.")
+            fmt.Fprintln(rp.Output(), "The deposit amount is invalid.")
         }
         if canDeposit.UnbondedMinipoolsAtMax {
-            fmt.Println("The node cannot create any more unbonded minipools.")
+            fmt.Fprintln(rp.Output(), "The node cannot create any more unbonded minipools.")
         }
         if canDeposit.DepositDisabled {
-            fmt.Println("Node deposits are currently disabled.")
+            fmt.Fprintln(rp.Output(), "Node deposits are currently disabled.")
         }
         if !canDeposit.InConsensus {
-            fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+            fmt.Fprintln(rp.Output(), "The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+        }
+        return balanceErr
+    }
+
+    // Print deposit preview
+    if c.Bool("assign-queue") {
+        position, err := rp.QueuePosition(amountWei)
+        if err != nil {
+            return err
         }
-        return nil
+        return printDepositPreview(rp, amountWei, minNodeFee, canDeposit, position)
     }
 
     // Check to see if eth2 is synced
@@ -130,18 +142,18 @@ func nodeDeposit(c *cli.Context) error {
     colorYellow := "\033[33m"
     syncResponse, err := rp.NodeSync()
     if err != nil {
-        fmt.Printf("%s**WARNING**: Can't verify the sync status of your eth2 client.\nYOU WILL LOSE ETH if your minipool is activated before it is fully synced.\n" +
+        fmt.Fprintf(rp.Output(), "%s**WARNING**: Can't verify the sync status of your eth2 client.\nYOU WILL LOSE ETH if your minipool is activated before it is fully synced.\n" +
         "Reason: %s\n%s", colorRed, err, colorReset)
     } else {
         if !syncResponse.Eth2Synced {
-            fmt.Printf("%s**WARNING**: your eth2 client is still syncing.\nYOU WILL LOSE ETH if your minipool is activated before it is fully synced.\n%s", colorRed, colorReset)
+            fmt.Fprintf(rp.Output(), "%s**WARNING**: your eth2 client is still syncing.\nYOU WILL LOSE ETH if your minipool is activated before it is fully synced.\n%s", colorRed, colorReset)
         } else {
-            fmt.Printf("Your eth2 client is synced, you may safely create a minipool.\n")
+            fmt.Fprintf(rp.Output(), "Your eth2 client is synced, you may safely create a minipool.\n")
         }
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canDeposit.GasInfo)
+    if err := rp.PrintGasInfo(canDeposit.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
@@ -151,8 +163,7 @@ func nodeDeposit(c *cli.Context) error {
         minNodeFee * 100,
         colorYellow,
         colorReset))) {
-            fmt.Println("Cancelled.")
-            return nil
+            return exit.ErrCancelled
     }
 
     // Make deposit
@@ -162,7 +173,7 @@ func nodeDeposit(c *cli.Context) error {
     }
 
     // Log and wait for the minipool address
-    fmt.Printf("Creating minipool...\n")
+    fmt.Fprintf(rp.Output(), "Creating minipool...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     minipoolResponse, err := rp.GetMinipoolAddress(response.TxHash)
     if err != nil {
@@ -170,9 +181,46 @@ func nodeDeposit(c *cli.Context) error {
     }
 
     // Log & return
-    fmt.Printf("The node deposit of %.6f ETH was made successfully.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
-    fmt.Printf("A new minipool was created at %s.\n", minipoolResponse.MinipoolAddress.Hex())
+    fmt.Fprintf(rp.Output(), "The node deposit of %.6f ETH was made successfully.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+    fmt.Fprintf(rp.Output(), "A new minipool was created at %s.\n", minipoolResponse.MinipoolAddress.Hex())
     return nil
 
 }
 
+
+// Print a pre-flight summary of a node deposit: its gas estimate, the deposit pool & queue state, and whether it will be assigned ETH immediately
+func printDepositPreview(rp *rocketpool.Client, amountWei *big.Int, minNodeFee float64, canDeposit api.CanNodeDepositResponse, position api.QueuePositionResponse) error {
+
+    // Deposit
+    fmt.Fprintf(rp.Output(), "Deposit preview (no deposit will be made):\n\n")
+    fmt.Fprintf(rp.Output(), "Deposit:          %.6f ETH (%s deposit minipool)\n", math.RoundDown(eth.WeiToEth(amountWei), 6), position.DepositType.String())
+    fmt.Fprintf(rp.Output(), "Min. commission:  %f%%\n", minNodeFee * 100)
+    fmt.Fprintf(rp.Output(), "Deposit pool:     %.6f ETH\n", math.RoundDown(eth.WeiToEth(position.DepositPoolBalance), 6))
+    fmt.Fprintf(rp.Output(), "Minipool queue:   %d half, %d full, %d empty deposit minipool(s)\n", position.QueueLengths.Half, position.QueueLengths.Full, position.QueueLengths.Empty)
+    fmt.Fprintln(rp.Output(), "")
+
+    // Gas; the preview never sends a transaction, so it continues if only the estimate was requested
+    if err := rp.PrintGasInfo(canDeposit.GasInfo); err != nil && !errors.Is(err, rocketpool.ErrEstimateOnly) {
+        return err
+    }
+    fmt.Fprintln(rp.Output(), "")
+
+    // Assignment & timing
+    if position.Shortfall.Sign() == 0 {
+        fmt.Fprintln(rp.Output(), "The deposit pool has enough ETH for the minipools ahead of it, so the new minipool will be assigned ETH immediately.")
+    } else {
+        fmt.Fprintf(rp.Output(), "The new minipool will be queued at position %d, and assigned ETH once the deposit pool gains another %.6f ETH.\n", position.Position, math.RoundDown(eth.WeiToEth(position.Shortfall), 6))
+        period := time.Duration(position.DepositRatePeriod) * time.Second
+        if position.WaitEstimated {
+            wait := time.Duration(position.EstimatedWait) * time.Second
+            fmt.Fprintf(rp.Output(), "At the deposit rate of the last %.1f days, this would take roughly %.1f days.\n", period.Hours() / 24, wait.Hours() / 24)
+        } else {
+            fmt.Fprintf(rp.Output(), "No ETH was deposited into the deposit pool in the last %.1f days, so the wait can't be estimated.\n", period.Hours() / 24)
+        }
+    }
+    if amountWei.Cmp(eth.EthToWei(32)) == 0 {
+        fmt.Fprintln(rp.Output(), "As a full deposit, the minipool begins staking immediately either way; the ETH assigned to it is refunded to the node.")
+    }
+    return nil
+
+}
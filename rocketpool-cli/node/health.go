@@ -0,0 +1,154 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
+)
+
+// Config
+const (
+    GigabyteBytes = 1024 * 1024 * 1024
+    DefaultHealthMinDiskSpaceGb = 50
+    DefaultHealthWarnDiskSpaceGb = 100
+    CollateralWarningMargin = 1.2
+)
+
+
+// Health check states, in order of severity
+type healthState int
+const (
+    healthPass healthState = iota
+    healthWarn
+    healthFail
+)
+func (s healthState) String() string {
+    switch s {
+        case healthPass: return "PASS"
+        case healthWarn: return "WARN"
+        default: return "FAIL"
+    }
+}
+
+
+func getHealth(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Run checks
+    worst := healthPass
+    report := func(name string, state healthState, message string) {
+        fmt.Printf("[%s] %-11s %s\n", state.String(), name, message)
+        if state > worst {
+            worst = state
+        }
+    }
+    report(checkSyncHealth(rp))
+    report(checkCollateralHealth(rp))
+    report(checkDiskSpaceHealth(rp, c.Float64("min-disk-space"), c.Float64("warn-disk-space")))
+    report(checkContainerHealth(rp, c.StringSlice("compose-file")))
+
+    // Return exit code for the worst state
+    fmt.Println("")
+    switch worst {
+        case healthWarn:
+            fmt.Println("The node is running, but some health checks raised warnings.")
+            return exit.NewError(exit.HealthWarning, nil)
+        case healthFail:
+            fmt.Println("Some node health checks failed.")
+            return exit.NewError(exit.HealthFailure, nil)
+    }
+    fmt.Println("All node health checks passed.")
+    return nil
+
+}
+
+
+// Check the eth1 & eth2 client sync state
+func checkSyncHealth(rp *rocketpool.Client) (string, healthState, string) {
+    status, err := rp.NodeSync()
+    if err != nil {
+        return "Sync", healthFail, fmt.Sprintf("Could not get client sync state: %s", err.Error())
+    }
+    syncing := []string{}
+    if !status.Eth1Synced {
+        syncing = append(syncing, fmt.Sprintf("eth1 client is syncing (%0.2f%%)", status.Eth1Progress * 100))
+    }
+    if !status.Eth2Synced {
+        if status.Eth2Progress != -1 {
+            syncing = append(syncing, fmt.Sprintf("eth2 client is syncing (%0.2f%%)", status.Eth2Progress * 100))
+        } else {
+            syncing = append(syncing, "eth2 client is syncing")
+        }
+    }
+    if len(syncing) > 0 {
+        return "Sync", healthFail, strings.Join(syncing, ", ")
+    }
+    if status.Eth2UsedFallback {
+        return "Sync", healthWarn, "Clients are synced, but the primary eth2 client could not be reached and the fallback was used"
+    }
+    return "Sync", healthPass, "The eth1 and eth2 clients are synced"
+}
+
+
+// Check the node's RPL collateral against the minimum
+func checkCollateralHealth(rp *rocketpool.Client) (string, healthState, string) {
+    status, err := rp.NodeStatus()
+    if err != nil {
+        return "Collateral", healthFail, fmt.Sprintf("Could not get node status: %s", err.Error())
+    }
+    if !status.Registered {
+        return "Collateral", healthWarn, "The node is not registered with Rocket Pool"
+    }
+    if status.RplStake == nil || status.MinimumRplStake == nil || status.MinimumRplStake.Sign() == 0 {
+        return "Collateral", healthPass, "The node has no minipools requiring RPL collateral"
+    }
+    message := fmt.Sprintf("%.6f RPL staked (%.2f%% collateral), minimum %.6f RPL", eth.WeiToEth(status.RplStake), status.CollateralRatio * 100, eth.WeiToEth(status.MinimumRplStake))
+    if status.RplStake.Cmp(status.MinimumRplStake) < 0 {
+        return "Collateral", healthFail, message
+    }
+    warningStake, _ := new(big.Float).Mul(new(big.Float).SetInt(status.MinimumRplStake), big.NewFloat(CollateralWarningMargin)).Int(nil)
+    if status.RplStake.Cmp(warningStake) < 0 {
+        return "Collateral", healthWarn, message + " - close to the minimum"
+    }
+    return "Collateral", healthPass, message
+}
+
+
+// Check the available disk space on the Rocket Pool host
+func checkDiskSpaceHealth(rp *rocketpool.Client, minGb, warnGb float64) (string, healthState, string) {
+    availableBytes, err := rp.GetDiskSpace()
+    if err != nil {
+        return "Disk space", healthFail, err.Error()
+    }
+    availableGb := float64(availableBytes) / GigabyteBytes
+    if availableGb < minGb {
+        return "Disk space", healthFail, fmt.Sprintf("%.1f GB available, below the minimum of %.1f GB", availableGb, minGb)
+    }
+    if availableGb < warnGb {
+        return "Disk space", healthWarn, fmt.Sprintf("%.1f GB available, below the warning threshold of %.1f GB", availableGb, warnGb)
+    }
+    return "Disk space", healthPass, fmt.Sprintf("%.1f GB available", availableGb)
+}
+
+
+// Check that all Rocket Pool service containers are running
+func checkContainerHealth(rp *rocketpool.Client, composeFiles []string) (string, healthState, string) {
+    stopped, err := rp.GetStoppedServices(composeFiles)
+    if err != nil {
+        return "Containers", healthFail, err.Error()
+    }
+    if len(stopped) > 0 {
+        return "Containers", healthFail, fmt.Sprintf("Not running: %s", strings.Join(stopped, ", "))
+    }
+    return "Containers", healthPass, "All service containers are running"
+}
@@ -0,0 +1,94 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
+)
+
+
+func getPendingTransactions(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get pending transactions
+    pending, err := rp.NodePendingTransactions()
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if len(pending.PendingNonces) == 0 {
+        fmt.Fprintf(rp.Output(), "The node account has no pending transactions (next nonce: %d).\n", pending.PendingNonce)
+        return nil
+    }
+    fmt.Fprintf(rp.Output(), "The node account has %d pending transaction(s):\n", len(pending.PendingNonces))
+    for _, nonce := range pending.PendingNonces {
+        fmt.Fprintf(rp.Output(), "- Nonce %d\n", nonce)
+    }
+    fmt.Fprintln(rp.Output(), "")
+    fmt.Fprintln(rp.Output(), "A stuck transaction can be cancelled with 'rocketpool node cancel-transaction <nonce>'.")
+    return nil
+
+}
+
+
+func cancelTransaction(c *cli.Context, nonce uint64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Check the transaction can be cancelled
+    canCancel, err := rp.CanCancelTransaction(nonce)
+    if err != nil {
+        return err
+    }
+    if !canCancel.CanCancel {
+        fmt.Fprintln(rp.Output(), "Cannot cancel the transaction:")
+        if canCancel.NotPending {
+            fmt.Fprintln(rp.Output(), canCancel.NotPendingReason)
+        }
+        return nil
+    }
+
+    // Display gas estimate
+    if err := rp.PrintGasInfo(canCancel.GasInfo); err != nil { return err }
+    if canCancel.PendingGasPrice != nil {
+        fmt.Fprintf(rp.Output(), "The pending transaction's gas price is %.6f gwei; it will be replaced at %.6f gwei.\n", eth.WeiToGwei(canCancel.PendingGasPrice), eth.WeiToGwei(canCancel.GasPrice))
+    } else {
+        fmt.Fprintf(rp.Output(), "The pending transaction's gas price could not be found, so it will be replaced at %.6f gwei.\n", eth.WeiToGwei(canCancel.GasPrice))
+        fmt.Fprintf(rp.Output(), "The replacement will only be accepted if its gas price is at least %d%% higher than the pending transaction's; use the --gasPrice option to raise it if required.\n", canCancel.GasPriceBumpPercent)
+    }
+
+    // Prompt for confirmation
+    if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to replace the pending transaction with nonce %d with a zero-value transaction to the node account?", nonce))) {
+        return exit.ErrCancelled
+    }
+
+    // Cancel transaction
+    response, err := rp.CancelTransaction(nonce)
+    if err != nil {
+        return err
+    }
+
+    fmt.Fprintf(rp.Output(), "Replacing the transaction with nonce %d...\n", nonce)
+    cliutils.PrintTransactionHash(rp, response.TxHash)
+    if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Fprintf(rp.Output(), "Successfully cancelled the transaction with nonce %d.\n", nonce)
+    return nil
+
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -31,23 +32,22 @@ func registerNode(c *cli.Context) error {
         return err
     }
     if !canRegister.CanRegister {
-        fmt.Println("The node cannot be registered:")
+        fmt.Fprintln(rp.Output(), "The node cannot be registered:")
         if canRegister.AlreadyRegistered {
-            fmt.Println("The node is already registered with Rocket Pool.")
+            fmt.Fprintln(rp.Output(), "The node is already registered with Rocket Pool.")
         }
         if canRegister.RegistrationDisabled {
-            fmt.Println("Node registrations are currently disabled.")
+            fmt.Fprintln(rp.Output(), "Node registrations are currently disabled.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canRegister.GasInfo)
+    if err := rp.PrintGasInfo(canRegister.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to register this node?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Register node
@@ -56,14 +56,14 @@ func registerNode(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Registering node...\n")
+    fmt.Fprintf(rp.Output(), "Registering node...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Println("The node was successfully registered with Rocket Pool.")
+    fmt.Fprintln(rp.Output(), "The node was successfully registered with Rocket Pool.")
     return nil
 
 }
@@ -0,0 +1,54 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const RegistrationTimeFormat = "2006-01-02 15:04:05 UTC"
+
+
+func getRegistration(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get node registration
+    fmt.Println("Searching the eth1 chain for the node's registration, this may take a while...")
+    var response api.NodeRegistrationResponse
+    if c.String("node-address") != "" {
+        response, err = rp.ObservedNodeRegistration(common.HexToAddress(c.String("node-address")))
+    } else {
+        response, err = rp.NodeRegistration()
+    }
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    if !response.Registered {
+        fmt.Println("The node is not registered with Rocket Pool.")
+        return nil
+    }
+    if !response.Found {
+        fmt.Println("The node is registered with Rocket Pool, but its registration event could not be found.")
+        return nil
+    }
+    fmt.Printf("The node was registered at block %d", response.Block)
+    if response.Time > 0 {
+        fmt.Printf(" on %s", time.Unix(int64(response.Time), 0).UTC().Format(RegistrationTimeFormat))
+    }
+    fmt.Println(".")
+    fmt.Printf("Registration transaction: %s\n", response.TxHash.Hex())
+    return nil
+
+}
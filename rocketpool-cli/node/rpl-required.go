@@ -0,0 +1,40 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+
+func getRplRequired(c *cli.Context, additionalMinipools uint64) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get required RPL
+    response, err := rp.NodeRplRequired(additionalMinipools)
+    if err != nil {
+        return err
+    }
+
+    // Print & return
+    fmt.Printf("The node has %d minipool(s) and %.6f RPL staked.\n", response.MinipoolCount, math.RoundDown(eth.WeiToEth(response.RplStake), 6))
+    fmt.Printf("At the current RPL price of %.6f ETH, running %d minipool(s) in total requires a minimum stake of %.6f RPL.\n",
+        math.RoundDown(eth.WeiToEth(response.RplPrice), 6),
+        response.MinipoolCount + response.AdditionalMinipools,
+        math.RoundUp(eth.WeiToEth(response.RequiredRplStake), 6))
+    if response.AdditionalRplStake.Sign() > 0 {
+        fmt.Printf("You need to stake an additional %.6f RPL to run %d more minipool(s).\n", math.RoundUp(eth.WeiToEth(response.AdditionalRplStake), 6), response.AdditionalMinipools)
+    } else {
+        fmt.Printf("You already have enough RPL staked to run %d more minipool(s).\n", response.AdditionalMinipools)
+    }
+    return nil
+
+}
@@ -2,6 +2,9 @@ package node
 
 import (
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -9,6 +12,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -20,8 +24,18 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Add
     if err != nil { return err }
     defer rp.Close()
 
-    // Get amount in wei
+    // Get amount in wei, or in the token's base units for ERC-20 tokens
     amountWei := eth.EthToWei(amount)
+    tokenName := token
+    if common.IsHexAddress(token) {
+        tokenInfo, err := rp.NodeTokenInfo(common.HexToAddress(token))
+        if err != nil {
+            return err
+        }
+        amountWei = tokenAmountToBaseUnits(amount, tokenInfo.Decimals)
+        tokenName = fmt.Sprintf("%s (%s)", tokenInfo.Symbol, token)
+        fmt.Fprintf(rp.Output(), "The node has a balance of %s %s.\n", formatTokenAmount(tokenInfo.Balance, tokenInfo.Decimals), tokenName)
+    }
 
     // Check tokens can be sent
     canSend, err := rp.CanNodeSend(amountWei, token)
@@ -29,20 +43,17 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Add
         return err
     }
     if !canSend.CanSend {
-        fmt.Println("Cannot send tokens:")
-        if canSend.InsufficientBalance {
-            fmt.Printf("The node's %s balance is insufficient.\n", token)
-        }
-        return nil
+        fmt.Fprintln(rp.Output(), "Cannot send tokens:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canSend.InsufficientBalance, fmt.Sprintf("The node's %s balance is insufficient.", tokenName))
+        return balanceErr
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canSend.GasInfo)
+    if err := rp.PrintGasInfo(canSend.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
-    if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %.6f %s to %s? This action cannot be undone!", math.RoundDown(eth.WeiToEth(amountWei), 6), token, toAddress.Hex()))) {
-        fmt.Println("Cancelled.")
-        return nil
+    if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to send %s %s to %s? This action cannot be undone!", formatSendAmount(amount, token), tokenName, toAddress.Hex()))) {
+        return exit.ErrCancelled
     }
 
     // Send tokens
@@ -51,15 +62,43 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddress common.Add
         return err
     }
 
-    fmt.Printf("Sending %s to %s...\n", token, toAddress.Hex())
+    fmt.Fprintf(rp.Output(), "Sending %s to %s...\n", tokenName, toAddress.Hex())
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully sent %.6f %s to %s.\n", math.RoundDown(eth.WeiToEth(amountWei), 6), token, toAddress.Hex())
+    fmt.Fprintf(rp.Output(), "Successfully sent %s %s to %s.\n", formatSendAmount(amount, token), tokenName, toAddress.Hex())
     return nil
 
 }
 
+
+// Format an amount to send; ETH & Rocket Pool token amounts are rounded down to 6 decimal places
+func formatSendAmount(amount float64, token string) string {
+    if common.IsHexAddress(token) {
+        return strconv.FormatFloat(amount, 'f', -1, 64)
+    }
+    return fmt.Sprintf("%.6f", math.RoundDown(eth.WeiToEth(eth.EthToWei(amount)), 6))
+}
+
+
+// Convert a token amount to its base units
+func tokenAmountToBaseUnits(amount float64, decimals uint8) *big.Int {
+    scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+    baseUnits, _ := new(big.Float).Mul(big.NewFloat(amount), scale).Int(nil)
+    return baseUnits
+}
+
+
+// Format a token amount in base units, without trailing zeros
+func formatTokenAmount(baseUnits *big.Int, decimals uint8) string {
+    scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+    whole, fraction := new(big.Int).QuoRem(baseUnits, scale, new(big.Int))
+    if decimals == 0 || fraction.Sign() == 0 {
+        return whole.String()
+    }
+    fractionText := strings.TrimRight(fmt.Sprintf("%0*s", int(decimals), fraction.String()), "0")
+    return fmt.Sprintf("%s.%s", whole.String(), fractionText)
+}
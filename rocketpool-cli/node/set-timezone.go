@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -32,12 +33,11 @@ func setTimezoneLocation(c *cli.Context) error {
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canResponse.GasInfo)
+    if err := rp.PrintGasInfo(canResponse.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to set your timezone?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Set node's timezone location
@@ -46,14 +46,14 @@ func setTimezoneLocation(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Setting timezone...\n")
+    fmt.Fprintf(rp.Output(), "Setting timezone...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("The node's timezone location was successfully updated to '%s'.\n", timezoneLocation)
+    fmt.Fprintf(rp.Output(), "The node's timezone location was successfully updated to '%s'.\n", timezoneLocation)
     return nil
 
 }
@@ -1,8 +1,10 @@
 package node
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
@@ -10,6 +12,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -25,17 +28,17 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
     colorRed := "\033[31m"
     colorYellow := "\033[33m"
     var confirm bool
-    fmt.Println("You are about to change your withdrawal address. All future ETH & RPL rewards/refunds will be sent here.")
+    fmt.Fprintln(rp.Output(), "You are about to change your withdrawal address. All future ETH & RPL rewards/refunds will be sent here.")
     if !c.Bool("force") {
         confirm = false
-        fmt.Println("By default, this will put your new withdrawal address into a \"pending\" state.")
-        fmt.Println("Rocket Pool will continue to use your old withdrawal address until you confirm that you own the new address via the Rocket Pool website.")
-        fmt.Println("You will need to use a web3-compatible wallet (such as MetaMask) with your new address to confirm it.")
-        fmt.Printf("%sIf you cannot use such a wallet, or if you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
+        fmt.Fprintln(rp.Output(), "By default, this will put your new withdrawal address into a \"pending\" state.")
+        fmt.Fprintln(rp.Output(), "Rocket Pool will continue to use your old withdrawal address until you confirm that you own the new address via the Rocket Pool website.")
+        fmt.Fprintln(rp.Output(), "You will need to use a web3-compatible wallet (such as MetaMask) with your new address to confirm it.")
+        fmt.Fprintf(rp.Output(), "%sIf you cannot use such a wallet, or if you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
     } else {
         confirm = true
-        fmt.Printf("%sYou have specified the \"--force\" option, so your new address will take effect immediately.\n", colorRed)
-        fmt.Printf("Please ensure that you have the correct address - you will not be able to change this once set!%s\n\n", colorReset)
+        fmt.Fprintf(rp.Output(), "%sYou have specified the \"--force\" option, so your new address will take effect immediately.\n", colorRed)
+        fmt.Fprintf(rp.Output(), "Please ensure that you have the correct address - you will not be able to change this once set!%s\n\n", colorReset)
     }
 
     // Set node's withdrawal address
@@ -44,6 +47,39 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
         return err
     }
 
+    // Check withdrawal address
+    if canResponse.ZeroAddress {
+        return errors.New("The withdrawal address cannot be the zero address - any rewards sent to it would be lost.")
+    }
+    if canResponse.WithdrawalAddressSet {
+        return fmt.Errorf("The node's withdrawal address is already set to %s, so it can only be changed from that address.", canResponse.CurrentWithdrawalAddress.Hex())
+    }
+    if canResponse.WouldRevert {
+        return fmt.Errorf("Setting the withdrawal address would fail: %s", canResponse.RevertReason)
+    }
+
+    // Print the simulation result
+    if c.Bool("dry-run") {
+        fmt.Fprintln(rp.Output(), "Simulation succeeded: setting the withdrawal address would not revert.")
+        if canResponse.Immediate {
+            fmt.Fprintf(rp.Output(), "%s would become the node's withdrawal address immediately.\n", withdrawalAddress.Hex())
+        } else {
+            fmt.Fprintf(rp.Output(), "%s would become the node's pending withdrawal address; %s would remain in use until the new address is confirmed.\n", withdrawalAddress.Hex(), canResponse.CurrentWithdrawalAddress.Hex())
+        }
+        if canResponse.IsContract {
+            fmt.Fprintf(rp.Output(), "%sWARNING: %s is a contract. Unless the contract can receive ETH & RPL and confirm the withdrawal address, your rewards and refunds may be stuck there permanently.%s\n", colorRed, withdrawalAddress.Hex(), colorReset)
+        }
+        if err := rp.PrintGasInfo(canResponse.GasInfo); err != nil { return err }
+        fmt.Fprintln(rp.Output(), "This was a dry run, so no transaction was sent.")
+        return nil
+    }
+    if canResponse.IsContract {
+        fmt.Fprintf(rp.Output(), "%sWARNING: %s is a contract. Unless the contract can receive ETH & RPL and confirm the withdrawal address, your rewards and refunds may be stuck there permanently.%s\n\n", colorRed, withdrawalAddress.Hex(), colorReset)
+        if !cliutils.Confirm("Are you sure you want to use a contract as your withdrawal address?") {
+            return exit.ErrCancelled
+        }
+    }
+
     // Prompt for a test transaction
     if cliutils.Confirm("Would you like to send a test transaction to make sure you have the correct address?") {
         inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddress), "^\\d+(\\.\\d+)?$", "Invalid amount")
@@ -52,32 +88,52 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
             return fmt.Errorf("Invalid test amount '%s': %w\n", inputAmount, err)
         }
         amountWei := eth.EthToWei(testAmount)
-        response, err := rp.NodeSend(amountWei, "eth", withdrawalAddress)
+
+        // Check the test transaction & display the total gas estimate for both transactions
+        canSend, err := rp.CanNodeSend(amountWei, "eth")
         if err != nil {
             return err
         }
+        if !canSend.CanSend {
+            if canSend.InsufficientBalance {
+                return exit.NewError(exit.InsufficientBalance, errors.New("The node's ETH balance is insufficient for the test transaction."))
+            }
+            return errors.New("Cannot send the test transaction.")
+        }
+        if err := rp.PrintMultiTxGasInfo(canSend.GasInfo, canResponse.GasInfo); err != nil { return err }
 
         if !cliutils.Confirm(fmt.Sprintf("Please confirm you want to send %f ETH to %s.", testAmount, withdrawalAddress)) {
-            fmt.Println("Cancelled.")
-            return nil
+            return exit.ErrCancelled
         }
 
-        fmt.Printf("Sending ETH to %s...\n", withdrawalAddress.Hex())
+        response, err := rp.NodeSend(amountWei, "eth", withdrawalAddress)
+        if err != nil {
+            return err
+        }
+
+        fmt.Fprintf(rp.Output(), "Sending ETH to %s...\n", withdrawalAddress.Hex())
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
             return err
         }
 
-        fmt.Printf("Successfully sent the test transaction.\nPlease verify that your withdrawal address received it before confirming it below.\n\n")
+        fmt.Fprintf(rp.Output(), "Successfully sent the test transaction.\nPlease verify that your withdrawal address received it before confirming it below.\n\n")
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canResponse.GasInfo)
+    if err := rp.PrintGasInfo(canResponse.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !cliutils.Confirm(fmt.Sprintf("Are you sure you want to set your node's withdrawal address to %s?", withdrawalAddress.Hex())) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
+    }
+
+    // Require the address to be re-entered when it will take effect immediately
+    if confirm {
+        reenteredAddress := cliutils.Prompt("Please re-type the full withdrawal address to confirm it:", "^\\s*0x[0-9a-fA-F]{40}\\s*$", "Please enter a full address, including the '0x' prefix")
+        if !strings.EqualFold(strings.TrimSpace(reenteredAddress), withdrawalAddress.Hex()) {
+            return exit.NewError(exit.Cancelled, fmt.Errorf("The address you entered does not match %s, so the withdrawal address was not changed.", withdrawalAddress.Hex()))
+        }
     }
 
     // Set node's withdrawal address
@@ -86,14 +142,14 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address) erro
         return err
     }
 
-    fmt.Printf("Setting withdrawal address...\n")
+    fmt.Fprintf(rp.Output(), "Setting withdrawal address...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("The node's withdrawal address was successfully set to %s.\n", withdrawalAddress.Hex())
+    fmt.Fprintf(rp.Output(), "The node's withdrawal address was successfully set to %s.\n", withdrawalAddress.Hex())
     return nil
 
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -29,7 +30,7 @@ func nodeStakeRpl(c *cli.Context) error {
 
     // If a custom nonce is set, print the multi-transaction warning
     if c.GlobalUint64("nonce") != 0 {
-        cliutils.PrintMultiTransactionNonceWarning()
+        cliutils.PrintMultiTransactionNonceWarning(rp)
     }
 
     // Check for fixed-supply RPL balance
@@ -46,12 +47,11 @@ func nodeStakeRpl(c *cli.Context) error {
             }
 
             // Display gas estimate
-            rp.PrintGasInfo(canSwap.GasInfo)
+            if err := rp.PrintGasInfo(canSwap.GasInfo); err != nil { return err }
         
             // Prompt for confirmation
             if !(c.Bool("yes") || cliutils.Confirm("Do you accept this gas cost?")) {
-                fmt.Println("Cancelled.")
-                return nil
+                return exit.ErrCancelled
             }
 
             // Approve RPL for swapping
@@ -60,7 +60,7 @@ func nodeStakeRpl(c *cli.Context) error {
                 return err
             }
             hash := response.ApproveTxHash
-            fmt.Printf("Approving old RPL for swap...\n")
+            fmt.Fprintf(rp.Output(), "Approving old RPL for swap...\n")
             cliutils.PrintTransactionHashNoCancel(rp, hash)
 
             // If a custom nonce is set, increment it for the next transaction
@@ -73,7 +73,7 @@ func nodeStakeRpl(c *cli.Context) error {
             if err != nil {
                 return err
             }
-            fmt.Printf("Swapping old RPL for new RPL...\n")
+            fmt.Fprintf(rp.Output(), "Swapping old RPL for new RPL...\n")
             cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
             if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
                 return err
@@ -85,8 +85,8 @@ func nodeStakeRpl(c *cli.Context) error {
             }
 
             // Log
-            fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
-            fmt.Println("")
+            fmt.Fprintf(rp.Output(), "Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
+            fmt.Fprintln(rp.Output(), "")
 
             // Get new account RPL balance
             rplBalance.Add(status.AccountBalances.RPL, status.AccountBalances.FixedSupplyRPL)
@@ -171,24 +171,21 @@ func nodeStakeRpl(c *cli.Context) error {
         return err
     }
     if !canStake.CanStake {
-        fmt.Println("Cannot stake RPL:")
-        if canStake.InsufficientBalance {
-            fmt.Println("The node's RPL balance is insufficient.")
-        }
+        fmt.Fprintln(rp.Output(), "Cannot stake RPL:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canStake.InsufficientBalance, "The node's RPL balance is insufficient.")
         if !canStake.InConsensus {
-            fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+            fmt.Fprintln(rp.Output(), "The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
         }
-        return nil
+        return balanceErr
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canStake.GasInfo)
+    if err := rp.PrintGasInfo(canStake.GasInfo); err != nil { return err }
     rp.PrintMultiTxWarning()
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stake %.6f RPL? Staked RPL can only be withdrawn after a delay.", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Approve RPL for staking
@@ -197,7 +194,7 @@ func nodeStakeRpl(c *cli.Context) error {
         return err
     }
     hash := response.ApproveTxHash
-    fmt.Printf("Approving RPL for staking...\n")
+    fmt.Fprintf(rp.Output(), "Approving RPL for staking...\n")
     cliutils.PrintTransactionHashNoCancel(rp, hash)
 
     // If a custom nonce is set, increment it for the next transaction
@@ -210,14 +207,14 @@ func nodeStakeRpl(c *cli.Context) error {
     if err != nil {
         return err
     }
-    fmt.Printf("Staking RPL...\n")
+    fmt.Fprintf(rp.Output(), "Staking RPL...\n")
     cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
     if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully staked %.6f RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+    fmt.Fprintf(rp.Output(), "Successfully staked %.6f RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
     return nil
 
 }
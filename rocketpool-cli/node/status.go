@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -21,15 +25,34 @@ func getStatus(c *cli.Context) error {
     defer rp.Close()
 
     // Get node status
-    status, err := rp.NodeStatus()
+    var status api.NodeStatusResponse
+    if c.String("node-address") != "" {
+        status, err = rp.ObservedNodeStatus(common.HexToAddress(c.String("node-address")))
+    } else {
+        status, err = rp.NodeStatus()
+    }
     if err != nil {
         return err
     }
 
+    // Load the address book
+    var cfg *config.RocketPoolConfig
+    if c.Bool("address-book") {
+        mergedConfig, err := rp.LoadMergedConfig()
+        if err != nil { return err }
+        cfg = &mergedConfig
+    }
+
+    // Print as markdown
+    if strings.ToLower(c.String("format")) == "markdown" {
+        printStatusMarkdown(status, cfg)
+        return nil
+    }
+
     // Account address & balances
     fmt.Printf(
         "The node %s has a balance of %.6f ETH and %.6f RPL.\n",
-        status.AccountAddress.Hex(),
+        formatAddress(status.AccountAddress, cfg),
         math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6),
         math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6))
     if status.AccountBalances.FixedSupplyRPL.Cmp(big.NewInt(0)) > 0 {
@@ -43,7 +66,7 @@ func getStatus(c *cli.Context) error {
         if !bytes.Equal(status.AccountAddress.Bytes(), status.WithdrawalAddress.Bytes()) {
             fmt.Printf(
                 "The node's withdrawal address %s has a balance of %.6f ETH and %.6f RPL.\n",
-                status.WithdrawalAddress.Hex(),
+                formatAddress(status.WithdrawalAddress, cfg),
                 math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.ETH), 6),
                 math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.RPL), 6))
         }
@@ -100,10 +123,26 @@ func getStatus(c *cli.Context) error {
             if status.MinipoolCounts.CloseAvailable > 0 {
                 fmt.Printf("* %d dissolved minipool(s) can be closed!\n", status.MinipoolCounts.CloseAvailable)
             }
+            fmt.Println("")
+
+            // Minipool commission rates
+            if status.MinipoolNodeFees.Min == status.MinipoolNodeFees.Max {
+                fmt.Printf("The node's minipools earn a commission rate of %.2f%%.\n", status.MinipoolNodeFees.Min * 100)
+            } else {
+                fmt.Printf("The node's minipools earn commission rates between %.2f%% and %.2f%%.\n", status.MinipoolNodeFees.Min * 100, status.MinipoolNodeFees.Max * 100)
+            }
 
         } else {
             fmt.Println("The node does not have any minipools yet.")
         }
+
+        // Network commission rates
+        fmt.Printf(
+            "The current network commission rate for new minipools is %.2f%% (minimum %.2f%%, target %.2f%%, maximum %.2f%%).\n",
+            status.NodeFee * 100,
+            status.MinNodeFee * 100,
+            status.TargetNodeFee * 100,
+            status.MaxNodeFee * 100)
         
     } else {
         fmt.Println("The node is not registered with Rocket Pool.")
@@ -114,3 +153,37 @@ func getStatus(c *cli.Context) error {
 
 }
 
+
+// Print the node status as a markdown table
+func printStatusMarkdown(status api.NodeStatusResponse, cfg *config.RocketPoolConfig) {
+    fmt.Println("| Field | Value |")
+    fmt.Println("| --- | --- |")
+    row := func(field string, value string) {
+        fmt.Printf("| %s | %s |\n", field, strings.ReplaceAll(value, "|", "\\|"))
+    }
+    row("Node address", formatAddress(status.AccountAddress, cfg))
+    row("Node balance", fmt.Sprintf("%.6f ETH, %.6f RPL", math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6), math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6)))
+    row("Registered", fmt.Sprintf("%t", status.Registered))
+    if !status.Registered {
+        return
+    }
+    if !bytes.Equal(status.AccountAddress.Bytes(), status.WithdrawalAddress.Bytes()) {
+        row("Withdrawal address", formatAddress(status.WithdrawalAddress, cfg))
+        row("Withdrawal balance", fmt.Sprintf("%.6f ETH, %.6f RPL", math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.ETH), 6), math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.RPL), 6)))
+    }
+    row("Timezone", status.TimezoneLocation)
+    row("Oracle DAO member", fmt.Sprintf("%t", status.Trusted))
+    row("RPL stake", fmt.Sprintf("%.6f RPL", math.RoundDown(eth.WeiToEth(status.RplStake), 6)))
+    row("Effective RPL stake", fmt.Sprintf("%.6f RPL", math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6)))
+    row("Minimum RPL stake", fmt.Sprintf("%.6f RPL", math.RoundDown(eth.WeiToEth(status.MinimumRplStake), 6)))
+    row("Collateral ratio", fmt.Sprintf("%.2f%%", status.CollateralRatio * 100))
+    row("Minipool limit", fmt.Sprintf("%d", status.MinipoolLimit))
+    row("Minipools", fmt.Sprintf(
+        "%d total (%d initialized, %d prelaunch, %d staking, %d withdrawable, %d dissolved)",
+        status.MinipoolCounts.Total,
+        status.MinipoolCounts.Initialized,
+        status.MinipoolCounts.Prelaunch,
+        status.MinipoolCounts.Staking,
+        status.MinipoolCounts.Withdrawable,
+        status.MinipoolCounts.Dissolved))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -23,7 +24,7 @@ func nodeSwapRpl(c *cli.Context) error {
 
     // If a custom nonce is set, print the multi-transaction warning
     if c.GlobalUint64("nonce") != 0 {
-        cliutils.PrintMultiTransactionNonceWarning()
+        cliutils.PrintMultiTransactionNonceWarning(rp)
     }
 
     // Get swap amount
@@ -78,21 +79,18 @@ func nodeSwapRpl(c *cli.Context) error {
         return err
     }
     if !canSwap.CanSwap {
-        fmt.Println("Cannot swap RPL:")
-        if canSwap.InsufficientBalance {
-            fmt.Println("The node's old RPL balance is insufficient.")
-        }
-        return nil
+        fmt.Fprintln(rp.Output(), "Cannot swap RPL:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canSwap.InsufficientBalance, "The node's old RPL balance is insufficient.")
+        return balanceErr
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canSwap.GasInfo)
+    if err := rp.PrintGasInfo(canSwap.GasInfo); err != nil { return err }
     rp.PrintMultiTxWarning()
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to swap %.6f old RPL for new RPL?", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Approve RPL for swapping
@@ -101,7 +99,7 @@ func nodeSwapRpl(c *cli.Context) error {
         return err
     }
     hash := response.ApproveTxHash
-    fmt.Printf("Approving old RPL for swap...\n")
+    fmt.Fprintf(rp.Output(), "Approving old RPL for swap...\n")
     cliutils.PrintTransactionHashNoCancel(rp, hash)
 
     // If a custom nonce is set, increment it for the next transaction
@@ -114,14 +112,14 @@ func nodeSwapRpl(c *cli.Context) error {
     if err != nil {
         return err
     }
-    fmt.Printf("Swapping old RPL for new RPL...\n")
+    fmt.Fprintf(rp.Output(), "Swapping old RPL for new RPL...\n")
     cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
     if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+    fmt.Fprintf(rp.Output(), "Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
     return nil
 
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -17,7 +19,12 @@ func getSyncProgress(c *cli.Context) error {
     defer rp.Close()
 
     // Get node status
-    status, err := rp.NodeSync()
+    var status api.NodeSyncProgressResponse
+    if c.IsSet("sync-retries") {
+        status, err = rp.NodeSyncWithRetries(c.Uint64("sync-retries"))
+    } else {
+        status, err = rp.NodeSync()
+    }
     if err != nil {
         return err
     }
@@ -32,11 +39,23 @@ func getSyncProgress(c *cli.Context) error {
     // Print eth2 status
     if status.Eth2Synced {
         fmt.Print("Your eth2 client is fully synced.\n")
+    } else if status.Eth2Backfilling && status.Eth2BackfillProgress != -1 {
+        fmt.Printf("Your eth2 client was checkpoint synced and has reached the chain head, but is still backfilling historical blocks (%0.2f%%).\n", status.Eth2BackfillProgress * 100)
+    } else if status.Eth2Backfilling {
+        fmt.Print("Your eth2 client was checkpoint synced and has reached the chain head, but is still backfilling historical blocks.\n")
     } else if status.Eth2Progress != -1 {
         fmt.Printf("Your eth2 client is still syncing (%0.2f%%).\n", status.Eth2Progress * 100)
     } else {
         fmt.Print("Your eth2 client is still syncing (but does not provide its progress).\n")
     }
+    if status.Eth2UsedFallback {
+        fmt.Print("Your primary eth2 client could not be reached, so the fallback eth2 client was used.\n")
+    }
+
+    // Return not synced exit code if either client is still syncing
+    if !status.Eth1Synced || !status.Eth2Synced {
+        return exit.NewError(exit.NotSynced, nil)
+    }
 
     // Return
     return nil
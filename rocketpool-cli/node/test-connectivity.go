@@ -0,0 +1,57 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
+)
+
+
+func testConnectivity(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Test connectivity
+    response, err := rp.NodeTestConnectivity()
+    if err != nil {
+        return err
+    }
+
+    // Print eth1 status
+    if response.Eth1Reachable {
+        fmt.Printf("Your eth1 provider is reachable (latency %s).\n", response.Eth1Latency)
+        fmt.Printf("Network ID: %d, chain ID: %d\n", response.Eth1NetworkID, response.Eth1ChainID)
+        if response.Eth1ClientVersion != "" {
+            fmt.Printf("Client: %s (%s)\n", response.Eth1ClientType, response.Eth1ClientVersion)
+        } else {
+            fmt.Println("Client: unknown (the provider does not report its version)")
+        }
+        if !response.Eth1ChainIDMatch {
+            fmt.Printf("WARNING: Your eth1 provider's chain ID does not match the selected network (expected %d).\n", response.Eth1ExpectedChainID)
+        }
+    } else {
+        fmt.Printf("Your eth1 provider is not reachable: %s\n", response.Eth1Error)
+    }
+
+    // Print eth2 status
+    if response.Eth2Reachable {
+        fmt.Printf("Your eth2 provider is reachable (latency %s).\n", response.Eth2Latency)
+    } else {
+        fmt.Printf("Your eth2 provider is not reachable: %s\n", response.Eth2Error)
+    }
+
+    // Return error exit code if either provider failed
+    if !response.Eth1Reachable || !response.Eth1ChainIDMatch || !response.Eth2Reachable {
+        return exit.NewError(exit.GeneralError, nil)
+    }
+
+    // Return
+    return nil
+
+}
@@ -9,6 +9,9 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -113,3 +116,16 @@ func promptMinNodeFee(networkCurrentNodeFee, networkMinNodeFee float64) float64
 
 }
 
+
+// Format an address with its address book label, if it has one
+func formatAddress(address common.Address, cfg *config.RocketPoolConfig) string {
+    if cfg == nil {
+        return address.Hex()
+    }
+    label := cfg.GetAddressLabel(address.Hex())
+    if label == "" {
+        return address.Hex()
+    }
+    return fmt.Sprintf("%s (%s)", label, address.Hex())
+}
+
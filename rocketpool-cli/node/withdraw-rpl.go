@@ -10,6 +10,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -84,29 +85,26 @@ func nodeWithdrawRpl(c *cli.Context) error {
         return err
     }
     if !canWithdraw.CanWithdraw {
-        fmt.Println("Cannot withdraw staked RPL:")
-        if canWithdraw.InsufficientBalance {
-            fmt.Println("The node's staked RPL balance is insufficient.")
-        }
+        fmt.Fprintln(rp.Output(), "Cannot withdraw staked RPL:")
+        balanceErr := cliutils.PrintInsufficientBalance(rp, canWithdraw.InsufficientBalance, "The node's staked RPL balance is insufficient.")
         if canWithdraw.MinipoolsUndercollateralized {
-            fmt.Println("Remaining staked RPL is not enough to collateralize the node's minipools.")
+            fmt.Fprintln(rp.Output(), "Remaining staked RPL is not enough to collateralize the node's minipools.")
         }
         if canWithdraw.WithdrawalDelayActive {
-            fmt.Println("The withdrawal delay period has not passed.")
+            fmt.Fprintln(rp.Output(), "The withdrawal delay period has not passed.")
         }
         if !canWithdraw.InConsensus {
-            fmt.Println("The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
+            fmt.Fprintln(rp.Output(), "The RPL price and total effective staked RPL of the network are still being voted on by the Oracle DAO.\nPlease try again in a few minutes.")
         }
-        return nil
+        return balanceErr
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canWithdraw.GasInfo)
+    if err := rp.PrintGasInfo(canWithdraw.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to withdraw %.6f staked RPL? This may decrease your node's RPL rewards.", math.RoundDown(eth.WeiToEth(amountWei), 6)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Withdraw RPL
@@ -115,14 +113,14 @@ func nodeWithdrawRpl(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Withdrawing RPL...\n")
+    fmt.Fprintf(rp.Output(), "Withdrawing RPL...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully withdrew %.6f staked RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+    fmt.Fprintf(rp.Output(), "Successfully withdrew %.6f staked RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
     return nil
 
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -43,7 +44,7 @@ func cancelProposal(c *cli.Context) error {
 
     // Check for cancelable proposals
     if len(cancelableProposals) == 0 {
-        fmt.Println("No proposals can be cancelled.")
+        fmt.Fprintln(rp.Output(), "No proposals can be cancelled.")
         return nil
     }
 
@@ -87,12 +88,11 @@ func cancelProposal(c *cli.Context) error {
     if err != nil {
         return err
     }
-    rp.PrintGasInfo(canResponse.GasInfo)
+    if err := rp.PrintGasInfo(canResponse.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to cancel proposal %d?", selectedProposal.ID))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Cancel proposal
@@ -101,14 +101,14 @@ func cancelProposal(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Canceling proposal...\n")
+    fmt.Fprintf(rp.Output(), "Canceling proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully cancelled proposal %d.\n", selectedProposal.ID)
+    fmt.Fprintf(rp.Output(), "Successfully cancelled proposal %d.\n", selectedProposal.ID)
     return nil
 
 }
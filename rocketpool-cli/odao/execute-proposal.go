@@ -11,6 +11,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -37,7 +38,7 @@ func executeProposal(c *cli.Context) error {
 
     // Check for executable proposals
     if len(executableProposals) == 0 {
-        fmt.Println("No proposals can be executed.")
+        fmt.Fprintln(rp.Output(), "No proposals can be executed.")
         return nil
     }
 
@@ -94,7 +95,7 @@ func executeProposal(c *cli.Context) error {
     for _, proposal := range selectedProposals {
         canResponse, err := rp.CanExecuteTNDAOProposal(proposal.ID)
         if err != nil {
-            fmt.Printf("WARNING: Couldn't get gas price for execute transaction (%s)", err)
+            fmt.Fprintf(rp.Output(), "WARNING: Couldn't get gas price for execute transaction (%s)", err)
             break
         } else {
             gasInfo = canResponse.GasInfo
@@ -104,28 +105,27 @@ func executeProposal(c *cli.Context) error {
     gasInfo.EstGasLimit = totalGas
 
     // Display gas estimate
-    rp.PrintGasInfo(gasInfo)
+    if err := rp.PrintGasInfo(gasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to execute %d proposals?", len(selectedProposals)))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Execute proposals
     for _, proposal := range selectedProposals {
         response, err := rp.ExecuteTNDAOProposal(proposal.ID)
         if err != nil {
-            fmt.Printf("Could not execute proposal %d: %s.\n", proposal.ID, err)
+            fmt.Fprintf(rp.Output(), "Could not execute proposal %d: %s.\n", proposal.ID, err)
             continue
         }
     
-        fmt.Printf("Executing proposal...\n")
+        fmt.Fprintf(rp.Output(), "Executing proposal...\n")
         cliutils.PrintTransactionHash(rp, response.TxHash)
         if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-            fmt.Printf("Could not execute proposal %d: %s.\n", proposal.ID, err)
+            fmt.Fprintf(rp.Output(), "Could not execute proposal %d: %s.\n", proposal.ID, err)
         } else {
-            fmt.Printf("Successfully executed proposal %d.\n", proposal.ID)
+            fmt.Fprintf(rp.Output(), "Successfully executed proposal %d.\n", proposal.ID)
         }
     }
 
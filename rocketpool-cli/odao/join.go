@@ -9,6 +9,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -28,7 +29,7 @@ func join(c *cli.Context) error {
 
     // If a custom nonce is set, print the multi-transaction warning
     if c.GlobalUint64("nonce") != 0 {
-        cliutils.PrintMultiTransactionNonceWarning()
+        cliutils.PrintMultiTransactionNonceWarning(rp)
     }
 
     // Check for fixed-supply RPL balance
@@ -43,7 +44,7 @@ func join(c *cli.Context) error {
                 return err
             }
             hash := response.ApproveTxHash
-            fmt.Printf("Approving old RPL for swap...\n")
+            fmt.Fprintf(rp.Output(), "Approving old RPL for swap...\n")
             cliutils.PrintTransactionHashNoCancel(rp, hash)
 
             // If a custom nonce is set, increment it for the next transaction
@@ -56,7 +57,7 @@ func join(c *cli.Context) error {
             if err != nil {
                 return err
             }
-            fmt.Printf("Swapping old RPL for new RPL...\n")
+            fmt.Fprintf(rp.Output(), "Swapping old RPL for new RPL...\n")
             cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
             if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
                 return err
@@ -68,8 +69,8 @@ func join(c *cli.Context) error {
             }
 
             // log
-            fmt.Printf("Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
-            fmt.Println("")
+            fmt.Fprintf(rp.Output(), "Successfully swapped %.6f old RPL for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
+            fmt.Fprintln(rp.Output(), "")
 
         }
 
@@ -81,27 +82,23 @@ func join(c *cli.Context) error {
         return err
     }
     if !canJoin.CanJoin {
-        fmt.Println("Cannot join the oracle DAO:")
+        fmt.Fprintln(rp.Output(), "Cannot join the oracle DAO:")
         if canJoin.ProposalExpired {
-            fmt.Println("The proposal for you to join the oracle DAO does not exist or has expired.")
+            fmt.Fprintln(rp.Output(), "The proposal for you to join the oracle DAO does not exist or has expired.")
         }
         if canJoin.AlreadyMember {
-            fmt.Println("The node is already a member of the oracle DAO.")
+            fmt.Fprintln(rp.Output(), "The node is already a member of the oracle DAO.")
         }
-        if canJoin.InsufficientRplBalance {
-            fmt.Println("The node does not have enough RPL to pay the RPL bond.")
-        }
-        return nil
+        return cliutils.PrintInsufficientBalance(rp, canJoin.InsufficientRplBalance, "The node does not have enough RPL to pay the RPL bond.")
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canJoin.GasInfo)
+    if err := rp.PrintGasInfo(canJoin.GasInfo); err != nil { return err }
     rp.PrintMultiTxWarning()
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to join the oracle DAO? Your RPL bond will be locked until you leave.")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
     
     // Approve RPL for joining the ODAO
@@ -110,7 +107,7 @@ func join(c *cli.Context) error {
         return err
     }
     hash := response.ApproveTxHash
-    fmt.Printf("Approving RPL for joining the Oracle DAO...\n")
+    fmt.Fprintf(rp.Output(), "Approving RPL for joining the Oracle DAO...\n")
     cliutils.PrintTransactionHashNoCancel(rp, hash)
 
     // If a custom nonce is set, increment it for the next transaction
@@ -123,14 +120,14 @@ func join(c *cli.Context) error {
     if err != nil {
         return err
     }
-    fmt.Printf("Joining the ODAO...\n")
+    fmt.Fprintf(rp.Output(), "Joining the ODAO...\n")
     cliutils.PrintTransactionHash(rp, joinResponse.JoinTxHash)
     if _, err = rp.WaitForTransaction(joinResponse.JoinTxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Println("Successfully joined the oracle DAO.")
+    fmt.Fprintln(rp.Output(), "Successfully joined the oracle DAO.")
     return nil
 
 }
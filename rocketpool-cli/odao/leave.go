@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -61,23 +62,22 @@ func leave(c *cli.Context) error {
         return err
     }
     if !canLeave.CanLeave {
-        fmt.Println("Cannot leave the oracle DAO:")
+        fmt.Fprintln(rp.Output(), "Cannot leave the oracle DAO:")
         if canLeave.ProposalExpired {
-            fmt.Println("The proposal for you to leave the oracle DAO does not exist or has expired.")
+            fmt.Fprintln(rp.Output(), "The proposal for you to leave the oracle DAO does not exist or has expired.")
         }
         if canLeave.InsufficientMembers {
-            fmt.Println("There are not enough members in the oracle DAO to allow a member to leave.")
+            fmt.Fprintln(rp.Output(), "There are not enough members in the oracle DAO to allow a member to leave.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canLeave.GasInfo)
+    if err := rp.PrintGasInfo(canLeave.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to leave the oracle DAO and refund your RPL bond to %s? This action cannot be undone!", bondRefundAddress.Hex()))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Leave the oracle DAO
@@ -86,14 +86,14 @@ func leave(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Leaving oracle DAO...\n")
+    fmt.Fprintf(rp.Output(), "Leaving oracle DAO...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Println("Successfully left the oracle DAO.")
+    fmt.Fprintln(rp.Output(), "Successfully left the oracle DAO.")
     return nil
 
 }
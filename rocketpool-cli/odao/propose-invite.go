@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -24,23 +25,22 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose inviting member:")
+        fmt.Fprintln(rp.Output(), "Cannot propose inviting member:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         if canPropose.MemberAlreadyExists {
-            fmt.Printf("The node %s is already a member of the oracle DAO.\n", memberAddress.Hex())
+            fmt.Fprintf(rp.Output(), "The node %s is already a member of the oracle DAO.\n", memberAddress.Hex())
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -49,14 +49,14 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
         return err
     }
 
-    fmt.Printf("Inviting %s to the oracle DAO...\n", memberAddress.Hex())
+    fmt.Fprintf(rp.Output(), "Inviting %s to the oracle DAO...\n", memberAddress.Hex())
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted an invite proposal with ID %d for node %s.\n", response.ProposalId, memberAddress.Hex())
+    fmt.Fprintf(rp.Output(), "Successfully submitted an invite proposal with ID %d for node %s.\n", response.ProposalId, memberAddress.Hex())
     return nil
 
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -92,23 +93,22 @@ func proposeKick(c *cli.Context) error {
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose kicking member:")
+        fmt.Fprintln(rp.Output(), "Cannot propose kicking member:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         if canPropose.InsufficientRplBond {
-            fmt.Printf("The fine amount of %.6f RPL is greater than the member's bond of %.6f RPL.\n", math.RoundDown(eth.WeiToEth(fineAmountWei), 6), math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6))
+            fmt.Fprintf(rp.Output(), "The fine amount of %.6f RPL is greater than the member's bond of %.6f RPL.\n", math.RoundDown(eth.WeiToEth(fineAmountWei), 6), math.RoundDown(eth.WeiToEth(selectedMember.RPLBondAmount), 6))
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -117,14 +117,14 @@ func proposeKick(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Kicking %s from the oracle DAO...\n", selectedMember.Address.Hex())
+    fmt.Fprintf(rp.Output(), "Kicking %s from the oracle DAO...\n", selectedMember.Address.Hex())
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a kick proposal with ID %d for node %s, with a fine of %.6f RPL.\n", response.ProposalId, selectedMember.Address.Hex(), math.RoundDown(eth.WeiToEth(fineAmountWei), 6))
+    fmt.Fprintf(rp.Output(), "Successfully submitted a kick proposal with ID %d for node %s, with a fine of %.6f RPL.\n", response.ProposalId, selectedMember.Address.Hex(), math.RoundDown(eth.WeiToEth(fineAmountWei), 6))
     return nil
 
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -23,23 +24,22 @@ func proposeLeave(c *cli.Context) error {
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose leaving:")
+        fmt.Fprintln(rp.Output(), "Cannot propose leaving:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         if canPropose.InsufficientMembers {
-            fmt.Println("There are not enough members in the oracle DAO to allow a member to leave.")
+            fmt.Fprintln(rp.Output(), "There are not enough members in the oracle DAO to allow a member to leave.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -48,14 +48,14 @@ func proposeLeave(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Proposing a leave from the oracle DAO...\n")
+    fmt.Fprintf(rp.Output(), "Proposing a leave from the oracle DAO...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a leave proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a leave proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -24,20 +25,19 @@ func proposeSettingMembersQuorum(c *cli.Context, quorumPercent float64) error {
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -46,14 +46,14 @@ func proposeSettingMembersQuorum(c *cli.Context, quorumPercent float64) error {
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a members.quorum setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a members.quorum setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -72,20 +72,19 @@ func proposeSettingMembersRplBond(c *cli.Context, bondAmountEth float64) error {
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -94,14 +93,14 @@ func proposeSettingMembersRplBond(c *cli.Context, bondAmountEth float64) error {
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a members.rplbond setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a members.rplbond setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -120,20 +119,19 @@ func proposeSettingMinipoolUnbondedMax(c *cli.Context, unbondedMinipoolMax uint6
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -142,14 +140,14 @@ func proposeSettingMinipoolUnbondedMax(c *cli.Context, unbondedMinipoolMax uint6
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a members.minipool.unbonded.max setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a members.minipool.unbonded.max setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -168,20 +166,19 @@ func proposeSettingProposalCooldown(c *cli.Context, proposalCooldownBlocks uint6
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -190,14 +187,14 @@ func proposeSettingProposalCooldown(c *cli.Context, proposalCooldownBlocks uint6
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a proposal.cooldown setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a proposal.cooldown setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -216,20 +213,19 @@ func proposeSettingProposalVoteBlocks(c *cli.Context, proposalVoteBlocks uint64)
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -238,14 +234,14 @@ func proposeSettingProposalVoteBlocks(c *cli.Context, proposalVoteBlocks uint64)
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a proposal.vote.blocks setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a proposal.vote.blocks setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -264,20 +260,19 @@ func proposeSettingProposalVoteDelayBlocks(c *cli.Context, proposalDelayBlocks u
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -286,14 +281,14 @@ func proposeSettingProposalVoteDelayBlocks(c *cli.Context, proposalDelayBlocks u
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a proposal.vote.delay.blocks setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a proposal.vote.delay.blocks setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -312,20 +307,19 @@ func proposeSettingProposalExecuteBlocks(c *cli.Context, proposalExecuteBlocks u
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -334,14 +328,14 @@ func proposeSettingProposalExecuteBlocks(c *cli.Context, proposalExecuteBlocks u
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a proposal.execute.blocks setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a proposal.execute.blocks setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -360,20 +354,19 @@ func proposeSettingProposalActionBlocks(c *cli.Context, proposalActionBlocks uin
         return err
     }
     if !canPropose.CanPropose {
-        fmt.Println("Cannot propose setting update:")
+        fmt.Fprintln(rp.Output(), "Cannot propose setting update:")
         if canPropose.ProposalCooldownActive {
-            fmt.Println("The node must wait for the proposal cooldown period to pass before making another proposal.")
+            fmt.Fprintln(rp.Output(), "The node must wait for the proposal cooldown period to pass before making another proposal.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canPropose.GasInfo)
+    if err := rp.PrintGasInfo(canPropose.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this proposal?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Submit proposal
@@ -382,14 +375,14 @@ func proposeSettingProposalActionBlocks(c *cli.Context, proposalActionBlocks uin
         return err
     }
 
-    fmt.Printf("Submitting proposal...\n")
+    fmt.Fprintf(rp.Output(), "Submitting proposal...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully submitted a proposal.action.blocks setting update proposal with ID %d.\n", response.ProposalId)
+    fmt.Fprintf(rp.Output(), "Successfully submitted a proposal.action.blocks setting update proposal with ID %d.\n", response.ProposalId)
     return nil
 
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -36,7 +37,7 @@ func voteOnProposal(c *cli.Context) error {
 
     // Check for votable proposals
     if len(votableProposals) == 0 {
-        fmt.Println("No proposals can be voted on.")
+        fmt.Fprintln(rp.Output(), "No proposals can be voted on.")
         return nil
     }
 
@@ -111,20 +112,19 @@ func voteOnProposal(c *cli.Context) error {
         return err
     }
     if !canVote.CanVote {
-        fmt.Println("Cannot vote on proposal:")
+        fmt.Fprintln(rp.Output(), "Cannot vote on proposal:")
         if canVote.JoinedAfterCreated {
-            fmt.Println("You cannot vote on proposals created before you joined the oracle DAO.")
+            fmt.Fprintln(rp.Output(), "You cannot vote on proposals created before you joined the oracle DAO.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canVote.GasInfo)
+    if err := rp.PrintGasInfo(canVote.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to vote %s proposal %d? Your vote cannot be changed later.", supportLabel, selectedProposal.ID))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Vote on proposal
@@ -133,14 +133,14 @@ func voteOnProposal(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Submitting vote...\n")
+    fmt.Fprintf(rp.Output(), "Submitting vote...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Printf("Successfully voted %s proposal %d.\n", supportLabel, selectedProposal.ID)
+    fmt.Fprintf(rp.Output(), "Successfully voted %s proposal %d.\n", supportLabel, selectedProposal.ID)
     return nil
 
 }
@@ -31,6 +31,32 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "position",
+                Aliases:   []string{"o"},
+                Usage:     "Get the queue position and estimated wait for ETH assignment of a new minipool",
+                UsageText: "rocketpool queue position [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "amount, a",
+                        Usage: "The amount of ETH the new minipool would be created with (0, 16 or 32)",
+                        Value: "16",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if _, err := cliutils.ValidateDepositEthAmount("deposit amount", c.String("amount")); err != nil { return err }
+
+                    // Run
+                    return getPosition(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "process",
                 Aliases:   []string{"p"},
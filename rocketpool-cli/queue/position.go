@@ -0,0 +1,59 @@
+package queue
+
+import (
+    "fmt"
+    "strconv"
+    "time"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getPosition(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get deposit amount
+    amount, err := strconv.ParseFloat(c.String("amount"), 64)
+    if err != nil {
+        return fmt.Errorf("Invalid deposit amount '%s': %w", c.String("amount"), err)
+    }
+
+    // Get queue position
+    position, err := rp.QueuePosition(eth.EthToWei(amount))
+    if err != nil {
+        return err
+    }
+
+    // Print queue status
+    fmt.Printf("The deposit pool has a balance of %.6f ETH.\n", math.RoundDown(eth.WeiToEth(position.DepositPoolBalance), 6))
+    fmt.Printf("The minipool queue has %d half deposit, %d full deposit and %d empty deposit minipools (assigned in that order).\n", position.QueueLengths.Half, position.QueueLengths.Full, position.QueueLengths.Empty)
+    fmt.Println("")
+
+    // Print position
+    fmt.Printf("A new minipool with a %.0f ETH deposit would join the %s deposit queue at position %d overall.\n", amount, position.DepositType.String(), position.Position)
+    fmt.Printf("It will be assigned ETH once the deposit pool holds %.6f ETH for it and the minipools ahead of it.\n", math.RoundDown(eth.WeiToEth(position.RequiredBalance), 6))
+    if position.Shortfall.Sign() == 0 {
+        fmt.Println("The deposit pool already has enough ETH, so it would be assigned ETH on the next deposit or queue process.")
+        return nil
+    }
+    fmt.Printf("The deposit pool is %.6f ETH short of this.\n", math.RoundDown(eth.WeiToEth(position.Shortfall), 6))
+
+    // Print estimated wait
+    period := time.Duration(position.DepositRatePeriod) * time.Second
+    if !position.WaitEstimated {
+        fmt.Printf("No ETH was deposited into the deposit pool in the last %.1f days, so the wait can't be estimated.\n", period.Hours() / 24)
+        return nil
+    }
+    wait := time.Duration(position.EstimatedWait) * time.Second
+    fmt.Printf("At the rate of the last %.1f days (%.6f ETH deposited), this would take roughly %.1f days.\n", period.Hours() / 24, math.RoundDown(eth.WeiToEth(position.DepositRate), 6), wait.Hours() / 24)
+    fmt.Println("This is only an estimate; deposit rates vary and other minipools may join the queue ahead of yours.")
+    return nil
+
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
@@ -23,26 +24,25 @@ func processQueue(c *cli.Context) error {
         return err
     }
     if !canProcess.CanProcess {
-        fmt.Println("The deposit queue cannot be processed:")
+        fmt.Fprintln(rp.Output(), "The deposit queue cannot be processed:")
         if canProcess.AssignDepositsDisabled {
-            fmt.Println("Deposit assignments are currently disabled.")
+            fmt.Fprintln(rp.Output(), "Deposit assignments are currently disabled.")
         }
         if canProcess.NoMinipoolsAvailable {
-            fmt.Println("No minipools are available for assignment.")
+            fmt.Fprintln(rp.Output(), "No minipools are available for assignment.")
         }
         if canProcess.InsufficientDepositBalance {
-            fmt.Println("The deposit pool has an insufficient balance for assignment.")
+            fmt.Fprintln(rp.Output(), "The deposit pool has an insufficient balance for assignment.")
         }
         return nil
     }
 
     // Display gas estimate
-    rp.PrintGasInfo(canProcess.GasInfo)
+    if err := rp.PrintGasInfo(canProcess.GasInfo); err != nil { return err }
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Do you accept this gas fee?")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Process deposit queue
@@ -51,14 +51,14 @@ func processQueue(c *cli.Context) error {
         return err
     }
 
-    fmt.Printf("Processing queue...\n")
+    fmt.Fprintf(rp.Output(), "Processing queue...\n")
     cliutils.PrintTransactionHash(rp, response.TxHash)
     if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
         return err
     }
 
     // Log & return
-    fmt.Println("The deposit queue was successfully processed.")
+    fmt.Fprintln(rp.Output(), "The deposit queue was successfully processed.")
     return nil
 
 }
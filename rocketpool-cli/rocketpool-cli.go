@@ -1,11 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool-cli/api"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
@@ -14,6 +18,10 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 // Run
@@ -68,6 +76,15 @@ ______           _        _    ______           _
             Name:  "daemon-path, d",
             Usage: "Interact with a Rocket Pool service daemon at a `path` on the host OS, running outside of docker",
         },
+        cli.StringFlag{
+            Name:  "project",
+            Usage: "Override the Rocket Pool docker project `name`, to manage one of several stacks on the same host",
+        },
+        cli.StringFlag{
+            Name:  "api-mode",
+            Usage: "How to call the Rocket Pool API: 'exec' in the running API container, 'run' in a one-shot container, or 'auto' to run a one-shot container only if the API container is not running",
+            Value: "auto",
+        },
         cli.StringFlag{
             Name:  "host, o",
             Usage: "Smart node SSH host `address`",
@@ -88,14 +105,43 @@ ______           _        _    ______           _
             Name:  "known-hosts, n",
             Usage: "Smart node SSH known_hosts `file` (default: current user's ~/.ssh/known_hosts)",
         },
+        cli.BoolFlag{
+            Name:  "insecure-ignore-host-key",
+            Usage: "UNSAFE: disable SSH host key verification; only use this for disposable test hosts, as it allows man-in-the-middle attacks",
+        },
         cli.StringFlag{
             Name:  "gasPrice, g",
-            Usage: "Desired gas price in gwei",
+            Usage: "Desired gas price in gwei (e.g. '30' or '30gwei') or wei (e.g. '30000000000wei')",
         },
         cli.StringFlag{
             Name:  "gasLimit, l",
             Usage: "Desired gas limit",
         },
+        cli.DurationFlag{
+            Name:  "command-timeout",
+            Usage: "Timeout for docker & Rocket Pool service commands, e.g. '2m' (0 for no timeout); does not apply to installation or following logs",
+        },
+        cli.BoolFlag{
+            Name:  "json",
+            Usage: "Print transaction gas estimates to stdout as JSON (amounts in wei) for scripting, with all other output on stderr; combine with a command's '--yes' option to skip its confirmation prompt",
+        },
+        cli.BoolFlag{
+            Name:  "estimate-only",
+            Usage: "Print the gas estimate of a transaction command and exit without sending the transaction",
+        },
+        cli.BoolFlag{
+            Name:  "receipt",
+            Usage: "Print the full receipt of each transaction (status, gas used, effective gas price & logs) as JSON once it is mined",
+        },
+        cli.Uint64Flag{
+            Name:  "confirmations",
+            Usage: "Wait until each transaction is confirmed by this many blocks before treating it as final, re-checking its receipt in case of chain reorgs",
+            Value: 1,
+        },
+        cli.BoolFlag{
+            Name:  "skip-compat-check",
+            Usage: "Advanced: allow running an Eth 2.0 client which is not listed as compatible with the selected Eth 1.0 client (a warning is printed)",
+        },
         cli.Uint64Flag{
             Name: "nonce",
             Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
@@ -103,6 +149,7 @@ ______           _        _    ______           _
     }
 
     // Register commands
+         api.RegisterCommands(app, "api",      []string{})
      auction.RegisterCommands(app, "auction",  []string{"a"})
     minipool.RegisterCommands(app, "minipool", []string{"m"})
      network.RegisterCommands(app, "network",  []string{"e"})
@@ -112,22 +159,75 @@ ______           _        _    ______           _
      service.RegisterCommands(app, "service",  []string{"s"})
       wallet.RegisterCommands(app, "wallet",   []string{"w"})
 
-    // Check user ID
+    // Check user ID & set up output
+    // In JSON mode, stdout is kept for JSON output and everything else is printed to stderr
+    // Command flags aren't parsed yet, so JSON progress output is detected from the arguments
     app.Before = func(c *cli.Context) error {
         if os.Getuid() == 0 && !c.GlobalBool("allow-root") {
             fmt.Fprintln(os.Stderr, "rocketpool should not be run as root. Please try again without 'sudo'.")
             fmt.Fprintln(os.Stderr, "If you want to run rocketpool as root anyway, use the '--allow-root' option to override this warning.")
             os.Exit(1)
         }
+
+        if c.GlobalBool("json") || hasBoolFlag(c.Args(), "--progress-json", "-progress-json") {
+            cliutils.ReserveStdout()
+        }
+        fmt.Fprintln(cliutils.Output(), "")
         return nil
     }
 
     // Run application
-    fmt.Println("")
-    if err := app.Run(os.Args); err != nil {
-        fmt.Println(err)
+    err := translateError(app.Run(os.Args))
+    if errors.Is(err, rocketpool.ErrEstimateOnly) {
+        err = nil
+    }
+    if err != nil && err.Error() != "" {
+        fmt.Fprintln(cliutils.Output(), err)
     }
-    fmt.Println("")
+    fmt.Fprintln(cliutils.Output(), "")
 
+    // Exit with error code
+    if err != nil {
+        os.Exit(exit.GetCode(err))
+    }
+
+}
+
+
+// Check whether a boolean flag was set in a set of arguments, either alone or as name=value
+// Arguments after a "--" terminator are not flags
+func hasBoolFlag(args []string, names ...string) bool {
+    for _, arg := range args {
+        if arg == "--" {
+            return false
+        }
+        for _, name := range names {
+            if arg == name {
+                return true
+            }
+            if strings.HasPrefix(arg, name + "=") {
+                if value, err := strconv.ParseBool(strings.TrimPrefix(arg, name + "=")); err == nil && value {
+                    return true
+                }
+            }
+        }
+    }
+    return false
+}
+
+
+// Translate known daemon errors into actionable messages
+// Daemon errors are matched by the error code passed back in the API response
+func translateError(err error) error {
+    if err == nil {
+        return nil
+    }
+    if errors.Is(err, apitypes.ErrWalletNotInitialized) {
+        return exit.NewError(exit.GetCode(err), errors.New("No wallet found; run 'rocketpool wallet init' or 'rocketpool wallet recover'."))
+    }
+    if errors.Is(err, apitypes.ErrNotSynced) {
+        return exit.NewError(exit.NotSynced, err)
+    }
+    return err
 }
 
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "testing"
+)
+
+
+func TestHasBoolFlag(t *testing.T) {
+    for _, test := range []struct {
+        args []string
+        expected bool
+    }{
+        {[]string{"service", "install", "--progress-json"}, true},
+        {[]string{"service", "install", "-progress-json"}, true},
+        {[]string{"service", "install", "--progress-json=true"}, true},
+        {[]string{"service", "install", "--progress-json=1"}, true},
+        {[]string{"service", "install", "-progress-json=T"}, true},
+        {[]string{"service", "install", "--progress-json=false"}, false},
+        {[]string{"service", "install", "--progress-json=0"}, false},
+        {[]string{"service", "install", "--progress-json=yes"}, false},
+        {[]string{"service", "install", "--progress-jsonl"}, false},
+        {[]string{"service", "install", "--", "--progress-json"}, false},
+        {[]string{"service", "install"}, false},
+    } {
+        if hasBoolFlag(test.args, "--progress-json", "-progress-json") != test.expected {
+            t.Errorf("Expected %t for arguments %v", test.expected, test.args)
+        }
+    }
+}
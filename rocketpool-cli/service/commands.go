@@ -39,6 +39,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "no-deps, d",
                         Usage: "Do not install Operating System dependencies",
                     },
+                    cli.BoolFlag{
+                        Name:  "force",
+                        Usage: "Reinstall the service even if it is already at the requested version",
+                    },
                     cli.StringFlag{
                         Name:  "network, n",
                         Usage: "The Eth 2.0 network to run Rocket Pool on",
@@ -49,12 +53,34 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Usage: "The smart node package version to install",
                         Value: "latest",
                     },
+                    cli.StringFlag{
+                        Name:  "installer-checksum",
+                        Usage: "The expected sha256 checksum of the installation script; installation is aborted if it does not match",
+                    },
+                    cli.BoolFlag{
+                        Name:  "progress-json",
+                        Usage: "Print installation progress as JSON line events (stage, step & message) instead of the installer output, for front-ends; all other output is printed to stderr",
+                    },
+                    cli.Float64Flag{
+                        Name:  "min-disk-space",
+                        Usage: "The minimum free disk space in GB required on the Rocket Pool host",
+                        Value: DefaultMinDiskSpaceGb,
+                    },
+                    cli.BoolFlag{
+                        Name:  "ignore-disk-space",
+                        Usage: "Continue with a warning if free disk space is below the minimum",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
+                    // Validate flags
+                    if c.String("installer-checksum") != "" {
+                        if _, err := cliutils.ValidateSHA256Checksum("installer checksum", c.String("installer-checksum")); err != nil { return err }
+                    }
+
                     // Run command
                     return installService(c)
 
@@ -75,6 +101,109 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                     return configureService(c)
 
                 },
+                Subcommands: []cli.Command{
+
+                    cli.Command{
+                        Name:      "export",
+                        Aliases:   []string{"e"},
+                        Usage:     "Export the Rocket Pool service configuration to a file",
+                        UsageText: "rocketpool service config export [options] path",
+                        Flags: []cli.Flag{
+                            cli.StringFlag{
+                                Name:  "config-format",
+                                Usage: "The format to export the configuration in ('yaml', 'json' or 'toml'; default: by the file extension, or 'yaml')",
+                            },
+                            cli.BoolFlag{
+                                Name:  "include-secrets",
+                                Usage: "Include inline provider credentials, API keys & notification tokens in the export instead of masking them",
+                            },
+                        },
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                            path := c.Args().Get(0)
+
+                            // Run command
+                            return exportConfig(c, path)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "import",
+                        Aliases:   []string{"i"},
+                        Usage:     "Import the Rocket Pool service configuration from an exported file",
+                        UsageText: "rocketpool service config import [options] path",
+                        Flags: []cli.Flag{
+                            cli.StringFlag{
+                                Name:  "config-format",
+                                Usage: "The format of the exported configuration ('yaml', 'json' or 'toml'; default: by the file extension, or 'yaml')",
+                            },
+                            cli.BoolFlag{
+                                Name:  "yes, y",
+                                Usage: "Automatically confirm overwriting the existing configuration",
+                            },
+                        },
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                            path := c.Args().Get(0)
+
+                            // Run command
+                            return importConfig(c, path)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "params",
+                        Aliases:   []string{"p"},
+                        Usage:     "View the custom params of the selected Eth 1.0 & Eth 2.0 clients",
+                        UsageText: "rocketpool service config params",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                            // Run command
+                            return getParams(c)
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "set-param",
+                        Usage:     "Set a custom param of the selected Eth 1.0 or Eth 2.0 client",
+                        UsageText: "rocketpool service config set-param chain param value",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 3); err != nil { return err }
+
+                            // Run command
+                            return setParam(c, c.Args().Get(0), c.Args().Get(1), c.Args().Get(2))
+
+                        },
+                    },
+
+                    cli.Command{
+                        Name:      "unset-param",
+                        Usage:     "Reset a custom param of the selected Eth 1.0 or Eth 2.0 client to its default value",
+                        UsageText: "rocketpool service config unset-param chain param",
+                        Action: func(c *cli.Context) error {
+
+                            // Validate args
+                            if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+
+                            // Run command
+                            return unsetParam(c, c.Args().Get(0), c.Args().Get(1))
+
+                        },
+                    },
+
+                },
             },
 
             cli.Command{
@@ -97,7 +226,38 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 Name:      "start",
                 Aliases:   []string{"s"},
                 Usage:     "Start the Rocket Pool service",
-                UsageText: "rocketpool service start",
+                UsageText: "rocketpool service start [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                    cli.Float64Flag{
+                        Name:  "min-disk-space",
+                        Usage: "The minimum free disk space in GB required on the Rocket Pool host",
+                        Value: DefaultMinDiskSpaceGb,
+                    },
+                    cli.BoolFlag{
+                        Name:  "ignore-disk-space",
+                        Usage: "Continue with a warning if free disk space is below the minimum",
+                    },
+                    cli.BoolFlag{
+                        Name:  "verify-images",
+                        Usage: "Verify the service images against the expected digests in the image manifest, and refuse to continue on a mismatch",
+                    },
+                    cli.StringFlag{
+                        Name:  "image-manifest",
+                        Usage: "The signed image manifest `path` to verify images against; its signature is read from the same path with a .sig suffix (default: image-digests.yml in the config path)",
+                    },
+                    cli.BoolFlag{
+                        Name:  "require-synced",
+                        Usage: "Start the eth1 & eth2 clients first, and only start the rest of the service once they report synced; use after data loss or a client resync",
+                    },
+                    cli.DurationFlag{
+                        Name:  "sync-timeout",
+                        Usage: "How long to wait for the clients to sync with '--require-synced' before asking whether to start anyway, e.g. '2h' (0 to wait indefinitely)",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
@@ -109,6 +269,40 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "rebuild",
+                Aliases:   []string{"b"},
+                Usage:     "Re-pull the Rocket Pool service images and recreate its containers",
+                UsageText: "rocketpool service rebuild [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "yes, y",
+                        Usage: "Automatically confirm service rebuild",
+                    },
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                    cli.BoolFlag{
+                        Name:  "verify-images",
+                        Usage: "Verify the service images against the expected digests in the image manifest, and refuse to continue on a mismatch",
+                    },
+                    cli.StringFlag{
+                        Name:  "image-manifest",
+                        Usage: "The signed image manifest `path` to verify images against; its signature is read from the same path with a .sig suffix (default: image-digests.yml in the config path)",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return rebuildService(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "pause",
                 Aliases:   []string{"p"},
@@ -119,6 +313,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "yes, y",
                         Usage: "Automatically confirm service suspension",
                     },
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                    cli.BoolFlag{
+                        Name:  "freeze, f",
+                        Usage: "Freeze the service containers in place with docker pause instead of stopping them; resume with 'rocketpool service resume'",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -140,6 +342,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "yes, y",
                         Usage: "Automatically confirm service suspension",
                     },
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                    cli.BoolFlag{
+                        Name:  "freeze, f",
+                        Usage: "Freeze the service containers in place with docker pause instead of stopping them; resume with 'rocketpool service resume'",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -152,6 +362,49 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "resume",
+                Aliases:   []string{"r"},
+                Usage:     "Resume the Rocket Pool service after it was frozen with 'rocketpool service pause --freeze'",
+                UsageText: "rocketpool service resume [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return resumeService(c)
+
+                },
+            },
+
+            cli.Command{
+                Name:      "lock-wallet",
+                Usage:     "Lock the node wallet in the running node & watchtower daemons, wiping their cached keys until they are next needed",
+                UsageText: "rocketpool service lock-wallet [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return lockWallet(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "terminate",
                 Aliases:   []string{"t"},
@@ -162,6 +415,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "yes, y",
                         Usage: "Automatically confirm service termination",
                     },
+                    cli.BoolFlag{
+                        Name:  "remove-volumes",
+                        Usage: "Remove the service's docker volumes, deleting all chain data",
+                    },
+                    cli.BoolFlag{
+                        Name:  "delete-data",
+                        Usage: "Decommission the node: remove the service's docker volumes and delete the Rocket Pool config & data directory, including the node wallet",
+                    },
+                    cli.BoolFlag{
+                        Name:  "quiet, q",
+                        Usage: "Suppress docker output unless the command fails",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -210,11 +475,33 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "env",
+                Aliases:   []string{"e"},
+                Usage:     "View the environment variables used to run the Rocket Pool service, with secrets masked",
+                UsageText: "rocketpool service env",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return serviceEnv(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "version",
                 Aliases:   []string{"v"},
                 Usage:     "View the Rocket Pool service version information",
-                UsageText: "rocketpool service version",
+                UsageText: "rocketpool service version [options]",
+                Flags: []cli.Flag{
+                    cli.BoolFlag{
+                        Name:  "json",
+                        Usage: "Print the version information as JSON",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
@@ -226,6 +513,32 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "support-bundle",
+                Usage:     "Export the service config, versions, status and recent logs to a tar.gz file to attach to support requests, with secrets masked",
+                UsageText: "rocketpool service support-bundle [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "output, o",
+                        Usage: "The `path` to save the support bundle to (default: rocketpool-support-<time>.tar.gz in the current directory)",
+                    },
+                    cli.StringFlag{
+                        Name:  "tail, t",
+                        Usage: "The number of lines to include from the end of each service's logs (number or \"all\")",
+                        Value: DefaultSupportBundleLogTail,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run command
+                    return exportSupportBundle(c)
+
+                },
+            },
+
         },
     })
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"math/rand"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 // Configure the Rocket Pool service
@@ -40,7 +42,10 @@ func configureService(c *cli.Context) error {
     // Get the list of compatible eth2 clients
     var compatibleEth2Clients []string
     compatibleString := globalConfig.Chains.Eth1.GetSelectedClient().CompatibleEth2Clients
-    if compatibleString != "" {
+    if compatibleString != "" && c.GlobalBool("skip-compat-check") {
+        fmt.Println("WARNING: The eth1/eth2 compatibility check is disabled, so all Eth 2.0 clients are available; incompatible pairings may not work.")
+        fmt.Println("")
+    } else if compatibleString != "" {
         compatibleEth2Clients = strings.Split(globalConfig.Chains.Eth1.GetSelectedClient().CompatibleEth2Clients, ";")
     }
 
@@ -49,6 +54,12 @@ func configureService(c *cli.Context) error {
         return err
     }
 
+    // Configure validator client
+    configureValidatorClient(&(globalConfig.Chains.Eth2), &(userConfig.Chains.Eth2))
+
+    // Configure graffiti
+    configureGraffiti(globalConfig.Smartnode.GraffitiVersion, &userConfig)
+
     // Save user config
     if err := rp.SaveUserConfig(userConfig); err != nil {
         return err
@@ -61,6 +72,149 @@ func configureService(c *cli.Context) error {
 }
 
 
+// Configure a validator client to run separately from the beacon client
+func configureValidatorClient(globalChain, userChain *config.Chain) {
+
+    // Get the other validator clients supported by the selected beacon client
+    userChain.Client.SelectedValidator = ""
+    beaconClient := globalChain.GetSelectedClient()
+    if beaconClient == nil {
+        return
+    }
+    var validatorClients []config.ClientOption
+    for _, option := range globalChain.Client.Options {
+        if option.ID != beaconClient.ID && beaconClient.SupportsValidatorClient(option.ID) {
+            validatorClients = append(validatorClients, option)
+        }
+    }
+    if len(validatorClients) == 0 {
+        return
+    }
+
+    // Prompt for a separate validator client
+    if !cliutils.Confirm(fmt.Sprintf("Would you like to run a different validator client alongside the %s beacon client?", beaconClient.Name)) {
+        return
+    }
+    clientOptions := make([]string, len(validatorClients))
+    for oi, option := range validatorClients {
+        clientOptions[oi] = option.Name
+    }
+    selected, _ := cliutils.Select("Which validator client would you like to run?", clientOptions)
+
+    // Set selected validator client
+    userChain.Client.SelectedValidator = validatorClients[selected].ID
+
+    // Log
+    fmt.Printf("%s validator client selected.\n", validatorClients[selected].Name)
+    fmt.Println("")
+
+}
+
+
+// Configure custom validator graffiti
+// A blank answer keeps the current graffiti, which can be removed by entering '-'
+func configureGraffiti(version string, userConfig *config.RocketPoolConfig) {
+    prompt := fmt.Sprintf("Please enter custom validator graffiti text (leave blank for none)\n(it will be combined with the Rocket Pool version, up to %d bytes in total)", config.MaxGraffitiLength)
+    if userConfig.Smartnode.Graffiti != "" {
+        prompt = fmt.Sprintf("Please enter custom validator graffiti text (leave blank to keep '%s', or enter '-' to remove it)\n(it will be combined with the Rocket Pool version, up to %d bytes in total)", userConfig.Smartnode.Graffiti, config.MaxGraffitiLength)
+    }
+    for {
+        graffiti := cliutils.Prompt(prompt, "^.*$", "Invalid graffiti")
+        if graffiti == "" {
+            graffiti = userConfig.Smartnode.Graffiti
+        } else if graffiti == "-" {
+            graffiti = ""
+        }
+        if _, err := config.GetGraffiti(graffiti, version); err != nil {
+            fmt.Println(err)
+            continue
+        }
+        userConfig.Smartnode.Graffiti = graffiti
+        fmt.Println("")
+        return
+    }
+}
+
+
+// Export the Rocket Pool service configuration
+func exportConfig(c *cli.Context, path string) error {
+
+    // Get export format
+    format := config.DetectFormat(path)
+    if c.String("config-format") != "" {
+        var err error
+        format, err = config.ParseFormat(c.String("config-format"))
+        if err != nil { return err }
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Create export file; may contain provider credentials
+    file, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_TRUNC, 0600)
+    if err != nil {
+        return fmt.Errorf("Could not create config export file at %s: %w", path, err)
+    }
+    defer file.Close()
+
+    // Export config
+    if err := rp.ExportConfig(file, format, c.Bool("include-secrets")); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("The Rocket Pool service configuration was exported to %s.\n", path)
+    if !c.Bool("include-secrets") {
+        fmt.Println("Inline credentials were masked; re-enter them after importing, or export again with --include-secrets.")
+    }
+    fmt.Println("Secrets referenced from a secrets file are not exported; copy the secrets file separately.")
+    return nil
+
+}
+
+
+// Import the Rocket Pool service configuration
+func importConfig(c *cli.Context, path string) error {
+
+    // Get import format
+    format := config.DetectFormat(path)
+    if c.String("config-format") != "" {
+        var err error
+        format, err = config.ParseFormat(c.String("config-format"))
+        if err != nil { return err }
+    }
+
+    // Prompt for confirmation
+    if !(c.Bool("yes") || cliutils.Confirm("Importing a configuration will overwrite your existing settings. Are you sure you want to continue?")) {
+        return exit.ErrCancelled
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Open export file
+    file, err := os.Open(path)
+    if err != nil {
+        return fmt.Errorf("Could not open config export file at %s: %w", path, err)
+    }
+    defer file.Close()
+
+    // Import config
+    if err := rp.ImportConfig(file, format); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("Done! Run 'rocketpool service start' to apply the imported configuration settings.")
+    return nil
+
+}
+
+
 // Configure a chain
 func configureChain(globalChain, userChain *config.Chain, chainName string, defaultRandomClient bool, compatibleClients []string) error {
 
@@ -192,17 +346,9 @@ func configureChain(globalChain, userChain *config.Chain, chainName string, defa
             }
 
             // Type checking
-            switch param.Type {
-                case "uint":
-                    if _, err := strconv.ParseUint(value, 0, 0); err != nil {
-                        fmt.Printf("'%s' is not a valid value for %s, try again.\n", value, param.Name)
-                        isValid = false
-                    }
-                case "uint16":
-                    if _, err := strconv.ParseUint(value, 0, 16); err != nil {
-                        fmt.Printf("'%s' is not a valid value for %s, try again.\n", value, param.Name)
-                        isValid = false
-                    }
+            if err := checkParamType(param, value); err != nil {
+                fmt.Printf("%s, try again.\n", err.Error())
+                isValid = false
             }
 
             // Continue if input is valid
@@ -250,3 +396,17 @@ func configureChain(globalChain, userChain *config.Chain, chainName string, defa
 
 }
 
+
+// Check that a param value is valid for its type
+func checkParamType(param config.ClientParam, value string) error {
+    var err error
+    switch param.Type {
+        case "uint": _, err = strconv.ParseUint(value, 0, 0)
+        case "uint16": _, err = strconv.ParseUint(value, 0, 16)
+    }
+    if err != nil {
+        return fmt.Errorf("'%s' is not a valid value for %s", value, param.Name)
+    }
+    return nil
+}
+
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+
+// View the custom params of the selected eth1 & eth2 clients
+func getParams(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Load config
+    cfg, err := rp.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+
+    // Print params
+    printChainParams(&(cfg.Chains.Eth1), "Eth 1.0")
+    printChainParams(&(cfg.Chains.Eth2), "Eth 2.0")
+    return nil
+
+}
+
+
+// Set a custom param of the selected eth1 or eth2 client
+func setParam(c *cli.Context, chainName, env, value string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Validate & save param
+    var param config.ClientParam
+    err = rp.UpdateUserConfig(func(userConfig *config.RocketPoolConfig) error {
+
+        // Get param
+        var userChain *config.Chain
+        var err error
+        userChain, param, err = getChainParam(rp, userConfig, chainName, env)
+        if err != nil {
+            return err
+        }
+
+        // Validate value
+        if param.Required && value == "" {
+            return fmt.Errorf("%s is required and cannot be blank", param.Name)
+        }
+        if param.Regex != "" && value != "" {
+            regex, err := regexp.Compile(param.Regex)
+            if err != nil {
+                return fmt.Errorf("Invalid format for %s in the global config: %w", param.Name, err)
+            }
+            if !regex.MatchString(value) {
+                return fmt.Errorf("'%s' is not a valid value for %s", value, param.Name)
+            }
+        }
+        if value != "" {
+            if err := checkParamType(param, value); err != nil {
+                return err
+            }
+        }
+
+        // Set param
+        setUserParam(userChain, param.Env, value)
+        return nil
+
+    })
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Printf("%s set to '%s'. Run 'rocketpool service start' to apply the new setting.\n", param.Name, value)
+    return nil
+
+}
+
+
+// Reset a custom param of the selected eth1 or eth2 client to its default value
+func unsetParam(c *cli.Context, chainName, env string) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Reset & save param
+    var param config.ClientParam
+    err = rp.UpdateUserConfig(func(userConfig *config.RocketPoolConfig) error {
+        var userChain *config.Chain
+        var err error
+        userChain, param, err = getChainParam(rp, userConfig, chainName, env)
+        if err != nil {
+            return err
+        }
+        if param.Required && param.Default == "" {
+            return fmt.Errorf("%s is required and has no default value to reset to", param.Name)
+        }
+        setUserParam(userChain, param.Env, param.Default)
+        return nil
+    })
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    if param.Default == "" {
+        fmt.Printf("%s unset. Run 'rocketpool service start' to apply the new setting.\n", param.Name)
+    } else {
+        fmt.Printf("%s reset to its default value '%s'. Run 'rocketpool service start' to apply the new setting.\n", param.Name, param.Default)
+    }
+    return nil
+
+}
+
+
+// Print the params of a chain's selected client
+func printChainParams(chain *config.Chain, chainName string) {
+    client := chain.GetSelectedClient()
+    if client == nil {
+        fmt.Printf("No %s client is selected; run 'rocketpool service config' to select one.\n\n", chainName)
+        return
+    }
+    if len(client.Params) == 0 {
+        fmt.Printf("The %s %s client has no custom params.\n\n", client.Name, chainName)
+        return
+    }
+    fmt.Printf("%s %s client params:\n", client.Name, chainName)
+    for _, param := range client.Params {
+        value, set := getUserParam(chain, param.Env)
+        if !set || value == "" {
+            value = param.Default
+        }
+        if value == "" {
+            value = "(none)"
+        }
+        fmt.Printf("  %s (%s): %s\n", param.Name, param.Env, value)
+        if param.Desc != "" {
+            fmt.Printf("      %s\n", param.Desc)
+        }
+    }
+    fmt.Println("")
+}
+
+
+// Get a user chain and a param of its selected client by chain name & param env var name
+// The user config is passed in rather than loaded, as it is locked while being updated
+func getChainParam(rp *rocketpool.Client, userConfig *config.RocketPoolConfig, chainName, env string) (*config.Chain, config.ClientParam, error) {
+
+    // Merge configs
+    globalConfig, err := rp.LoadGlobalConfig()
+    if err != nil {
+        return nil, config.ClientParam{}, err
+    }
+    cfg, err := config.Merge(&globalConfig, userConfig)
+    if err != nil {
+        return nil, config.ClientParam{}, err
+    }
+
+    // Get chain
+    var chain *config.Chain
+    var userChain *config.Chain
+    switch strings.ToLower(chainName) {
+        case "eth1":
+            chain = &(cfg.Chains.Eth1)
+            userChain = &(userConfig.Chains.Eth1)
+        case "eth2":
+            chain = &(cfg.Chains.Eth2)
+            userChain = &(userConfig.Chains.Eth2)
+        default:
+            return nil, config.ClientParam{}, fmt.Errorf("Invalid chain '%s' - valid chains are 'eth1' and 'eth2'", chainName)
+    }
+
+    // Get param
+    client := chain.GetSelectedClient()
+    if client == nil {
+        return nil, config.ClientParam{}, fmt.Errorf("No %s client is selected; run 'rocketpool service config' to select one", chainName)
+    }
+    for _, param := range client.Params {
+        if strings.EqualFold(param.Env, env) {
+            return userChain, param, nil
+        }
+    }
+    return nil, config.ClientParam{}, fmt.Errorf("The %s client has no param '%s'; run 'rocketpool service config params' to list its params", client.Name, env)
+
+}
+
+
+// Get / set the value of a user param by env var name
+func getUserParam(chain *config.Chain, env string) (string, bool) {
+    for _, param := range chain.Client.Params {
+        if param.Env == env {
+            return param.Value, true
+        }
+    }
+    return "", false
+}
+func setUserParam(chain *config.Chain, env, value string) {
+    for pi, param := range chain.Client.Params {
+        if param.Env == env {
+            chain.Client.Params[pi].Value = value
+            return
+        }
+    }
+    chain.Client.Params = append(chain.Client.Params, config.UserParam{
+        Env: env,
+        Value: value,
+    })
+}
@@ -1,18 +1,50 @@
 package service
 
 import (
+    "encoding/json"
+    "errors"
     "fmt"
+    "io"
+    "os"
+    "time"
 
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/rocketpool"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 
+// Config
+const (
+    GigabyteBytes = 1024 * 1024 * 1024
+    DefaultMinDiskSpaceGb = 50
+    TerminateConfirmationPhrase = "delete my node data"
+)
+var syncCheckInterval, _ = time.ParseDuration("15s")
+var clientServices = []string{"eth1", "eth2"}
+
+
 // Install the Rocket Pool service
 func installService(c *cli.Context) error {
 
+    // Write progress events to stdout if requested, with all other output on stderr so the event stream stays valid JSON
+    var events io.Writer
+    if c.Bool("progress-json") {
+        cliutils.ReserveStdout()
+        events = os.Stdout
+    }
+    output := cliutils.Output()
+
+    // Write an error event for errors before installation starts
+    installError := func(err error) error {
+        if events != nil {
+            rocketpool.WriteInstallEvent(events, rocketpool.InstallEvent{Stage: rocketpool.InstallStageError, Message: err.Error()})
+        }
+        return err
+    }
+
     // Get install location
     var location string
     if c.GlobalString("host") == "" {
@@ -21,34 +53,55 @@ func installService(c *cli.Context) error {
         location = fmt.Sprintf("at %s", c.GlobalString("host"))
     }
 
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return installError(err) }
+    defer rp.Close()
+    rp.SetOutput(output, os.Stderr)
+
+    // Check if the service is already at the target version
+    if !c.Bool("force") {
+        isServiceVersion, err := rp.IsServiceVersion(c.String("version"))
+        if err != nil { return installError(err) }
+        if isServiceVersion {
+            message := fmt.Sprintf("The Rocket Pool service is already at %s. Use '--force' to reinstall it.", c.String("version"))
+            if events != nil {
+                rocketpool.WriteInstallEvent(events, rocketpool.InstallEvent{Stage: rocketpool.InstallStageDone, Message: message})
+            } else {
+                fmt.Fprintln(output, message)
+            }
+            return nil
+        }
+    }
+
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
         "The Rocket Pool service will be installed %s --\nNetwork: %s\nVersion: %s\n\nAny existing configuration will be overwritten.\nAre you sure you want to continue?",
         location, c.String("network"), c.String("version"),
     ))) {
-        fmt.Println("Cancelled.")
-        return nil
+        return installError(exit.ErrCancelled)
     }
 
-    // Get RP client
-    rp, err := rocketpool.NewClientFromCtx(c)
-    if err != nil { return err }
-    defer rp.Close()
+    // Check available disk space
+    if err := checkDiskSpace(c, rp); err != nil { return installError(err) }
 
-    // Install service
-    err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"))
+    // Install service, streaming progress events if requested
+    err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), c.String("installer-checksum"), events)
     if err != nil { return err }
+    if events != nil {
+        return nil
+    }
 
     // Print success message & return
-    fmt.Println("")
-    fmt.Printf("The Rocket Pool service was successfully installed %s!\n", location)
+    fmt.Fprintln(output, "")
+    fmt.Fprintf(output, "The Rocket Pool service was successfully installed %s!\n", location)
     if c.GlobalString("host") == "" {
-        fmt.Println("")
-        fmt.Println("Please start a new shell session to apply updated user permissions.")
-        fmt.Println("(To start a new shell session, log out and back in.)")
-        fmt.Println("")
+        fmt.Fprintln(output, "")
+        fmt.Fprintln(output, "Please start a new shell session to apply updated user permissions.")
+        fmt.Fprintln(output, "(To start a new shell session, log out and back in.)")
+        fmt.Fprintln(output, "")
     }
-    fmt.Println("Run 'rocketpool service config' to configure the service before starting it.")
+    fmt.Fprintln(output, "Run 'rocketpool service config' to configure the service before starting it.")
     return nil
 
 }
@@ -76,19 +129,123 @@ func startService(c *cli.Context) error {
     if err != nil { return err }
     defer rp.Close()
 
+    // Check available disk space
+    if err := checkDiskSpace(c, rp); err != nil { return err }
+
+    // Verify images
+    if c.Bool("verify-images") {
+        if err := rp.VerifyServiceImages(c.String("image-manifest")); err != nil {
+            return err
+        }
+    }
+
+    // Start the eth1 & eth2 clients and wait for them to sync
+    if c.Bool("require-synced") {
+        if err := startClientsAndWaitForSync(c, rp); err != nil {
+            return err
+        }
+    }
+
     // Start service
-    return rp.StartService(getComposeFiles(c))
+    return rp.StartService(getComposeFiles(c), c.Bool("quiet"))
+
+}
+
+
+// Start only the eth1 & eth2 clients, and wait until both report synced
+// If the sync timeout is reached, the user is asked whether to start the rest of the service anyway
+func startClientsAndWaitForSync(c *cli.Context, rp *rocketpool.Client) error {
+
+    // Start clients
+    fmt.Println("Starting the eth1 & eth2 clients...")
+    if err := rp.StartServices(getComposeFiles(c), clientServices, c.Bool("quiet")); err != nil {
+        return err
+    }
+
+    // Wait for sync
+    fmt.Println("Waiting for the eth1 & eth2 clients to sync before starting the validator (press Ctrl+C to cancel)...")
+    timeout := c.Duration("sync-timeout")
+    started := time.Now()
+    for {
+        status, err := rp.NodeSync()
+        if err != nil {
+            fmt.Printf("Could not check client sync status: %s\n", err.Error())
+        } else if status.Eth1Synced && status.Eth2Synced {
+            fmt.Println("The eth1 & eth2 clients are synced.")
+            fmt.Println("")
+            return nil
+        } else {
+            eth2Progress := "unknown"
+            if status.Eth2Progress != -1 {
+                eth2Progress = fmt.Sprintf("%0.2f%%", status.Eth2Progress * 100)
+            }
+            fmt.Printf("Sync progress: eth1 %0.2f%%, eth2 %s\n", status.Eth1Progress * 100, eth2Progress)
+        }
+        if timeout > 0 && time.Since(started) >= timeout {
+            break
+        }
+        time.Sleep(syncCheckInterval)
+    }
+
+    // Prompt to start anyway
+    if !cliutils.Confirm(fmt.Sprintf("The eth1 & eth2 clients did not report synced within %s. If they were wiped or are resyncing, starting the validator now may be unsafe. Would you like to start the rest of the service anyway?", timeout.String())) {
+        return exit.NewError(exit.NotSynced, errors.New("The Rocket Pool service was not started because the clients are not synced."))
+    }
+    return nil
+
+}
+
+
+// Re-pull the Rocket Pool service images and recreate its containers
+func rebuildService(c *cli.Context) error {
+
+    // Prompt for confirmation
+    if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to rebuild the Rocket Pool service? All of its containers will be restarted.")) {
+        return exit.ErrCancelled
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Pull images
+    fmt.Println("Pulling Rocket Pool service images...")
+    if err := rp.PullServiceImages(getComposeFiles(c), c.Bool("quiet")); err != nil {
+        return err
+    }
+    fmt.Println("")
+
+    // Verify images
+    if c.Bool("verify-images") {
+        fmt.Println("Verifying Rocket Pool service images...")
+        if err := rp.VerifyServiceImages(c.String("image-manifest")); err != nil {
+            return err
+        }
+        fmt.Println("")
+    }
+
+    // Recreate containers
+    fmt.Println("Recreating Rocket Pool service containers...")
+    if err := rp.RecreateService(getComposeFiles(c), c.Bool("quiet")); err != nil {
+        return err
+    }
+    fmt.Println("")
+
+    // Log & return
+    fmt.Println("The Rocket Pool service was rebuilt successfully.")
+    return nil
 
 }
 
 
 // Pause the Rocket Pool service
+// The containers are stopped, or frozen in place if the freeze flag is set
 func pauseService(c *cli.Context) error {
 
     // Prompt for confirmation
     if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to pause the Rocket Pool service? Any staking minipools will be penalized!")) {
-        fmt.Println("Cancelled.")
-        return nil
+        return exit.ErrCancelled
     }
 
     // Get RP client
@@ -96,8 +253,49 @@ func pauseService(c *cli.Context) error {
     if err != nil { return err }
     defer rp.Close()
 
+    // Freeze service
+    if c.Bool("freeze") {
+        if err := rp.FreezeService(getComposeFiles(c), c.Bool("quiet")); err != nil {
+            return err
+        }
+        fmt.Println("The Rocket Pool service has been frozen. Run 'rocketpool service resume' to resume it.")
+        return nil
+    }
+
     // Pause service
-    return rp.PauseService(getComposeFiles(c))
+    return rp.PauseService(getComposeFiles(c), c.Bool("quiet"))
+
+}
+
+
+// Resume the Rocket Pool service after it was frozen
+func resumeService(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Resume service
+    return rp.UnfreezeService(getComposeFiles(c), c.Bool("quiet"))
+
+}
+
+
+// Lock the node wallet in the running daemons
+func lockWallet(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Lock wallet
+    if err := rp.LockServiceWallet(getComposeFiles(c), c.Bool("quiet")); err != nil {
+        return err
+    }
+    fmt.Println("The node wallet was locked; its keys will be decrypted again when they are next needed.")
+    return nil
 
 }
 
@@ -106,9 +304,25 @@ func pauseService(c *cli.Context) error {
 func stopService(c *cli.Context) error {
 
     // Prompt for confirmation
-    if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to terminate the Rocket Pool service? Any staking minipools will be penalized, chain databases will be deleted, and ethereum nodes will lose ALL sync progress!")) {
-        fmt.Println("Cancelled.")
-        return nil
+    deleteData := c.Bool("delete-data")
+    removeVolumes := c.Bool("remove-volumes") || deleteData
+    if deleteData {
+        if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to terminate the Rocket Pool service and delete ALL of its data? Any staking minipools will be penalized, chain databases will be deleted, and the node wallet & validator keys will be PERMANENTLY removed from this machine!")) {
+            return exit.ErrCancelled
+        }
+        fmt.Println("This will decommission the node. Make sure you have a backup of your mnemonic before continuing, or you will lose access to your node & validator funds.")
+        confirmation := cliutils.Prompt(fmt.Sprintf("Please type '%s' to confirm:", TerminateConfirmationPhrase), "^.*$", "")
+        if confirmation != TerminateConfirmationPhrase {
+            return exit.NewError(exit.Cancelled, errors.New("Confirmation phrase did not match; the service was not terminated."))
+        }
+    } else if removeVolumes {
+        if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to terminate the Rocket Pool service and remove its volumes? Any staking minipools will be penalized, chain databases will be deleted, and ethereum nodes will lose ALL sync progress!")) {
+            return exit.ErrCancelled
+        }
+    } else {
+        if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to terminate the Rocket Pool service? Any staking minipools will be penalized! Chain data volumes will be preserved.")) {
+            return exit.ErrCancelled
+        }
     }
 
     // Get RP client
@@ -117,7 +331,18 @@ func stopService(c *cli.Context) error {
     defer rp.Close()
 
     // Stop service
-    return rp.StopService(getComposeFiles(c))
+    if err := rp.StopService(getComposeFiles(c), removeVolumes, c.Bool("quiet")); err != nil {
+        return err
+    }
+
+    // Delete config & data directory
+    if deleteData {
+        if err := rp.DeleteConfigDirectory(); err != nil {
+            return err
+        }
+        fmt.Println("The Rocket Pool service was terminated and its config & data directory was deleted.")
+    }
+    return nil
 
 }
 
@@ -150,6 +375,38 @@ func serviceStats(c *cli.Context) error {
 }
 
 
+// View the Rocket Pool service environment variables
+func serviceEnv(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get service environment variables
+    env, err := rp.GetServiceEnv()
+    if err != nil { return err }
+
+    // Print & return
+    for _, variable := range env {
+        fmt.Println(variable)
+    }
+    return nil
+
+}
+
+
+// Service version information
+type serviceVersionInfo struct {
+    ClientVersion string                `json:"clientVersion"`
+    ServiceVersion string               `json:"serviceVersion"`
+    Eth1Client string                   `json:"eth1Client"`
+    Eth2Client string                   `json:"eth2Client"`
+    ValidatorClient string              `json:"validatorClient"`
+    BeaconClientVersion string          `json:"beaconClientVersion"`
+}
+
+
 // View the Rocket Pool service version information
 func serviceVersion(c *cli.Context) error {
 
@@ -158,20 +415,53 @@ func serviceVersion(c *cli.Context) error {
     if err != nil { return err }
     defer rp.Close()
 
+    // Get version info
+    versionInfo, err := getServiceVersionInfo(c, rp)
+    if err != nil { return err }
+
+    // Print version info as JSON
+    if c.Bool("json") {
+        versionBytes, err := json.MarshalIndent(versionInfo, "", "    ")
+        if err != nil { return err }
+        fmt.Println(string(versionBytes))
+        return nil
+    }
+
+    // Print version info
+    fmt.Printf("Rocket Pool client version: %s\n", versionInfo.ClientVersion)
+    fmt.Printf("Rocket Pool service version: %s\n", versionInfo.ServiceVersion)
+    fmt.Printf("Selected Eth 1.0 client: %s\n", versionInfo.Eth1Client)
+    fmt.Printf("Selected Eth 2.0 client: %s\n", versionInfo.Eth2Client)
+    fmt.Printf("Selected validator client: %s\n", versionInfo.ValidatorClient)
+    if versionInfo.BeaconClientVersion != "" {
+        fmt.Printf("Running Eth 2.0 beacon client: %s\n", versionInfo.BeaconClientVersion)
+    } else {
+        fmt.Println("Running Eth 2.0 beacon client: (unavailable)")
+    }
+    return nil
+
+}
+
+
+// Get the Rocket Pool service version information
+func getServiceVersionInfo(c *cli.Context, rp *rocketpool.Client) (serviceVersionInfo, error) {
+
     // Get RP service version
     serviceVersion, err := rp.GetServiceVersion()
-    if err != nil { return err }
+    if err != nil { return serviceVersionInfo{}, err }
 
     // Get config
     cfg, err := rp.LoadMergedConfig()
-    if err != nil { return err }
+    if err != nil { return serviceVersionInfo{}, err }
     eth1Client := cfg.GetSelectedEth1Client()
     eth2Client := cfg.GetSelectedEth2Client()
+    validatorClient := cfg.GetSelectedValidatorClient()
 
     // Get client versions
     var eth1ClientVersion string
     var eth2ClientVersion string
     var eth2ClientImage string
+    var validatorClientVersion string
     if eth1Client != nil {
         eth1ClientVersion = fmt.Sprintf("%s (%s)", eth1Client.Name, eth1Client.Image)
     } else {
@@ -187,13 +477,55 @@ func serviceVersion(c *cli.Context) error {
     } else {
         eth2ClientVersion = "(none)"
     }
+    if validatorClient != nil {
+        validatorClientVersion = fmt.Sprintf("%s (%s)", validatorClient.Name, validatorClient.GetValidatorImage())
+    } else {
+        validatorClientVersion = "(none)"
+    }
 
-    // Print version info
-    fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
-    fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
-    fmt.Printf("Selected Eth 1.0 client: %s\n", eth1ClientVersion)
-    fmt.Printf("Selected Eth 2.0 client: %s\n", eth2ClientVersion)
-    return nil
+    // Get running beacon client version; the service may not be running
+    var beaconClientVersion string
+    if beaconVersion, err := rp.NodeBeaconClientVersion(); err == nil {
+        beaconClientVersion = beaconVersion.RawVersion
+    }
+
+    // Return
+    return serviceVersionInfo{
+        ClientVersion: c.App.Version,
+        ServiceVersion: serviceVersion,
+        Eth1Client: eth1ClientVersion,
+        Eth2Client: eth2ClientVersion,
+        ValidatorClient: validatorClientVersion,
+        BeaconClientVersion: beaconClientVersion,
+    }, nil
+
+}
+
+
+// Check that the Rocket Pool host has enough free disk space to run the service
+// Returns an error if it is below the minimum, unless overridden
+func checkDiskSpace(c *cli.Context, rp *rocketpool.Client) error {
+
+    // Get available disk space
+    colorReset := "\033[0m"
+    colorYellow := "\033[33m"
+    availableBytes, err := rp.GetDiskSpace()
+    if err != nil {
+        fmt.Fprintf(cliutils.Output(), "%sWARNING: %s%s\n\n", colorYellow, err.Error(), colorReset)
+        return nil
+    }
+
+    // Check against minimum
+    availableGb := float64(availableBytes) / GigabyteBytes
+    minGb := c.Float64("min-disk-space")
+    if availableGb >= minGb {
+        return nil
+    }
+    if c.Bool("ignore-disk-space") {
+        fmt.Fprintf(cliutils.Output(), "%sWARNING: only %.1f GB of disk space is available, which is below the minimum of %.1f GB. Running out of disk space can corrupt chain data.%s\n\n", colorYellow, availableGb, minGb, colorReset)
+        return nil
+    }
+    return fmt.Errorf("Only %.1f GB of disk space is available, which is below the minimum of %.1f GB. Running out of disk space can corrupt chain data.\nPlease free up disk space, or use '--ignore-disk-space' to continue anyway.", availableGb, minGb)
 
 }
 
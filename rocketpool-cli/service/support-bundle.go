@@ -0,0 +1,153 @@
+package service
+
+import (
+    "archive/tar"
+    "compress/gzip"
+    "encoding/json"
+    "fmt"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Config
+const (
+    DefaultSupportBundleLogTail = "1000"
+    SupportBundleTimeFormat = "20060102-150405"
+)
+
+
+// A file in a support bundle
+type supportBundleFile struct {
+    name string
+    content []byte
+}
+
+
+// Export the Rocket Pool service config, versions, status & recent logs as a support bundle, with secrets masked
+func exportSupportBundle(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get output path
+    path := c.String("output")
+    if path == "" {
+        path = fmt.Sprintf("rocketpool-support-%s.tar.gz", time.Now().Format(SupportBundleTimeFormat))
+    }
+
+    // Load redacted config; required to mask secrets in the other files
+    cfg, replacer, err := rp.LoadRedactedConfig()
+    if err != nil { return err }
+    configBytes, err := cfg.Serialize()
+    if err != nil { return err }
+
+    // Collect files; failures are recorded in the bundle rather than aborting it
+    files := []supportBundleFile{{name: "config.yml", content: configBytes}}
+    collectionErrors := []string{}
+    collect := func(name string, getContent func() ([]byte, error)) {
+        fmt.Printf("Collecting %s...\n", name)
+        content, err := getContent()
+        if err != nil {
+            collectionErrors = append(collectionErrors, fmt.Sprintf("%s: %s", name, err.Error()))
+            return
+        }
+        files = append(files, supportBundleFile{name: name, content: []byte(replacer.Replace(string(content)))})
+    }
+    collect("versions.json", func() ([]byte, error) {
+        return getSupportBundleVersions(c, rp)
+    })
+    collect("status.txt", func() ([]byte, error) {
+        return rp.GetServiceStatus(getComposeFiles(c))
+    })
+    collect("logs.txt", func() ([]byte, error) {
+        return rp.GetServiceLogs(getComposeFiles(c), c.String("tail"))
+    })
+    if len(collectionErrors) > 0 {
+        files = append(files, supportBundleFile{name: "errors.txt", content: []byte(replacer.Replace(strings.Join(collectionErrors, "\n") + "\n"))})
+    }
+
+    // Write bundle
+    if err := writeSupportBundle(path, files); err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("")
+    if len(collectionErrors) > 0 {
+        fmt.Printf("Some information could not be collected; see errors.txt in the bundle for details.\n")
+    }
+    fmt.Printf("The support bundle was saved to %s.\n", path)
+    fmt.Println("Known secrets have been masked, but please review its contents before sharing it.")
+    return nil
+
+}
+
+
+// Get the Rocket Pool & client versions for a support bundle
+func getSupportBundleVersions(c *cli.Context, rp *rocketpool.Client) ([]byte, error) {
+
+    // Get service version info
+    versionInfo, err := getServiceVersionInfo(c, rp)
+    if err != nil {
+        return nil, err
+    }
+
+    // Get running eth1 client version; the provider may not support it
+    var eth1ClientVersion string
+    if connectivity, err := rp.NodeTestConnectivity(); err == nil {
+        eth1ClientVersion = connectivity.Eth1ClientVersion
+    }
+
+    // Serialize versions
+    return json.MarshalIndent(struct {
+        serviceVersionInfo
+        Eth1ClientVersion string        `json:"eth1ClientVersion"`
+    }{versionInfo, eth1ClientVersion}, "", "    ")
+
+}
+
+
+// Write support bundle files to a gzipped tar archive
+func writeSupportBundle(path string, files []supportBundleFile) error {
+
+    // Create bundle file; may contain node addresses & other private details
+    file, err := os.OpenFile(path, os.O_WRONLY | os.O_CREATE | os.O_TRUNC, 0600)
+    if err != nil {
+        return fmt.Errorf("Could not create support bundle at %s: %w", path, err)
+    }
+    defer file.Close()
+
+    // Write archive
+    gzipWriter := gzip.NewWriter(file)
+    tarWriter := tar.NewWriter(gzipWriter)
+    modTime := time.Now()
+    for _, bundleFile := range files {
+        header := &tar.Header{
+            Name: bundleFile.name,
+            Mode: 0600,
+            Size: int64(len(bundleFile.content)),
+            ModTime: modTime,
+        }
+        if err := tarWriter.WriteHeader(header); err != nil {
+            return fmt.Errorf("Could not write support bundle: %w", err)
+        }
+        if _, err := tarWriter.Write(bundleFile.content); err != nil {
+            return fmt.Errorf("Could not write support bundle: %w", err)
+        }
+    }
+    if err := tarWriter.Close(); err != nil {
+        return fmt.Errorf("Could not write support bundle: %w", err)
+    }
+    if err := gzipWriter.Close(); err != nil {
+        return fmt.Errorf("Could not write support bundle: %w", err)
+    }
+    return nil
+
+}
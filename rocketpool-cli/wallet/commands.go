@@ -1,8 +1,12 @@
 package wallet
 
 import (
+    "errors"
+    "fmt"
+
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -41,6 +45,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "password, p",
                         Usage: "The password to secure the wallet with (if not already set)",
                     },
+                    cli.StringFlag{
+                        Name:  "password-file",
+                        Usage: "A file to read the password to secure the wallet with from (if not already set); it must only be accessible by its owner",
+                    },
                     cli.BoolFlag{
                         Name:  "confirm-mnemonic, c",
                         Usage: "Automatically confirm the mnemonic phrase",
@@ -55,6 +63,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                     if c.String("password") != "" {
                         if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil { return err }
                     }
+                    if c.String("password") != "" && c.String("password-file") != "" {
+                        return errors.New("Only one of '--password' and '--password-file' may be specified.")
+                    }
 
                     // Run
                     return initWallet(c)
@@ -72,10 +83,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                         Name:  "password, p",
                         Usage: "The password to secure the wallet with (if not already set)",
                     },
+                    cli.StringFlag{
+                        Name:  "password-file",
+                        Usage: "A file to read the password to secure the wallet with from (if not already set); it must only be accessible by its owner",
+                    },
                     cli.StringFlag{
                         Name:  "mnemonic, m",
                         Usage: "The mnemonic phrase to recover the wallet from",
                     },
+                    cli.StringFlag{
+                        Name:  "mnemonic-file, f",
+                        Usage: "A file to read the mnemonic phrase to recover the wallet from, or '-' to read it from stdin",
+                    },
                 },
                 Action: func(c *cli.Context) error {
 
@@ -86,9 +105,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                     if c.String("password") != "" {
                         if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil { return err }
                     }
+                    if c.String("password") != "" && c.String("password-file") != "" {
+                        return errors.New("Only one of '--password' and '--password-file' may be specified.")
+                    }
                     if c.String("mnemonic") != "" {
                         if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil { return err }
                     }
+                    if c.String("mnemonic") != "" && c.String("mnemonic-file") != "" {
+                        return errors.New("Only one of '--mnemonic' and '--mnemonic-file' may be specified.")
+                    }
 
                     // Run
                     return recoverWallet(c)
@@ -96,6 +121,47 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "rebuild-from-keystore",
+                Usage:     "Initialize the node wallet from an existing encrypted V3 keystore file",
+                UsageText: "rocketpool wallet rebuild-from-keystore [options] keystore-path",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "password, p",
+                        Usage: "The password to secure the wallet with (if not already set)",
+                    },
+                    cli.StringFlag{
+                        Name:  "password-file",
+                        Usage: "A file to read the password to secure the wallet with from (if not already set); it must only be accessible by its owner",
+                    },
+                    cli.StringFlag{
+                        Name:  "keystore-password-file",
+                        Usage: "A file to read the keystore's password from",
+                    },
+                    cli.BoolFlag{
+                        Name:  "yes, y",
+                        Usage: "Automatically confirm importing the keystore",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+
+                    // Validate flags
+                    if c.String("password") != "" {
+                        if _, err := cliutils.ValidateNodePassword("password", c.String("password")); err != nil { return err }
+                    }
+                    if c.String("password") != "" && c.String("password-file") != "" {
+                        return errors.New("Only one of '--password' and '--password-file' may be specified.")
+                    }
+
+                    // Run
+                    return rebuildFromKeystore(c, c.Args().Get(0))
+
+                },
+            },
+
             cli.Command{
                 Name:      "rebuild",
                 Aliases:   []string{"b"},
@@ -112,6 +178,41 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "derived-addresses",
+                Aliases:   []string{"d"},
+                Usage:     "List the addresses derived from the node wallet which hold an ETH or RPL balance",
+                UsageText: "rocketpool wallet derived-addresses [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "count, n",
+                        Usage: fmt.Sprintf("The number of derivation indices to check (at most %d)", wallet.MaxDerivedNodeAddresses),
+                        Value: 20,
+                    },
+                    cli.BoolFlag{
+                        Name:  "all, a",
+                        Usage: "Include addresses without a balance",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.Uint64("count") == 0 {
+                        return errors.New("The number of derivation indices must be greater than 0.")
+                    }
+                    if c.Uint64("count") > wallet.MaxDerivedNodeAddresses {
+                        return fmt.Errorf("The number of derivation indices cannot be greater than %d.", wallet.MaxDerivedNodeAddresses)
+                    }
+
+                    // Run
+                    return getDerivedAddresses(c)
+
+                },
+            },
+
             cli.Command{
                 Name:      "export",
                 Aliases:   []string{"e"},
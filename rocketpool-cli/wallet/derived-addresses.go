@@ -0,0 +1,63 @@
+package wallet
+
+import (
+    "fmt"
+
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+
+func getDerivedAddresses(c *cli.Context) error {
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get & check wallet status
+    status, err := rp.WalletStatus()
+    if err != nil {
+        return err
+    }
+    if !status.WalletInitialized {
+        fmt.Println("The node wallet is not initialized.")
+        return nil
+    }
+    if status.NodeKeyImported {
+        fmt.Println("The node wallet was imported from a keystore file and has no seed to derive addresses from.")
+        return nil
+    }
+
+    // Get derived addresses
+    response, err := rp.WalletDerivedAddresses(c.Uint64("count"))
+    if err != nil {
+        return err
+    }
+
+    // Print addresses
+    found := 0
+    for _, address := range response.Addresses {
+        if !c.Bool("all") && address.EthBalance.Sign() == 0 && address.RplBalance.Sign() == 0 {
+            continue
+        }
+        nodeAccount := ""
+        if address.Address == status.AccountAddress {
+            nodeAccount = " (node account)"
+        }
+        fmt.Printf("%s  %s%s\n", address.Path, address.Address.Hex(), nodeAccount)
+        fmt.Printf("    %.6f ETH, %.6f RPL\n", math.RoundDown(eth.WeiToEth(address.EthBalance), 6), math.RoundDown(eth.WeiToEth(address.RplBalance), 6))
+        found++
+    }
+    if found == 0 {
+        fmt.Printf("None of the first %d derived addresses hold an ETH or RPL balance.\n", len(response.Addresses))
+        return nil
+    }
+    fmt.Println("")
+    fmt.Println("Funds held by an address other than the node account can be recovered by importing the node mnemonic into a wallet which supports custom derivation paths.")
+    return nil
+
+}
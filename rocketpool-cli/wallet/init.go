@@ -29,11 +29,9 @@ func initWallet(c *cli.Context) error {
 
     // Set password if not set
     if !status.PasswordSet {
-        var password string
-        if c.String("password") != "" {
-            password = c.String("password")
-        } else {
-            password = promptPassword()
+        password, err := getWalletPassword(c)
+        if err != nil {
+            return err
         }
         if _, err := rp.SetPassword(password); err != nil {
             return err
@@ -0,0 +1,81 @@
+package wallet
+
+import (
+    "fmt"
+    "io/ioutil"
+    "strings"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/exit"
+)
+
+
+func rebuildFromKeystore(c *cli.Context, keystorePath string) error {
+
+    // Read keystore
+    keystoreJson, err := ioutil.ReadFile(keystorePath)
+    if err != nil {
+        return fmt.Errorf("Could not read keystore file: %w", err)
+    }
+
+    // Get RP client
+    rp, err := rocketpool.NewClientFromCtx(c)
+    if err != nil { return err }
+    defer rp.Close()
+
+    // Get & check wallet status
+    status, err := rp.WalletStatus()
+    if err != nil {
+        return err
+    }
+    if status.WalletInitialized {
+        fmt.Println("The node wallet is already initialized.")
+        return nil
+    }
+
+    // Warn about key derivation & prompt for confirmation
+    fmt.Println("The node wallet will use the keystore's private key as its node key. It will not have a mnemonic or seed, so it can't derive validator keys or recover them with 'wallet recover'.")
+    fmt.Println("Keep a backup of the keystore file and its password - they will be the only way to recover the node account.")
+    fmt.Println("")
+    if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to initialize the node wallet from this keystore?")) {
+        return exit.ErrCancelled
+    }
+
+    // Get keystore password
+    var keystorePassword string
+    if c.String("keystore-password-file") != "" {
+        passwordBytes, err := ioutil.ReadFile(c.String("keystore-password-file"))
+        if err != nil {
+            return fmt.Errorf("Could not read keystore password file: %w", err)
+        }
+        keystorePassword = strings.TrimRight(string(passwordBytes), "\r\n")
+    } else {
+        keystorePassword = cliutils.PromptPassword("Please enter the keystore's password:", "^.*$", "")
+    }
+
+    // Set password if not set
+    if !status.PasswordSet {
+        password, err := getWalletPassword(c)
+        if err != nil {
+            return err
+        }
+        if _, err := rp.SetPassword(password); err != nil {
+            return err
+        }
+    }
+
+    // Import keystore
+    response, err := rp.RebuildFromKeystore(keystoreJson, keystorePassword)
+    if err != nil {
+        return err
+    }
+
+    // Log & return
+    fmt.Println("The node wallet was successfully initialized from the keystore.")
+    fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+    return nil
+
+}
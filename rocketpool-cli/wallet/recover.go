@@ -28,11 +28,9 @@ func recoverWallet(c *cli.Context) error {
 
     // Set password if not set
     if !status.PasswordSet {
-        var password string
-        if c.String("password") != "" {
-            password = c.String("password")
-        } else {
-            password = promptPassword()
+        password, err := getWalletPassword(c)
+        if err != nil {
+            return err
         }
         if _, err := rp.SetPassword(password); err != nil {
             return err
@@ -43,6 +41,11 @@ func recoverWallet(c *cli.Context) error {
     var mnemonic string
     if c.String("mnemonic") != "" {
         mnemonic = c.String("mnemonic")
+    } else if c.String("mnemonic-file") != "" {
+        mnemonic, err = readMnemonic(c.String("mnemonic-file"))
+        if err != nil {
+            return err
+        }
     } else {
         mnemonic = promptMnemonic()
     }
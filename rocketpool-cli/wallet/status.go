@@ -26,6 +26,15 @@ func getStatus(c *cli.Context) error {
     if status.WalletInitialized {
         fmt.Println("The node wallet is initialized.")
         fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+        if status.NodeKeyImported {
+            fmt.Println("The node key was imported from a keystore file, so it has no derivation path.")
+        } else {
+            fmt.Printf("Derivation path: %s\n", status.NodeKeyPath)
+        }
+        if status.NodeKeyIndex > 0 {
+            fmt.Printf("Note: the derivation index was increased to %d because the lower indices derived invalid child keys,\n", status.NodeKeyIndex)
+            fmt.Printf("so the node account is not at the default path %s. Use the path above when recovering the node account in other wallets.\n", status.NodeKeyDefaultPath)
+        }
     } else {
         fmt.Println("The node wallet has not been initialized.")
     }
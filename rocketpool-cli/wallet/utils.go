@@ -1,15 +1,50 @@
 package wallet
 
 import (
+    "errors"
     "fmt"
+    "io/ioutil"
+    "os"
+    "strings"
 
     "github.com/tyler-smith/go-bip39"
+    "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services/passwords"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 
+// Get the password to secure the wallet with from the '--password' or '--password-file' option, or prompt for it
+func getWalletPassword(c *cli.Context) (string, error) {
+    if c.String("password") != "" {
+        return c.String("password"), nil
+    }
+    if c.String("password-file") != "" {
+        return readPasswordFile(c.String("password-file"))
+    }
+    return promptPassword(), nil
+}
+
+
+// Read a wallet password from a file which is only accessible by its owner
+// Trailing line breaks are removed; the password is not included in error messages
+func readPasswordFile(path string) (string, error) {
+    if err := passwords.CheckFilePermissions(path); err != nil {
+        return "", err
+    }
+    passwordBytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return "", fmt.Errorf("Could not read password file: %w", err)
+    }
+    password := strings.TrimRight(string(passwordBytes), "\r\n")
+    if len(password) < passwords.MinPasswordLength {
+        return "", fmt.Errorf("The password in %s must be at least %d characters long", path, passwords.MinPasswordLength)
+    }
+    return password, nil
+}
+
+
 // Prompt for a wallet password
 func promptPassword() string {
     for {
@@ -56,3 +91,34 @@ func confirmMnemonic(mnemonic string) {
     }
 }
 
+
+// Read a recovery mnemonic phrase from a file, or from stdin if the path is "-"
+// The raw input buffer is zeroed after use
+func readMnemonic(path string) (string, error) {
+
+    // Read mnemonic
+    var mnemonicBytes []byte
+    var err error
+    if path == "-" {
+        mnemonicBytes, err = ioutil.ReadAll(os.Stdin)
+    } else {
+        mnemonicBytes, err = ioutil.ReadFile(path)
+    }
+    defer (func() {
+        for i := range mnemonicBytes {
+            mnemonicBytes[i] = 0
+        }
+    })()
+    if err != nil {
+        return "", fmt.Errorf("Could not read mnemonic from %s: %w", path, err)
+    }
+
+    // Normalize whitespace & validate; the mnemonic is not included in the error message
+    mnemonic := strings.Join(strings.Fields(string(mnemonicBytes)), " ")
+    if !bip39.IsMnemonicValid(mnemonic) {
+        return "", errors.New("Invalid mnemonic phrase")
+    }
+    return mnemonic, nil
+
+}
+
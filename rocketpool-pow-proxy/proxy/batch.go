@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// Config
+const UpstreamErrorCode = -32000
+
+
+// JSON-RPC message ID
+type rpcMessage struct {
+    ID json.RawMessage `json:"id"`
+}
+
+
+// JSON-RPC error response
+type rpcErrorResponse struct {
+    JSONRPC string `json:"jsonrpc"`
+    ID json.RawMessage `json:"id"`
+    Error json.RawMessage `json:"error"`
+}
+type rpcError struct {
+    Code int `json:"code"`
+    Message string `json:"message"`
+}
+
+
+// Check whether a request body is a JSON-RPC batch
+func isBatch(body []byte) bool {
+    trimmed := bytes.TrimSpace(body)
+    return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+
+// Split a JSON-RPC batch into chunks of at most maxBatchSize requests, forward each chunk to the provider,
+// and reassemble the responses in request order
+func (p *HttpProxyServer) forwardBatch(providerUrl string, body []byte, contentType string) ([]byte, error) {
+
+    // Decode batch
+    var requests []json.RawMessage
+    if err := json.Unmarshal(body, &requests); err != nil {
+        return nil, fmt.Errorf("Could not decode JSON-RPC batch: %w", err)
+    }
+
+    // Get request order by ID
+    requestIndices := map[string]int{}
+    for ri, request := range requests {
+        var message rpcMessage
+        if err := json.Unmarshal(request, &message); err == nil && len(message.ID) > 0 {
+            requestIndices[string(message.ID)] = ri
+        }
+    }
+
+    // Forward chunks
+    responses := []json.RawMessage{}
+    for start := 0; start < len(requests); start += p.MaxBatchSize {
+        end := start + p.MaxBatchSize
+        if end > len(requests) {
+            end = len(requests)
+        }
+        chunkResponses, err := p.forwardChunk(providerUrl, requests[start:end], contentType)
+        if err != nil {
+            return nil, err
+        }
+        responses = append(responses, chunkResponses...)
+    }
+
+    // Sort responses by request order; responses with unknown IDs are placed last
+    responseIndex := func(response json.RawMessage) int {
+        var message rpcMessage
+        if err := json.Unmarshal(response, &message); err == nil {
+            if ri, ok := requestIndices[string(message.ID)]; ok {
+                return ri
+            }
+        }
+        return len(requests)
+    }
+    sort.SliceStable(responses, func(i, j int) bool {
+        return responseIndex(responses[i]) < responseIndex(responses[j])
+    })
+
+    // Encode responses
+    return json.Marshal(responses)
+
+}
+
+
+// Forward a chunk of a JSON-RPC batch to the provider
+// If the provider fails to answer the chunk, an error response is returned for each request in it
+func (p *HttpProxyServer) forwardChunk(providerUrl string, requests []json.RawMessage, contentType string) ([]json.RawMessage, error) {
+
+    // Encode chunk
+    chunk, err := json.Marshal(requests)
+    if err != nil {
+        return nil, fmt.Errorf("Could not encode JSON-RPC batch chunk: %w", err)
+    }
+
+    // Forward chunk to provider
+    start := time.Now()
+    response, err := http.Post(providerUrl, contentType, bytes.NewReader(chunk))
+    p.logIfSlow(chunk, time.Since(start))
+    if err != nil {
+        log.Println(fmt.Errorf("Error forwarding request to remote server: %w", err))
+        return getChunkErrorResponses(requests, nil, "Error forwarding request to remote server")
+    }
+    defer response.Body.Close()
+    responseBody, err := ioutil.ReadAll(response.Body)
+    if err != nil {
+        log.Println(fmt.Errorf("Error reading response from remote server: %w", err))
+        return getChunkErrorResponses(requests, nil, "Error reading response from remote server")
+    }
+
+    // Decode responses
+    var responses []json.RawMessage
+    if response.StatusCode == http.StatusOK {
+        if err := json.Unmarshal(responseBody, &responses); err == nil {
+            return responses, nil
+        }
+    }
+
+    // Get the provider's error if it rejected the chunk with a single JSON-RPC error response
+    var errorResponse rpcErrorResponse
+    if err := json.Unmarshal(responseBody, &errorResponse); err != nil || string(errorResponse.Error) == "null" {
+        errorResponse.Error = nil
+    }
+    message := "Could not decode JSON-RPC batch response from remote server"
+    if response.StatusCode != http.StatusOK {
+        message = fmt.Sprintf("Remote server returned status %s", response.Status)
+    }
+    log.Printf("Error forwarding JSON-RPC batch chunk of %d requests: %s\n", len(requests), message)
+    return getChunkErrorResponses(requests, errorResponse.Error, message)
+
+}
+
+
+// Get an error response for each request in a chunk, keyed by its ID
+// The provider's error is used if set; requests without an ID are notifications and get no response
+func getChunkErrorResponses(requests []json.RawMessage, providerError json.RawMessage, errorMessage string) ([]json.RawMessage, error) {
+
+    // Get error
+    rpcErr := providerError
+    if len(rpcErr) == 0 {
+        var err error
+        rpcErr, err = json.Marshal(rpcError{Code: UpstreamErrorCode, Message: errorMessage})
+        if err != nil {
+            return nil, fmt.Errorf("Could not encode JSON-RPC error: %w", err)
+        }
+    }
+
+    // Get error responses
+    responses := []json.RawMessage{}
+    for _, request := range requests {
+        var message rpcMessage
+        if err := json.Unmarshal(request, &message); err != nil || len(message.ID) == 0 {
+            continue
+        }
+        response, err := json.Marshal(rpcErrorResponse{JSONRPC: "2.0", ID: message.ID, Error: rpcErr})
+        if err != nil {
+            return nil, fmt.Errorf("Could not encode JSON-RPC error response: %w", err)
+        }
+        responses = append(responses, response)
+    }
+    return responses, nil
+
+}
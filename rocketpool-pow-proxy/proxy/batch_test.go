@@ -0,0 +1,251 @@
+package proxy
+
+import (
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+
+// Get a test batch of requests with IDs 1 to count
+func getTestBatch(count int) string {
+    requests := []string{}
+    for id := 1; id <= count; id++ {
+        requests = append(requests, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"eth_blockNumber"}`, id))
+    }
+    return "[" + strings.Join(requests, ",") + "]"
+}
+
+
+// Get a test result response for an ID
+func getTestResult(id string) string {
+    return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":"0x%s"}`, id, id)
+}
+
+
+// Get a test upstream error response for an ID
+func getTestUpstreamError(id, message string) string {
+    return fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":%q}}`, id, UpstreamErrorCode, message)
+}
+
+
+// Start a test provider which answers each batch chunk with a handler given the chunk's request IDs
+func startTestProvider(t *testing.T, handler func(w http.ResponseWriter, ids []string)) (*httptest.Server, *[]int) {
+    chunkSizes := []int{}
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, err := ioutil.ReadAll(r.Body)
+        if err != nil {
+            t.Errorf("Could not read request: %s", err)
+            return
+        }
+        var requests []rpcMessage
+        if err := json.Unmarshal(body, &requests); err != nil {
+            t.Errorf("Could not decode batch chunk %s: %s", body, err)
+            return
+        }
+        ids := []string{}
+        for _, request := range requests {
+            ids = append(ids, string(request.ID))
+        }
+        chunkSizes = append(chunkSizes, len(requests))
+        handler(w, ids)
+    }))
+    return server, &chunkSizes
+}
+
+
+// Answer every request in a chunk in reverse order
+func answerReversed(w http.ResponseWriter, ids []string) {
+    responses := []string{}
+    for i := len(ids) - 1; i >= 0; i-- {
+        responses = append(responses, getTestResult(ids[i]))
+    }
+    fmt.Fprint(w, "[" + strings.Join(responses, ",") + "]")
+}
+
+
+// Check that two JSON messages are equal
+func checkJSON(t *testing.T, message []byte, expected string) {
+    t.Helper()
+    var decoded, decodedExpected interface{}
+    if err := json.Unmarshal(message, &decoded); err != nil {
+        t.Fatalf("Could not decode message %q: %s", message, err)
+    }
+    if err := json.Unmarshal([]byte(expected), &decodedExpected); err != nil {
+        t.Fatalf("Could not decode expected message %q: %s", expected, err)
+    }
+    if !reflect.DeepEqual(decoded, decodedExpected) {
+        t.Errorf("Expected message %s, got %s", expected, message)
+    }
+}
+
+
+func TestForwardBatch(t *testing.T) {
+    tests := []struct {
+        name string
+        maxBatchSize int
+        batch string
+        handler func(w http.ResponseWriter, ids []string)
+        chunkSizes []int
+        expected string
+    }{
+        {
+            name: "chunks reassembled in request order",
+            maxBatchSize: 2,
+            batch: getTestBatch(5),
+            handler: answerReversed,
+            chunkSizes: []int{2, 2, 1},
+            expected: "[" + strings.Join([]string{getTestResult("1"), getTestResult("2"), getTestResult("3"), getTestResult("4"), getTestResult("5")}, ",") + "]",
+        },
+        {
+            name: "batch within the maximum size",
+            maxBatchSize: 10,
+            batch: getTestBatch(3),
+            handler: answerReversed,
+            chunkSizes: []int{3},
+            expected: "[" + strings.Join([]string{getTestResult("1"), getTestResult("2"), getTestResult("3")}, ",") + "]",
+        },
+        {
+            name: "IDs missing from the response",
+            maxBatchSize: 2,
+            batch: getTestBatch(4),
+            handler: func(w http.ResponseWriter, ids []string) {
+                responses := []string{}
+                for _, id := range ids {
+                    if id != "2" {
+                        responses = append(responses, getTestResult(id))
+                    }
+                }
+                fmt.Fprint(w, "[" + strings.Join(responses, ",") + "]")
+            },
+            chunkSizes: []int{2, 2},
+            expected: "[" + strings.Join([]string{getTestResult("1"), getTestResult("3"), getTestResult("4")}, ",") + "]",
+        },
+        {
+            name: "responses with unknown IDs placed last",
+            maxBatchSize: 2,
+            batch: getTestBatch(2),
+            handler: func(w http.ResponseWriter, ids []string) {
+                fmt.Fprint(w, "[" + strings.Join([]string{getTestResult("9"), getTestResult("2"), getTestResult("1")}, ",") + "]")
+            },
+            chunkSizes: []int{2},
+            expected: "[" + strings.Join([]string{getTestResult("1"), getTestResult("2"), getTestResult("9")}, ",") + "]",
+        },
+        {
+            name: "chunk rejected with a status",
+            maxBatchSize: 2,
+            batch: getTestBatch(3),
+            handler: func(w http.ResponseWriter, ids []string) {
+                if ids[0] == "1" {
+                    http.Error(w, "too large", http.StatusRequestEntityTooLarge)
+                    return
+                }
+                answerReversed(w, ids)
+            },
+            chunkSizes: []int{2, 1},
+            expected: "[" + strings.Join([]string{
+                getTestUpstreamError("1", "Remote server returned status 413 Request Entity Too Large"),
+                getTestUpstreamError("2", "Remote server returned status 413 Request Entity Too Large"),
+                getTestResult("3"),
+            }, ",") + "]",
+        },
+        {
+            name: "chunk rejected with a single JSON-RPC error",
+            maxBatchSize: 2,
+            batch: getTestBatch(3),
+            handler: func(w http.ResponseWriter, ids []string) {
+                if ids[0] == "3" {
+                    fmt.Fprint(w, `{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"batch limit exceeded"}}`)
+                    return
+                }
+                answerReversed(w, ids)
+            },
+            chunkSizes: []int{2, 1},
+            expected: "[" + strings.Join([]string{
+                getTestResult("1"),
+                getTestResult("2"),
+                `{"jsonrpc":"2.0","id":3,"error":{"code":-32005,"message":"batch limit exceeded"}}`,
+            }, ",") + "]",
+        },
+        {
+            name: "chunk answered with an invalid response",
+            maxBatchSize: 2,
+            batch: getTestBatch(2),
+            handler: func(w http.ResponseWriter, ids []string) {
+                fmt.Fprint(w, "Internal error")
+            },
+            chunkSizes: []int{2},
+            expected: "[" + strings.Join([]string{
+                getTestUpstreamError("1", "Could not decode JSON-RPC batch response from remote server"),
+                getTestUpstreamError("2", "Could not decode JSON-RPC batch response from remote server"),
+            }, ",") + "]",
+        },
+        {
+            name: "notifications get no error response",
+            maxBatchSize: 2,
+            batch: `[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},{"jsonrpc":"2.0","method":"eth_blockNumber"}]`,
+            handler: func(w http.ResponseWriter, ids []string) {
+                w.WriteHeader(http.StatusServiceUnavailable)
+            },
+            chunkSizes: []int{2},
+            expected: "[" + getTestUpstreamError("1", "Remote server returned status 503 Service Unavailable") + "]",
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            provider, chunkSizes := startTestProvider(t, test.handler)
+            defer provider.Close()
+            p := &HttpProxyServer{MaxBatchSize: test.maxBatchSize}
+            response, err := p.forwardBatch(provider.URL, []byte(test.batch), "application/json")
+            if err != nil {
+                t.Fatalf("Could not forward batch: %s", err)
+            }
+            if !reflect.DeepEqual(*chunkSizes, test.chunkSizes) {
+                t.Errorf("Expected chunk sizes %v, got %v", test.chunkSizes, *chunkSizes)
+            }
+            checkJSON(t, response, test.expected)
+        })
+    }
+}
+
+
+// Chunks which can't reach the provider get an error response for each request
+func TestForwardBatchUnreachable(t *testing.T) {
+    provider := httptest.NewServer(http.NotFoundHandler())
+    provider.Close()
+    p := &HttpProxyServer{MaxBatchSize: 1}
+    response, err := p.forwardBatch(provider.URL, []byte(getTestBatch(2)), "application/json")
+    if err != nil {
+        t.Fatalf("Could not forward batch: %s", err)
+    }
+    checkJSON(t, response, "[" + strings.Join([]string{
+        getTestUpstreamError("1", "Error forwarding request to remote server"),
+        getTestUpstreamError("2", "Error forwarding request to remote server"),
+    }, ",") + "]")
+}
+
+
+// Batches which can't be decoded are forwarded to the provider unchanged
+func TestServeInvalidBatch(t *testing.T) {
+    var received string
+    provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, _ := ioutil.ReadAll(r.Body)
+        received = string(body)
+        fmt.Fprint(w, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`)
+    }))
+    defer provider.Close()
+    p := &HttpProxyServer{MaxBatchSize: 2, providerUrl: provider.URL}
+    request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[{"id":1,`))
+    request.Header.Set("Content-Type", "application/json")
+    recorder := httptest.NewRecorder()
+    p.ServeHTTP(recorder, request)
+    if received != `[{"id":1,` {
+        t.Errorf("Expected the invalid batch to be forwarded unchanged, got %q", received)
+    }
+    checkJSON(t, recorder.Body.Bytes(), `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`)
+}
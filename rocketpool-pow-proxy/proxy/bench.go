@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/rocket-pool/smartnode/shared/utils/latency"
+)
+
+// Config
+const BenchRequest = `{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`
+
+
+// JSON-RPC response error
+type rpcResponse struct {
+    Error *struct {
+        Code int `json:"code"`
+        Message string `json:"message"`
+    } `json:"error"`
+}
+
+
+// Make count eth_blockNumber requests to an upstream provider and summarize their latencies
+func Bench(providerUrl string, count int) latency.Summary {
+    return latency.Measure(count, func() error {
+
+        // Send request
+        response, err := http.Post(providerUrl, "application/json", bytes.NewReader([]byte(BenchRequest)))
+        if err != nil {
+            return err
+        }
+        defer response.Body.Close()
+        body, err := ioutil.ReadAll(response.Body)
+        if err != nil {
+            return fmt.Errorf("Error reading response from remote server: %w", err)
+        }
+        if response.StatusCode != http.StatusOK {
+            return fmt.Errorf("Remote server returned status %s", response.Status)
+        }
+
+        // Check for a JSON-RPC error
+        var rpc rpcResponse
+        if err := json.Unmarshal(body, &rpc); err != nil {
+            return fmt.Errorf("Could not decode response from remote server: %w", err)
+        }
+        if rpc.Error != nil {
+            return errors.New(rpc.Error.Message)
+        }
+        return nil
+
+    })
+}
@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+
+// Upstream provider settings
+// The websocket network, project ID & provider type override the shared settings for the websocket upstream only
+type ProviderConfig struct {
+    HttpProviderUrl string          `yaml:"httpProviderUrl,omitempty"`
+    WsProviderUrl string            `yaml:"wsProviderUrl,omitempty"`
+    WsFallbackProviderUrl string    `yaml:"wsFallbackProviderUrl,omitempty"`
+    Network string                  `yaml:"network,omitempty"`
+    ProjectId string                `yaml:"projectId,omitempty"`
+    ProviderType string             `yaml:"providerType,omitempty"`
+    WsNetwork string                `yaml:"wsNetwork,omitempty"`
+    WsProjectId string              `yaml:"wsProjectId,omitempty"`
+    WsProviderType string           `yaml:"wsProviderType,omitempty"`
+}
+
+
+// Get the provider settings for the websocket upstream, with its overrides applied
+func (config ProviderConfig) GetWsProviderConfig() ProviderConfig {
+    wsConfig := config
+    if config.WsNetwork != "" {
+        wsConfig.Network = config.WsNetwork
+    }
+    if config.WsProjectId != "" {
+        wsConfig.ProjectId = config.WsProjectId
+    }
+    if config.WsProviderType != "" {
+        wsConfig.ProviderType = config.WsProviderType
+    }
+    return wsConfig
+}
+
+
+// Check whether a websocket upstream is configured
+// Websocket upstreams are only available by URL or from Infura
+func (config ProviderConfig) HasWsProvider() bool {
+    return config.WsProviderUrl != "" || config.GetWsProviderConfig().ProviderType == "infura"
+}
+
+
+// Load provider settings from a config file
+// Settings missing from the file keep their values from defaults
+func LoadProviderConfig(path string, defaults ProviderConfig) (ProviderConfig, error) {
+
+    // Read file
+    bytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return ProviderConfig{}, fmt.Errorf("Could not read proxy config file at %s: %w", path, err)
+    }
+
+    // Decode settings over defaults
+    config := defaults
+    if err := yaml.Unmarshal(bytes, &config); err != nil {
+        return ProviderConfig{}, fmt.Errorf("Could not parse proxy config file at %s: %w", path, err)
+    }
+
+    // Return
+    return config, nil
+
+}
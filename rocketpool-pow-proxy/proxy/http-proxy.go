@@ -1,96 +1,224 @@
-package proxy
-
-import (
-	"errors"
-	"fmt"
-	"io"
-	"log"
-	"net/http"
-	"os"
-)
-
-// Config
-const InfuraURL = "https://%s.infura.io/v3/%s"
-const PocketURL = "https://%s.gateway.pokt.network/v1/%s"
-
-
-// Proxy server
-type HttpProxyServer struct {
-    Port string
-    ProviderUrl string
-}
-
-
-// Create new proxy server
-func NewHttpProxyServer(port string, providerUrl string, network string, projectId string, providerType string) *HttpProxyServer {
-
-    // Default provider to Infura
-    if providerType == "infura" {
-        providerUrl = fmt.Sprintf(InfuraURL, network, projectId)
-    } else if providerType == "pocket" {
-        providerUrl = fmt.Sprintf(PocketURL, network, projectId)
-    } else if providerUrl == "" {
-        fmt.Printf("Unknown provider [%s] and no providerUrl was provided, exiting.\n", providerType)
-        os.Exit(1)
-    }
-
-    // Create and return proxy server
-    return &HttpProxyServer{
-        Port: port,
-        ProviderUrl: providerUrl,
-    }
-
-}
-
-
-// Start proxy server
-func (p *HttpProxyServer) Start() error {
-
-    // Log
-    log.Printf("Proxy server listening on port %s\n", p.Port)
-
-    // Listen on RPC port
-    return http.ListenAndServe(":" + p.Port, p)
-
-}
-
-
-// Handle request / serve response
-func (p *HttpProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-
-    // Log request
-    log.Printf("New %s request received from %s\n", r.Method, r.RemoteAddr)
-
-    // Get request content type
-    contentTypes, ok := r.Header["Content-Type"]
-    if !ok || len(contentTypes) == 0 {
-        log.Println(errors.New("Request Content-Type header not specified"))
-        fmt.Fprintln(w, errors.New("Request Content-Type header not specified"))
-        return
-    }
-
-    // Forward request to provider
-    response, err := http.Post(p.ProviderUrl, contentTypes[0], r.Body)
-    if err != nil {
-        log.Println(fmt.Errorf("Error forwarding request to remote server: %w", err))
-        fmt.Fprintln(w, fmt.Errorf("Error forwarding request to remote server: %w", err))
-        return
-    }
-    defer response.Body.Close()
-
-    // Set response writer header
-    w.Header().Set("Content-Type", "application/json")
-
-    // Copy provider response body to response writer
-    _, err = io.Copy(w, response.Body)
-    if err != nil {
-        log.Println(fmt.Errorf("Error reading response from remote server: %w", err))
-        fmt.Fprintln(w, fmt.Errorf("Error reading response from remote server: %w", err))
-        return
-    }
-
-    // Log success
-    log.Printf("Response sent to %s successfully\n", r.RemoteAddr)
-
-}
-
+package proxy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config
+const InfuraURL = "https://%s.infura.io/v3/%s"
+const PocketURL = "https://%s.gateway.pokt.network/v1/%s"
+const CorsAllowedMethods = "POST, OPTIONS"
+const CorsAllowedHeaders = "Content-Type"
+
+
+// Proxy server
+type HttpProxyServer struct {
+    Port string
+    MaxBatchSize int
+    CorsOrigins []string
+    SlowRequestThreshold time.Duration
+    Stats *ClientStats
+    providerUrl string
+    lock sync.RWMutex
+}
+
+
+// Create new proxy server
+func NewHttpProxyServer(port string, providerUrl string, network string, projectId string, providerType string, maxBatchSize int, corsOrigins string) *HttpProxyServer {
+
+    // Get provider URL
+    providerUrl, err := GetHttpProviderUrl(providerUrl, network, projectId, providerType)
+    if err != nil {
+        fmt.Printf("%s, exiting.\n", err.Error())
+        os.Exit(1)
+    }
+
+    // Parse allowed CORS origins
+    origins := []string{}
+    for _, origin := range strings.Split(corsOrigins, ",") {
+        if origin = strings.TrimSpace(origin); origin != "" {
+            origins = append(origins, origin)
+        }
+    }
+
+    // Create and return proxy server
+    return &HttpProxyServer{
+        Port: port,
+        MaxBatchSize: maxBatchSize,
+        CorsOrigins: origins,
+        providerUrl: providerUrl,
+    }
+
+}
+
+
+// Get the HTTP provider URL for a provider type
+func GetHttpProviderUrl(providerUrl string, network string, projectId string, providerType string) (string, error) {
+
+    // Default provider to Infura
+    if providerType == "infura" || providerType == "pocket" {
+        network, err := ResolveNetwork(network, providerType)
+        if err != nil {
+            return "", err
+        }
+        if providerType == "infura" {
+            return fmt.Sprintf(InfuraURL, network, projectId), nil
+        }
+        return fmt.Sprintf(PocketURL, network, projectId), nil
+    } else if providerUrl == "" {
+        return "", fmt.Errorf("Unknown provider [%s] and no providerUrl was provided", providerType)
+    }
+    return providerUrl, nil
+
+}
+
+
+// Get / set the upstream provider URL
+// Requests already in flight continue against the provider URL they started with
+func (p *HttpProxyServer) GetProviderUrl() string {
+    p.lock.RLock()
+    defer p.lock.RUnlock()
+    return p.providerUrl
+}
+func (p *HttpProxyServer) SetProviderUrl(providerUrl string) {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.providerUrl = providerUrl
+}
+
+
+// Start proxy server
+func (p *HttpProxyServer) Start() error {
+
+    // Log
+    log.Printf("Proxy server listening on port %s\n", p.Port)
+
+    // Listen on RPC port
+    return http.ListenAndServe(":" + p.Port, p)
+
+}
+
+
+// Handle request / serve response
+func (p *HttpProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+    // Log request
+    log.Printf("New %s request received from %s\n", r.Method, r.RemoteAddr)
+
+    // Set CORS headers and answer preflight requests from allowed origins
+    if p.setCorsHeaders(w, r) && r.Method == http.MethodOptions {
+        w.WriteHeader(http.StatusNoContent)
+        return
+    }
+
+    // Count request by client
+    if p.Stats != nil {
+        p.Stats.Record(p.Stats.GetLabel(r), 1)
+    }
+
+    // Get request content type
+    contentTypes, ok := r.Header["Content-Type"]
+    if !ok || len(contentTypes) == 0 {
+        log.Println(errors.New("Request Content-Type header not specified"))
+        fmt.Fprintln(w, errors.New("Request Content-Type header not specified"))
+        return
+    }
+
+    // Get provider URL
+    providerUrl := p.GetProviderUrl()
+
+    // Read the request body if needed to split batches or log slow requests
+    body := r.Body
+    var requestBody []byte
+    if p.MaxBatchSize > 0 || p.SlowRequestThreshold > 0 {
+        var err error
+        requestBody, err = ioutil.ReadAll(r.Body)
+        if err != nil {
+            log.Println(fmt.Errorf("Error reading request body: %w", err))
+            fmt.Fprintln(w, fmt.Errorf("Error reading request body: %w", err))
+            return
+        }
+        body = ioutil.NopCloser(bytes.NewReader(requestBody))
+    }
+
+    // Split oversized JSON-RPC batches if enabled
+    // Batches which can't be decoded are forwarded unchanged, so the provider answers them with its own parse error
+    if p.MaxBatchSize > 0 && isBatch(requestBody) {
+        if responseBody, err := p.forwardBatch(providerUrl, requestBody, contentTypes[0]); err != nil {
+            log.Println(err)
+        } else {
+            w.Header().Set("Content-Type", "application/json")
+            if _, err := w.Write(responseBody); err != nil {
+                log.Println(fmt.Errorf("Error writing response: %w", err))
+                return
+            }
+            log.Printf("Response sent to %s successfully\n", r.RemoteAddr)
+            return
+        }
+    }
+
+    // Forward request to provider
+    start := time.Now()
+    response, err := http.Post(providerUrl, contentTypes[0], body)
+    p.logIfSlow(requestBody, time.Since(start))
+    if err != nil {
+        log.Println(fmt.Errorf("Error forwarding request to remote server: %w", err))
+        fmt.Fprintln(w, fmt.Errorf("Error forwarding request to remote server: %w", err))
+        return
+    }
+    defer response.Body.Close()
+
+    // Set response writer header
+    w.Header().Set("Content-Type", "application/json")
+
+    // Copy provider response body to response writer
+    _, err = io.Copy(w, response.Body)
+    if err != nil {
+        log.Println(fmt.Errorf("Error reading response from remote server: %w", err))
+        fmt.Fprintln(w, fmt.Errorf("Error reading response from remote server: %w", err))
+        return
+    }
+
+    // Log success
+    log.Printf("Response sent to %s successfully\n", r.RemoteAddr)
+
+}
+
+
+// Set CORS response headers if the request origin is allowed
+// Returns whether CORS is configured and the origin is allowed
+func (p *HttpProxyServer) setCorsHeaders(w http.ResponseWriter, r *http.Request) bool {
+    if len(p.CorsOrigins) == 0 {
+        return false
+    }
+    origin := r.Header.Get("Origin")
+    allowedOrigin := ""
+    for _, corsOrigin := range p.CorsOrigins {
+        if corsOrigin == "*" {
+            allowedOrigin = "*"
+            break
+        }
+        if origin != "" && strings.EqualFold(corsOrigin, origin) {
+            allowedOrigin = origin
+            w.Header().Add("Vary", "Origin")
+            break
+        }
+    }
+    if allowedOrigin == "" {
+        return false
+    }
+    w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+    w.Header().Set("Access-Control-Allow-Methods", CorsAllowedMethods)
+    w.Header().Set("Access-Control-Allow-Headers", CorsAllowedHeaders)
+    return true
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import (
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+
+// Preflight requests are only answered by the proxy when CORS is configured and the origin is allowed
+func TestServeCorsPreflight(t *testing.T) {
+    tests := []struct {
+        name string
+        corsOrigins []string
+        origin string
+        answered bool
+        allowOrigin string
+    }{
+        {
+            name: "CORS not configured",
+            origin: "https://app.example.com",
+        },
+        {
+            name: "origin allowed",
+            corsOrigins: []string{"https://app.example.com"},
+            origin: "https://APP.example.com",
+            answered: true,
+            allowOrigin: "https://APP.example.com",
+        },
+        {
+            name: "any origin allowed",
+            corsOrigins: []string{"*"},
+            origin: "https://other.example.com",
+            answered: true,
+            allowOrigin: "*",
+        },
+        {
+            name: "origin not allowed",
+            corsOrigins: []string{"https://app.example.com"},
+            origin: "https://other.example.com",
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+
+            // Start provider
+            forwarded := false
+            provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+                forwarded = true
+                fmt.Fprint(w, `{"jsonrpc":"2.0","id":null,"result":null}`)
+            }))
+            defer provider.Close()
+
+            // Send preflight request
+            p := &HttpProxyServer{CorsOrigins: test.corsOrigins, providerUrl: provider.URL}
+            request := httptest.NewRequest(http.MethodOptions, "/", nil)
+            request.Header.Set("Origin", test.origin)
+            request.Header.Set("Content-Type", "application/json")
+            recorder := httptest.NewRecorder()
+            p.ServeHTTP(recorder, request)
+
+            // Check response
+            if test.answered {
+                if recorder.Code != http.StatusNoContent {
+                    t.Errorf("Expected status %d, got %d", http.StatusNoContent, recorder.Code)
+                }
+                if forwarded {
+                    t.Error("Expected the preflight request not to be forwarded to the provider")
+                }
+            } else if !forwarded {
+                t.Error("Expected the request to be forwarded to the provider")
+            }
+            if allowOrigin := recorder.Header().Get("Access-Control-Allow-Origin"); allowOrigin != test.allowOrigin {
+                t.Errorf("Expected allowed origin %q, got %q", test.allowOrigin, allowOrigin)
+            }
+
+        })
+    }
+}
@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// JSON-RPC request method
+type rpcRequest struct {
+    Method string `json:"method"`
+}
+
+
+// Log a forwarded request at warning level if its upstream latency exceeded the slow request threshold
+func (p *HttpProxyServer) logIfSlow(body []byte, duration time.Duration) {
+    if p.SlowRequestThreshold <= 0 || duration < p.SlowRequestThreshold {
+        return
+    }
+    log.Printf("WARNING: Slow upstream request: %s took %s (threshold %s)\n", getRequestMethods(body), duration.String(), p.SlowRequestThreshold.String())
+}
+
+
+// Get a description of the JSON-RPC methods in a request body, e.g. "eth_call" or "batch of 3 [eth_call, eth_getBalance]"
+func getRequestMethods(body []byte) string {
+
+    // Decode single request
+    if !isBatch(body) {
+        var request rpcRequest
+        if err := json.Unmarshal(body, &request); err != nil || request.Method == "" {
+            return "unknown method"
+        }
+        return request.Method
+    }
+
+    // Decode batch
+    var requests []rpcRequest
+    if err := json.Unmarshal(body, &requests); err != nil {
+        return "batch of unknown methods"
+    }
+    methods := []string{}
+    seen := map[string]bool{}
+    for _, request := range requests {
+        if request.Method == "" || seen[request.Method] {
+            continue
+        }
+        seen[request.Method] = true
+        methods = append(methods, request.Method)
+    }
+    return fmt.Sprintf("batch of %d [%s]", len(requests), strings.Join(methods, ", "))
+
+}
@@ -0,0 +1,150 @@
+package proxy
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config
+const ClientLabelSourceIp = "ip"
+const ClientLabelHeaderPrefix = "header:"
+const UnknownClientLabel = "unknown"
+const OtherClientLabel = "other"
+const MaxClientLabels = 100
+const MaxClientLabelLength = 64
+
+
+// Request counts broken down by client label
+// The label is taken from the source IP or from a request header, depending on the label source
+// Labels are client-controlled, so they are truncated and only the first MaxClientLabels are counted separately; later clients are counted as 'other'
+type ClientStats struct {
+    labelSource string
+    labelHeader string
+    counts map[string]uint64
+    lock sync.Mutex
+}
+
+
+// Create new client stats
+// labelSource is 'ip' or 'header:<name>'
+func NewClientStats(labelSource string) (*ClientStats, error) {
+    stats := &ClientStats{
+        labelSource: labelSource,
+        counts: map[string]uint64{},
+    }
+    if strings.HasPrefix(labelSource, ClientLabelHeaderPrefix) {
+        stats.labelHeader = strings.TrimSpace(strings.TrimPrefix(labelSource, ClientLabelHeaderPrefix))
+        if stats.labelHeader == "" {
+            return nil, fmt.Errorf("Invalid client label source '%s' - no header name was provided", labelSource)
+        }
+    } else if labelSource != ClientLabelSourceIp {
+        return nil, fmt.Errorf("Invalid client label source '%s' - must be '%s' or '%s<name>'", labelSource, ClientLabelSourceIp, ClientLabelHeaderPrefix)
+    }
+    return stats, nil
+}
+
+
+// Get the client label for a request
+func (s *ClientStats) GetLabel(r *http.Request) string {
+    var label string
+    if s.labelHeader != "" {
+        label = strings.TrimSpace(r.Header.Get(s.labelHeader))
+    } else if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+        label = host
+    } else {
+        label = r.RemoteAddr
+    }
+    if label == "" {
+        return UnknownClientLabel
+    }
+    if len(label) > MaxClientLabelLength {
+        label = label[:MaxClientLabelLength]
+    }
+    return strings.ToValidUTF8(label, "")
+}
+
+
+// Record a number of requests for a client label
+func (s *ClientStats) Record(label string, requests uint64) {
+    if s == nil {
+        return
+    }
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    if _, ok := s.counts[label]; !ok && len(s.counts) >= MaxClientLabels {
+        label = OtherClientLabel
+    }
+    s.counts[label] += requests
+}
+
+
+// Get a sorted copy of the request counts
+func (s *ClientStats) getCounts() ([]string, map[string]uint64, uint64) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    labels := make([]string, 0, len(s.counts))
+    counts := make(map[string]uint64, len(s.counts))
+    var total uint64
+    for label, count := range s.counts {
+        labels = append(labels, label)
+        counts[label] = count
+        total += count
+    }
+    sort.Slice(labels, func(i, j int) bool {
+        if counts[labels[i]] != counts[labels[j]] {
+            return counts[labels[i]] > counts[labels[j]]
+        }
+        return labels[i] < labels[j]
+    })
+    return labels, counts, total
+}
+
+
+// Log the request counts per client label every interval
+func (s *ClientStats) LogPeriodically(interval time.Duration) {
+    for {
+        time.Sleep(interval)
+        labels, counts, total := s.getCounts()
+        if total == 0 {
+            continue
+        }
+        log.Printf("Requests by client (%s) since start: %d total\n", s.labelSource, total)
+        for _, label := range labels {
+            log.Printf("  %s: %d (%.1f%%)\n", label, counts[label], float64(counts[label]) * 100 / float64(total))
+        }
+    }
+}
+
+
+// Serve the request counts per client label in the Prometheus text exposition format
+func (s *ClientStats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    labels, counts, _ := s.getCounts()
+    w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+    fmt.Fprint(w, "# HELP rocketpool_proxy_requests_total The number of requests forwarded by the proxy per client\n")
+    fmt.Fprint(w, "# TYPE rocketpool_proxy_requests_total counter\n")
+    for _, label := range labels {
+        fmt.Fprintf(w, "rocketpool_proxy_requests_total{client=\"%s\"} %d\n", escapeLabelValue(label), counts[label])
+    }
+}
+
+
+// Escape a Prometheus label value: backslashes, double quotes & line feeds are escaped
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+func escapeLabelValue(value string) string {
+    return labelValueEscaper.Replace(value)
+}
+
+
+// Serve the request count metrics on an address & port
+func (s *ClientStats) StartMetricsServer(address string, port string) error {
+    mux := http.NewServeMux()
+    mux.Handle("/metrics", s)
+    log.Printf("Proxy metrics server listening on %s port %s\n", address, port)
+    return http.ListenAndServe(net.JoinHostPort(address, port), mux)
+}
@@ -0,0 +1,57 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+
+func TestClientStatsLabels(t *testing.T) {
+    stats, err := NewClientStats(ClientLabelHeaderPrefix + "X-Client")
+    if err != nil { t.Fatal(err) }
+
+    // Labels are truncated to valid UTF-8
+    for header, expected := range map[string]string{
+        "": UnknownClientLabel,
+        " geth ": "geth",
+        strings.Repeat("a", MaxClientLabelLength + 10): strings.Repeat("a", MaxClientLabelLength),
+        strings.Repeat("a", MaxClientLabelLength - 1) + "é": strings.Repeat("a", MaxClientLabelLength - 1),
+    } {
+        r := httptest.NewRequest(http.MethodPost, "/", nil)
+        r.Header.Set("X-Client", header)
+        if label := stats.GetLabel(r); label != expected {
+            t.Errorf("Expected label %q for header %q, got %q", expected, header, label)
+        }
+    }
+
+    // Clients beyond the label limit are counted as other
+    for i := 0; i < MaxClientLabels + 10; i++ {
+        stats.Record(fmt.Sprintf("client-%d", i), 1)
+    }
+    stats.Record("client-0", 1)
+    labels, counts, total := stats.getCounts()
+    if len(labels) != MaxClientLabels + 1 {
+        t.Errorf("Expected %d labels, got %d", MaxClientLabels + 1, len(labels))
+    }
+    if counts[OtherClientLabel] != 10 || counts["client-0"] != 2 || total != MaxClientLabels + 11 {
+        t.Errorf("Unexpected counts: other %d, client-0 %d, total %d", counts[OtherClientLabel], counts["client-0"], total)
+    }
+}
+
+
+func TestClientStatsMetrics(t *testing.T) {
+    stats, err := NewClientStats(ClientLabelSourceIp)
+    if err != nil { t.Fatal(err) }
+    stats.Record("a\"b\\c\nd", 3)
+
+    // Label values use Prometheus escaping rather than Go quoting
+    w := httptest.NewRecorder()
+    stats.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+    expected := "rocketpool_proxy_requests_total{client=\"a\\\"b\\\\c\\nd\"} 3\n"
+    if !strings.Contains(w.Body.String(), expected) {
+        t.Errorf("Expected metric %q, got:\n%s", expected, w.Body.String())
+    }
+}
@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Config
+const UnmaskedProjectIdLength = 4
+
+
+// Infura & Pocket network names by chain ID
+var infuraNetworks = map[uint64]string{
+    1: "mainnet",
+    3: "ropsten",
+    4: "rinkeby",
+    5: "goerli",
+    42: "kovan",
+    17000: "holesky",
+    11155111: "sepolia",
+}
+var pocketNetworks = map[uint64]string{
+    1: "eth-mainnet",
+    3: "eth-ropsten",
+    4: "eth-rinkeby",
+    5: "eth-goerli",
+    42: "poa-kovan",
+}
+
+
+// Resolve a network for a provider type
+// Network names are returned unchanged; numeric chain IDs are mapped to the provider's network name
+func ResolveNetwork(network string, providerType string) (string, error) {
+
+    // Check for a chain ID
+    chainId, err := strconv.ParseUint(network, 10, 64)
+    if err != nil {
+        return network, nil
+    }
+
+    // Get network name
+    var networks map[uint64]string
+    switch providerType {
+        case "infura": networks = infuraNetworks
+        case "pocket": networks = pocketNetworks
+        default: return "", fmt.Errorf("Chain ID %d can only be used as the network with the infura or pocket provider types", chainId)
+    }
+    name, ok := networks[chainId]
+    if !ok {
+        return "", fmt.Errorf("Chain ID %d is not supported by the %s provider; please specify the network by name", chainId, providerType)
+    }
+    return name, nil
+
+}
+
+
+// Mask the project ID in a provider URL so it can be safely printed
+func MaskProjectId(providerUrl string, projectId string) string {
+    if projectId == "" {
+        return providerUrl
+    }
+    var masked string
+    if len(projectId) > UnmaskedProjectIdLength {
+        masked = projectId[:UnmaskedProjectIdLength] + strings.Repeat("*", len(projectId) - UnmaskedProjectIdLength)
+    } else {
+        masked = strings.Repeat("*", len(projectId))
+    }
+    return strings.ReplaceAll(providerUrl, projectId, masked)
+}
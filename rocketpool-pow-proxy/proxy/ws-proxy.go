@@ -1,126 +1,279 @@
-package proxy
-
-import (
-	"fmt"
-	"log"
-	"net/http"
-	"sync"
-
-	"github.com/gorilla/websocket"
-)
-
-// Config
-const InfuraWsURL = "wss://%s.infura.io/ws/v3/%s"
-
-
-// Proxy server
-type WsProxyServer struct {
-    Port string
-    ProviderUrl string
-}
-
-
-// Create new proxy server
-func NewWsProxyServer(port string, providerUrl string, network string, projectId string) *WsProxyServer {
-
-    // Default provider to Infura
-    if providerUrl == "" {
-        providerUrl = fmt.Sprintf(InfuraWsURL, network, projectId)
-    }
-
-    // Create and return proxy server
-    return &WsProxyServer{
-        Port: port,
-        ProviderUrl: providerUrl,
-    }
-
-}
-
-
-// Start proxy server
-func (p *WsProxyServer) Start() error {
-
-    // Log
-    log.Printf("Proxy server listening on port %s\n", p.Port)
-
-    // Listen on RPC port
-    return http.ListenAndServe(":" + p.Port, p)
-}
-
-
-// Handle request / serve response
-func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-
-    var upgrader = websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool { return true },
-	}
-
-    // Establish a websocket with the requester
-    eth2Connection, err := upgrader.Upgrade(w, r, nil)
-	if err != nil {
-        log.Println(fmt.Errorf("Error upgrading websocket: %w", err))
-        fmt.Fprintln(w, fmt.Errorf("Error upgrading websocket: %w", err))
-		return
-	}
-	defer eth2Connection.Close()
-
-    // Connect to Infura
-    infuraConnection, _, err := websocket.DefaultDialer.Dial(p.ProviderUrl, nil)
-    if err != nil {
-        log.Println(fmt.Errorf("Error connecting to remote websocket: %w", err))
-        fmt.Fprintln(w, fmt.Errorf("Error connecting to remote websocket: %w", err))
-	}
-	defer infuraConnection.Close()
-
-    // Wait groups for the proxy loops
-    wg := new(sync.WaitGroup)
-    wg.Add(2)
-
-    // Run the eth2-to-remote loop
-	go func() {
-        for {
-            // Read from eth2
-            mt, message, err := eth2Connection.ReadMessage()
-		    if err != nil {
-                log.Println(fmt.Errorf("Error reading from eth2: %w", err))
-                fmt.Fprintln(w, fmt.Errorf("Error reading from eth2: %w", err))
-			    break
-		    }
-
-            // Send it to the remote server
-            if err = infuraConnection.WriteMessage(mt, message); err != nil {
-                log.Println(fmt.Errorf("Error writing to remote websocket: %w", err))
-                fmt.Fprintln(w, fmt.Errorf("Error writing to remote websocket: %w", err))
-			    break
-		    }
-        }
-
-        wg.Done()
-	}()
-	
-    // Run the remote-to-eth2 loop
-    go func() {
-        for {
-            // Read from the remote server
-            mt, message, err := infuraConnection.ReadMessage()
-		    if err != nil {
-                log.Println(fmt.Errorf("Error reading from remote websocket: %w", err))
-                fmt.Fprintln(w, fmt.Errorf("Error reading from remote websocket: %w", err))
-			    break
-		    }
-
-            // Send it to eth2
-            if err = eth2Connection.WriteMessage(mt, message); err != nil {
-                log.Println(fmt.Errorf("Error writing to eth2: %w", err))
-                fmt.Fprintln(w, fmt.Errorf("Error writing to eth2: %w", err))
-			    break
-		    }
-        }
-
-        wg.Done()
-    }()
-
-    // Wait for both loops to stop
-	wg.Wait()
-	return
-}
+package proxy
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Config
+const InfuraWsURL = "wss://%s.infura.io/ws/v3/%s"
+const WsUpstreamRetryInterval = 30 * time.Second
+
+
+// Proxy server
+type WsProxyServer struct {
+    Port string
+    Stats *ClientStats
+    providerUrl string
+    fallbackProviderUrl string
+    upstreamAvailable bool
+    retryingUpstream bool
+    lock sync.RWMutex
+}
+
+
+// Create new proxy server
+func NewWsProxyServer(port string, providerUrl string, fallbackProviderUrl string, network string, projectId string) *WsProxyServer {
+
+    // Get provider URL
+    providerUrl, err := GetWsProviderUrl(providerUrl, network, projectId)
+    if err != nil {
+        fmt.Printf("%s, exiting.\n", err.Error())
+        os.Exit(1)
+    }
+
+    // Create and return proxy server
+    return &WsProxyServer{
+        Port: port,
+        providerUrl: providerUrl,
+        fallbackProviderUrl: fallbackProviderUrl,
+    }
+
+}
+
+
+// Get the websocket provider URL, defaulting to Infura
+func GetWsProviderUrl(providerUrl string, network string, projectId string) (string, error) {
+    if providerUrl == "" {
+        network, err := ResolveNetwork(network, "infura")
+        if err != nil {
+            return "", err
+        }
+        return fmt.Sprintf(InfuraWsURL, network, projectId), nil
+    }
+    return providerUrl, nil
+}
+
+
+// Get / set the upstream provider URL
+// Open websocket connections stay on the provider URL they were established with
+func (p *WsProxyServer) GetProviderUrl() string {
+    p.lock.RLock()
+    defer p.lock.RUnlock()
+    return p.providerUrl
+}
+func (p *WsProxyServer) SetProviderUrl(providerUrl string) {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.providerUrl = providerUrl
+}
+
+
+// Get / set the fallback upstream provider URL
+// Open websocket connections fail over to the fallback if their upstream fails
+func (p *WsProxyServer) GetFallbackProviderUrl() string {
+    p.lock.RLock()
+    defer p.lock.RUnlock()
+    return p.fallbackProviderUrl
+}
+func (p *WsProxyServer) SetFallbackProviderUrl(fallbackProviderUrl string) {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.fallbackProviderUrl = fallbackProviderUrl
+}
+
+
+// Start proxy server
+// An unreachable upstream doesn't prevent the server from starting; it is retried in the background
+func (p *WsProxyServer) Start() error {
+
+    // Check the upstream connection
+    if err := p.checkUpstream(); err != nil {
+        log.Println(fmt.Errorf("Remote websocket is unavailable, retrying every %s: %w", WsUpstreamRetryInterval, err))
+        p.setUpstreamUnavailable()
+    } else {
+        p.setUpstreamAvailable()
+    }
+
+    // Log
+    log.Printf("Proxy server listening on port %s\n", p.Port)
+
+    // Listen on RPC port
+    return http.ListenAndServe(":" + p.Port, p)
+}
+
+
+// Check whether the remote websocket is reachable
+func (p *WsProxyServer) checkUpstream() error {
+    connection, _, err := websocket.DefaultDialer.Dial(p.GetProviderUrl(), nil)
+    if err != nil {
+        return err
+    }
+    connection.Close()
+    return nil
+}
+
+
+// Retry the remote websocket connection until it is reachable, or a client connects to it successfully
+func (p *WsProxyServer) retryUpstream() {
+    for {
+        time.Sleep(WsUpstreamRetryInterval)
+        if err := p.checkUpstream(); !p.stopRetryingUpstream(err) {
+            log.Println(fmt.Errorf("Remote websocket is still unavailable: %w", err))
+            continue
+        }
+        log.Println("Remote websocket is now available.")
+        return
+    }
+}
+
+
+// Stop the retry loop if the remote websocket is now available
+// The retry state is checked & cleared under one lock so that a failure can't be missed between the two
+func (p *WsProxyServer) stopRetryingUpstream(checkErr error) bool {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    if checkErr != nil && !p.upstreamAvailable {
+        return false
+    }
+    p.upstreamAvailable = true
+    p.retryingUpstream = false
+    return true
+}
+
+
+// Mark the remote websocket available; a running retry loop stops at its next check
+func (p *WsProxyServer) setUpstreamAvailable() {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.upstreamAvailable = true
+}
+
+
+// Mark the remote websocket unavailable, and start retrying it unless a retry loop is already running
+// The retry state is checked & set under one lock so that concurrent failures only start one retry loop
+func (p *WsProxyServer) setUpstreamUnavailable() {
+    p.lock.Lock()
+    defer p.lock.Unlock()
+    p.upstreamAvailable = false
+    if p.retryingUpstream {
+        return
+    }
+    p.retryingUpstream = true
+    go p.retryUpstream()
+}
+
+
+// Handle request / serve response
+func (p *WsProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+    // Connect to the remote websocket before upgrading so that failures can be reported to the requester
+    session, err := newWsSession([]string{p.GetProviderUrl(), p.GetFallbackProviderUrl()})
+    if err != nil {
+        p.setUpstreamUnavailable()
+        log.Println(fmt.Errorf("Error connecting to remote websocket: %w", err))
+        http.Error(w, fmt.Sprintf("Error connecting to remote websocket: %s", err.Error()), http.StatusBadGateway)
+        return
+    }
+    p.setUpstreamAvailable()
+
+    var upgrader = websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+    // Establish a websocket with the requester
+    eth2Connection, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+        log.Println(fmt.Errorf("Error upgrading websocket: %w", err))
+        session.upstream.Close()
+		return
+	}
+    session.client = eth2Connection
+	defer session.close()
+
+    // Get the client label for counting messages
+    var clientLabel string
+    if p.Stats != nil {
+        clientLabel = p.Stats.GetLabel(r)
+    }
+
+    // Wait groups for the proxy loops
+    wg := new(sync.WaitGroup)
+    wg.Add(2)
+
+    // Run the eth2-to-remote loop
+	go func() {
+        defer wg.Done()
+        defer session.close()
+        for {
+            // Read from eth2
+            mt, message, err := eth2Connection.ReadMessage()
+		    if err != nil {
+                if !session.isClosed() {
+                    log.Println(fmt.Errorf("Error reading from eth2: %w", err))
+                }
+			    return
+		    }
+
+            // Count message by client
+            p.Stats.Record(clientLabel, 1)
+
+            // Send it to the remote server
+            if err = session.forwardClientMessage(mt, message); err != nil {
+                log.Println(fmt.Errorf("Error writing to remote websocket: %w", err))
+			    return
+		    }
+        }
+	}()
+	
+    // Run the remote-to-eth2 loop
+    // Subscriptions stay on the current upstream until it fails, and are then re-created on the fallback
+    go func() {
+        defer wg.Done()
+        defer session.close()
+        for {
+            // Read from the remote server
+            upstream, generation := session.getUpstream()
+            mt, message, err := upstream.ReadMessage()
+		    if err != nil {
+                if session.isClosed() {
+                    return
+                }
+                log.Println(fmt.Errorf("Error reading from remote websocket: %w", err))
+                if err := session.failover(generation); err != nil {
+                    log.Println(fmt.Errorf("Could not fail over to another remote websocket: %w", err))
+                    return
+                }
+                continue
+		    }
+
+            // Send any reply back to the remote server
+            message, reply := session.handleUpstreamMessage(message)
+            if reply != nil {
+                if err := session.writeUpstream(websocket.TextMessage, reply, nil); err != nil {
+                    log.Println(fmt.Errorf("Error writing to remote websocket: %w", err))
+                    return
+                }
+            }
+
+            // Send it to eth2
+            if message == nil {
+                continue
+            }
+            if err = eth2Connection.WriteMessage(mt, message); err != nil {
+                log.Println(fmt.Errorf("Error writing to eth2: %w", err))
+			    return
+		    }
+        }
+    }()
+
+    // Wait for both loops to stop
+	wg.Wait()
+	return
+}
@@ -0,0 +1,436 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Config
+const WsResubscribeIdPrefix = "rp-resubscribe-"
+
+
+// A subscription made by a websocket client
+type wsSubscription struct {
+    clientId string
+    params json.RawMessage
+}
+
+
+// A subscription request awaiting its response from the upstream
+// Internal requests re-create an existing subscription after a failover, and their responses are not forwarded to the client
+// If the client unsubscribes while an internal request is pending, its unsubscribe request is sent once the subscription is re-created
+type wsPendingSubscribe struct {
+    request []byte
+    params json.RawMessage
+    clientId string
+    internal bool
+    sent bool
+    unsubscribeId json.RawMessage
+}
+
+
+// A websocket client session
+// The session stays bound to one upstream until it fails; it then fails over to the other upstream and re-creates its subscriptions there,
+// translating the new upstream subscription IDs back to the IDs the client already knows
+type wsSession struct {
+    client *websocket.Conn
+    upstream *websocket.Conn
+    upstreamUrls []string
+    upstreamIndex int
+    generation int
+    closed bool
+    subscriptions map[string]*wsSubscription
+    pending map[string]*wsPendingSubscribe
+    nextResubscribeId int
+    lock sync.Mutex
+}
+
+
+// Create a new websocket session
+// The session is bound to the first reachable upstream URL
+func newWsSession(upstreamUrls []string) (*wsSession, error) {
+    session := &wsSession{
+        subscriptions: map[string]*wsSubscription{},
+        pending: map[string]*wsPendingSubscribe{},
+    }
+    var err error
+    for ui, upstreamUrl := range upstreamUrls {
+        if upstreamUrl == "" {
+            continue
+        }
+        session.upstreamUrls = append(session.upstreamUrls, upstreamUrl)
+        if session.upstream != nil {
+            continue
+        }
+        var connection *websocket.Conn
+        connection, _, err = websocket.DefaultDialer.Dial(upstreamUrl, nil)
+        if err != nil {
+            if ui < len(upstreamUrls) - 1 {
+                log.Println(fmt.Errorf("Error connecting to remote websocket, trying the fallback: %w", err))
+            }
+            continue
+        }
+        session.upstream = connection
+        session.upstreamIndex = len(session.upstreamUrls) - 1
+    }
+    if session.upstream == nil {
+        return nil, err
+    }
+    return session, nil
+}
+
+
+// Get the current upstream connection and its generation
+func (s *wsSession) getUpstream() (*websocket.Conn, int) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    return s.upstream, s.generation
+}
+
+
+// Check whether the session has been closed
+func (s *wsSession) isClosed() bool {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    return s.closed
+}
+
+
+// Close the session's connections
+func (s *wsSession) close() {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    s.closed = true
+    s.upstream.Close()
+    s.client.Close()
+}
+
+
+// Forward a client message to the upstream, failing over once if the write fails
+func (s *wsSession) forwardClientMessage(messageType int, message []byte) error {
+    message, request := s.handleClientMessage(message)
+    if message == nil {
+        return nil
+    }
+    return s.writeUpstream(messageType, message, request)
+}
+
+
+// Write a message to the upstream, failing over once if the write fails
+// A subscription request is marked as sent once written, so a failover re-sends it if its response is lost
+func (s *wsSession) writeUpstream(messageType int, message []byte, request *wsPendingSubscribe) error {
+    for attempt := 0; attempt < 2; attempt++ {
+        s.lock.Lock()
+        generation := s.generation
+        err := s.upstream.WriteMessage(messageType, message)
+        if err == nil && request != nil {
+            request.sent = true
+        }
+        s.lock.Unlock()
+        if err == nil {
+            return nil
+        }
+        log.Println(fmt.Errorf("Error writing to remote websocket: %w", err))
+        if err := s.failover(generation); err != nil {
+            return err
+        }
+    }
+    return errors.New("Could not write to any remote websocket")
+}
+
+
+// Switch the session to the other upstream and re-create its subscriptions there
+// Does nothing if the upstream has already been switched since generation
+func (s *wsSession) failover(generation int) error {
+
+    // Check for an existing failover
+    s.lock.Lock()
+    if s.closed {
+        s.lock.Unlock()
+        return errors.New("The websocket session is closed")
+    }
+    if s.generation != generation {
+        s.lock.Unlock()
+        return nil
+    }
+    if len(s.upstreamUrls) < 2 {
+        s.lock.Unlock()
+        return errors.New("No fallback remote websocket is configured")
+    }
+    upstreamIndex := (s.upstreamIndex + 1) % len(s.upstreamUrls)
+    upstreamUrl := s.upstreamUrls[upstreamIndex]
+    s.lock.Unlock()
+
+    // Connect to the other upstream; the session is not locked while connecting, so client messages are not blocked
+    connection, _, err := websocket.DefaultDialer.Dial(upstreamUrl, nil)
+    if err != nil {
+        return fmt.Errorf("Error connecting to fallback remote websocket: %w", err)
+    }
+
+    // Check the session was not closed or switched while connecting
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    if s.closed {
+        connection.Close()
+        return errors.New("The websocket session is closed")
+    }
+    if s.generation != generation {
+        connection.Close()
+        return nil
+    }
+    s.upstream.Close()
+    s.upstream = connection
+    s.upstreamIndex = upstreamIndex
+    s.generation++
+    log.Printf("Remote websocket failed, switched to upstream %d and re-creating %d subscription(s).\n", upstreamIndex + 1, len(s.subscriptions))
+
+    // Re-send subscription requests still awaiting a response
+    // Client requests which haven't been sent yet are written by the client loop once the failover completes
+    pending := s.pending
+    s.pending = map[string]*wsPendingSubscribe{}
+    for id, request := range pending {
+        if request.internal {
+            resubscribe, err := s.resubscribe(request.clientId, request.params)
+            if err != nil {
+                return err
+            }
+            resubscribe.unsubscribeId = request.unsubscribeId
+            continue
+        }
+        s.pending[id] = request
+        if !request.sent {
+            continue
+        }
+        if err := s.upstream.WriteMessage(websocket.TextMessage, request.request); err != nil {
+            return fmt.Errorf("Error writing to fallback remote websocket: %w", err)
+        }
+    }
+
+    // Re-create subscriptions
+    subscriptions := s.subscriptions
+    s.subscriptions = map[string]*wsSubscription{}
+    for _, subscription := range subscriptions {
+        if _, err := s.resubscribe(subscription.clientId, subscription.params); err != nil {
+            return err
+        }
+    }
+    return nil
+
+}
+
+
+// Send an internal request to re-create a client subscription on the current upstream
+// Must be called with the session lock held
+func (s *wsSession) resubscribe(clientId string, params json.RawMessage) (*wsPendingSubscribe, error) {
+    s.nextResubscribeId++
+    id, _ := json.Marshal(fmt.Sprintf("%s%d", WsResubscribeIdPrefix, s.nextResubscribeId))
+    request, err := encodeWsRequest(id, "eth_subscribe", params)
+    if err != nil {
+        return nil, err
+    }
+    pending := &wsPendingSubscribe{
+        request: request,
+        params: params,
+        clientId: clientId,
+        internal: true,
+        sent: true,
+    }
+    s.pending[string(id)] = pending
+    if err := s.upstream.WriteMessage(websocket.TextMessage, request); err != nil {
+        return nil, fmt.Errorf("Error writing to fallback remote websocket: %w", err)
+    }
+    return pending, nil
+}
+
+
+// Encode a JSON-RPC request
+func encodeWsRequest(id json.RawMessage, method string, params json.RawMessage) ([]byte, error) {
+    encodedMethod, err := json.Marshal(method)
+    if err != nil {
+        return nil, err
+    }
+    return json.Marshal(map[string]json.RawMessage{
+        "jsonrpc": json.RawMessage(`"2.0"`),
+        "id": id,
+        "method": encodedMethod,
+        "params": params,
+    })
+}
+
+
+// Track subscription requests from the client
+// Unsubscribe requests are translated to the current upstream subscription ID
+// Returns the message to forward to the upstream and its pending subscription request, if any
+// Returns a nil message if an unsubscribe request is held until its subscription has been re-created on the upstream
+func (s *wsSession) handleClientMessage(message []byte) ([]byte, *wsPendingSubscribe) {
+
+    // Decode message; batches and other messages are forwarded unchanged
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(message, &fields); err != nil {
+        return message, nil
+    }
+    var method string
+    json.Unmarshal(fields["method"], &method)
+
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    switch method {
+
+        // Record subscription request
+        case "eth_subscribe":
+            if len(fields["id"]) > 0 {
+                request := &wsPendingSubscribe{
+                    request: message,
+                    params: fields["params"],
+                }
+                s.pending[string(fields["id"])] = request
+                return message, request
+            }
+
+        // Translate unsubscribe request
+        case "eth_unsubscribe":
+            var params []string
+            if err := json.Unmarshal(fields["params"], &params); err != nil || len(params) == 0 {
+                return message, nil
+            }
+            for upstreamId, subscription := range s.subscriptions {
+                if subscription.clientId != params[0] {
+                    continue
+                }
+                delete(s.subscriptions, upstreamId)
+                params[0] = upstreamId
+                if encoded, err := json.Marshal(params); err == nil {
+                    fields["params"] = encoded
+                    if encoded, err := json.Marshal(fields); err == nil {
+                        return encoded, nil
+                    }
+                }
+                return message, nil
+            }
+
+            // Hold the request if the subscription is being re-created, so the new upstream subscription isn't leaked
+            for _, request := range s.pending {
+                if request.internal && request.clientId == params[0] && request.unsubscribeId == nil {
+                    request.unsubscribeId = fields["id"]
+                    if request.unsubscribeId == nil {
+                        request.unsubscribeId = json.RawMessage("null")
+                    }
+                    return nil, nil
+                }
+            }
+
+    }
+    return message, nil
+
+}
+
+
+// Track subscription responses from the upstream and translate subscription IDs in notifications
+// Returns the message to forward to the client, or nil if it should not be forwarded,
+// and a message to send back to the upstream, if any
+func (s *wsSession) handleUpstreamMessage(message []byte) ([]byte, []byte) {
+
+    // Decode message; batches and other messages are forwarded unchanged
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(message, &fields); err != nil {
+        return message, nil
+    }
+
+    s.lock.Lock()
+    defer s.lock.Unlock()
+
+    // Handle subscription response
+    if id, ok := fields["id"]; ok {
+        request, ok := s.pending[string(id)]
+        if !ok {
+            return message, nil
+        }
+        delete(s.pending, string(id))
+        var upstreamId string
+        if err := json.Unmarshal(fields["result"], &upstreamId); err != nil || upstreamId == "" {
+            if !request.internal {
+                return message, nil
+            }
+            log.Printf("Could not re-create subscription %s on the remote websocket: %s\n", request.clientId, string(fields["error"]))
+            if request.unsubscribeId != nil {
+                return encodeWsResult(request.unsubscribeId, false), nil
+            }
+            return nil, nil
+        }
+
+        // Send the client's held unsubscribe request for the re-created subscription; the upstream responds to the client directly
+        if request.unsubscribeId != nil {
+            params, _ := json.Marshal([]string{upstreamId})
+            unsubscribe, err := encodeWsRequest(request.unsubscribeId, "eth_unsubscribe", params)
+            if err != nil {
+                return encodeWsResult(request.unsubscribeId, false), nil
+            }
+            return nil, unsubscribe
+        }
+
+        // Record subscription
+        clientId := request.clientId
+        if !request.internal {
+            clientId = upstreamId
+        }
+        s.subscriptions[upstreamId] = &wsSubscription{
+            clientId: clientId,
+            params: request.params,
+        }
+        if request.internal {
+            return nil, nil
+        }
+        return message, nil
+    }
+
+    // Translate subscription notification
+    var method string
+    json.Unmarshal(fields["method"], &method)
+    if method != "eth_subscription" {
+        return message, nil
+    }
+    var params map[string]json.RawMessage
+    if err := json.Unmarshal(fields["params"], &params); err != nil {
+        return message, nil
+    }
+    var upstreamId string
+    if err := json.Unmarshal(params["subscription"], &upstreamId); err != nil {
+        return message, nil
+    }
+    subscription, ok := s.subscriptions[upstreamId]
+    if !ok {
+        return message, nil
+    }
+    if subscription.clientId == upstreamId {
+        return message, nil
+    }
+    params["subscription"], _ = json.Marshal(subscription.clientId)
+    encodedParams, err := json.Marshal(params)
+    if err != nil {
+        return message, nil
+    }
+    fields["params"] = encodedParams
+    encoded, err := json.Marshal(fields)
+    if err != nil {
+        return message, nil
+    }
+    return encoded, nil
+
+}
+
+
+// Encode a JSON-RPC result response
+func encodeWsResult(id json.RawMessage, result interface{}) []byte {
+    encodedResult, _ := json.Marshal(result)
+    encoded, _ := json.Marshal(map[string]json.RawMessage{
+        "jsonrpc": json.RawMessage(`"2.0"`),
+        "id": id,
+        "result": encodedResult,
+    })
+    return encoded
+}
@@ -0,0 +1,334 @@
+package proxy
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/gorilla/websocket"
+)
+
+
+// Create a session with a subscription re-created on the upstream (0xnew for the client's 0xold),
+// a subscription made on the current upstream (0xcurrent), and a subscription being re-created (for the client's 0xpending)
+func getTestWsSession() *wsSession {
+    return &wsSession{
+        subscriptions: map[string]*wsSubscription{
+            "0xnew": {clientId: "0xold", params: json.RawMessage(`["newHeads"]`)},
+            "0xcurrent": {clientId: "0xcurrent", params: json.RawMessage(`["logs"]`)},
+        },
+        pending: map[string]*wsPendingSubscribe{
+            `"rp-resubscribe-1"`: {params: json.RawMessage(`["newPendingTransactions"]`), clientId: "0xpending", internal: true, sent: true},
+        },
+    }
+}
+
+
+// Check that two JSON messages are equal
+func checkWsMessage(t *testing.T, name string, message []byte, expected string) {
+    t.Helper()
+    if expected == "" {
+        if message != nil {
+            t.Errorf("Expected no %s message, got %s", name, message)
+        }
+        return
+    }
+    var decoded, decodedExpected interface{}
+    if err := json.Unmarshal(message, &decoded); err != nil {
+        t.Fatalf("Could not decode %s message %q: %s", name, message, err)
+    }
+    if err := json.Unmarshal([]byte(expected), &decodedExpected); err != nil {
+        t.Fatalf("Could not decode expected %s message %q: %s", name, expected, err)
+    }
+    if !reflect.DeepEqual(decoded, decodedExpected) {
+        t.Errorf("Expected %s message %s, got %s", name, expected, message)
+    }
+}
+
+
+func TestWsHandleClientMessage(t *testing.T) {
+    tests := []struct {
+        name string
+        message string
+        expected string
+        pending bool
+    }{
+        {
+            name: "other request",
+            message: `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`,
+            expected: `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`,
+        },
+        {
+            name: "batch",
+            message: `[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}]`,
+            expected: `[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}]`,
+        },
+        {
+            name: "subscribe",
+            message: `{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}`,
+            expected: `{"jsonrpc":"2.0","id":2,"method":"eth_subscribe","params":["newHeads"]}`,
+            pending: true,
+        },
+        {
+            name: "unsubscribe re-created subscription",
+            message: `{"jsonrpc":"2.0","id":3,"method":"eth_unsubscribe","params":["0xold"]}`,
+            expected: `{"jsonrpc":"2.0","id":3,"method":"eth_unsubscribe","params":["0xnew"]}`,
+        },
+        {
+            name: "unsubscribe current subscription",
+            message: `{"jsonrpc":"2.0","id":4,"method":"eth_unsubscribe","params":["0xcurrent"]}`,
+            expected: `{"jsonrpc":"2.0","id":4,"method":"eth_unsubscribe","params":["0xcurrent"]}`,
+        },
+        {
+            name: "unsubscribe subscription being re-created",
+            message: `{"jsonrpc":"2.0","id":5,"method":"eth_unsubscribe","params":["0xpending"]}`,
+        },
+        {
+            name: "unsubscribe unknown subscription",
+            message: `{"jsonrpc":"2.0","id":6,"method":"eth_unsubscribe","params":["0xunknown"]}`,
+            expected: `{"jsonrpc":"2.0","id":6,"method":"eth_unsubscribe","params":["0xunknown"]}`,
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            session := getTestWsSession()
+            message, request := session.handleClientMessage([]byte(test.message))
+            checkWsMessage(t, "upstream", message, test.expected)
+            if test.pending != (request != nil) {
+                t.Errorf("Expected pending subscription request: %t, got %+v", test.pending, request)
+            }
+            if request != nil && request.sent {
+                t.Error("Subscription request was marked as sent before it was written")
+            }
+        })
+    }
+}
+
+
+func TestWsHandleUpstreamMessage(t *testing.T) {
+    tests := []struct {
+        name string
+        pending map[string]*wsPendingSubscribe
+        message string
+        expectedClient string
+        expectedUpstream string
+        expectedSubscription string
+        expectedClientId string
+    }{
+        {
+            name: "other response",
+            message: `{"jsonrpc":"2.0","id":1,"result":"0x10"}`,
+            expectedClient: `{"jsonrpc":"2.0","id":1,"result":"0x10"}`,
+        },
+        {
+            name: "client subscribe response",
+            pending: map[string]*wsPendingSubscribe{"2": {params: json.RawMessage(`["newHeads"]`)}},
+            message: `{"jsonrpc":"2.0","id":2,"result":"0xclient"}`,
+            expectedClient: `{"jsonrpc":"2.0","id":2,"result":"0xclient"}`,
+            expectedSubscription: "0xclient",
+            expectedClientId: "0xclient",
+        },
+        {
+            name: "client subscribe error",
+            pending: map[string]*wsPendingSubscribe{"2": {params: json.RawMessage(`["bad"]`)}},
+            message: `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"invalid"}}`,
+            expectedClient: `{"jsonrpc":"2.0","id":2,"error":{"code":-32602,"message":"invalid"}}`,
+        },
+        {
+            name: "internal resubscribe response",
+            pending: map[string]*wsPendingSubscribe{`"rp-resubscribe-2"`: {params: json.RawMessage(`["newHeads"]`), clientId: "0xclient", internal: true}},
+            message: `{"jsonrpc":"2.0","id":"rp-resubscribe-2","result":"0xresubscribed"}`,
+            expectedSubscription: "0xresubscribed",
+            expectedClientId: "0xclient",
+        },
+        {
+            name: "internal resubscribe error",
+            pending: map[string]*wsPendingSubscribe{`"rp-resubscribe-2"`: {params: json.RawMessage(`["newHeads"]`), clientId: "0xclient", internal: true}},
+            message: `{"jsonrpc":"2.0","id":"rp-resubscribe-2","error":{"code":-32000,"message":"unavailable"}}`,
+        },
+        {
+            name: "internal resubscribe response after unsubscribe",
+            pending: map[string]*wsPendingSubscribe{`"rp-resubscribe-2"`: {params: json.RawMessage(`["newHeads"]`), clientId: "0xclient", internal: true, unsubscribeId: json.RawMessage(`7`)}},
+            message: `{"jsonrpc":"2.0","id":"rp-resubscribe-2","result":"0xresubscribed"}`,
+            expectedUpstream: `{"jsonrpc":"2.0","id":7,"method":"eth_unsubscribe","params":["0xresubscribed"]}`,
+        },
+        {
+            name: "internal resubscribe error after unsubscribe",
+            pending: map[string]*wsPendingSubscribe{`"rp-resubscribe-2"`: {params: json.RawMessage(`["newHeads"]`), clientId: "0xclient", internal: true, unsubscribeId: json.RawMessage(`7`)}},
+            message: `{"jsonrpc":"2.0","id":"rp-resubscribe-2","error":{"code":-32000,"message":"unavailable"}}`,
+            expectedClient: `{"jsonrpc":"2.0","id":7,"result":false}`,
+        },
+        {
+            name: "notification for re-created subscription",
+            message: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xnew","result":{"number":"0x1"}}}`,
+            expectedClient: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xold","result":{"number":"0x1"}}}`,
+        },
+        {
+            name: "notification for current subscription",
+            message: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xcurrent","result":{}}}`,
+            expectedClient: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xcurrent","result":{}}}`,
+        },
+        {
+            name: "notification for unknown subscription",
+            message: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xunknown","result":{}}}`,
+            expectedClient: `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xunknown","result":{}}}`,
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            session := getTestWsSession()
+            for id, request := range test.pending {
+                session.pending[id] = request
+            }
+            clientMessage, upstreamMessage := session.handleUpstreamMessage([]byte(test.message))
+            checkWsMessage(t, "client", clientMessage, test.expectedClient)
+            checkWsMessage(t, "upstream", upstreamMessage, test.expectedUpstream)
+            if len(session.pending) != 1 {
+                t.Errorf("Expected the response's pending request to be removed, got %d pending", len(session.pending))
+            }
+            if test.expectedSubscription != "" {
+                subscription, ok := session.subscriptions[test.expectedSubscription]
+                if !ok {
+                    t.Fatalf("Subscription %s was not recorded", test.expectedSubscription)
+                }
+                if subscription.clientId != test.expectedClientId {
+                    t.Errorf("Expected client subscription ID %s, got %s", test.expectedClientId, subscription.clientId)
+                }
+            } else if len(session.subscriptions) != 2 {
+                t.Errorf("Expected no new subscriptions, got %d", len(session.subscriptions) - 2)
+            }
+        })
+    }
+}
+
+
+// An unsubscribe request for a subscription being re-created is held, and sent once it has been re-created
+func TestWsUnsubscribeWhileResubscribing(t *testing.T) {
+    session := getTestWsSession()
+
+    // Unsubscribe while the subscription is being re-created
+    message, _ := session.handleClientMessage([]byte(`{"jsonrpc":"2.0","id":5,"method":"eth_unsubscribe","params":["0xpending"]}`))
+    checkWsMessage(t, "upstream", message, "")
+
+    // Re-create the subscription on the upstream
+    clientMessage, upstreamMessage := session.handleUpstreamMessage([]byte(`{"jsonrpc":"2.0","id":"rp-resubscribe-1","result":"0xresubscribed"}`))
+    checkWsMessage(t, "client", clientMessage, "")
+    checkWsMessage(t, "upstream", upstreamMessage, `{"jsonrpc":"2.0","id":5,"method":"eth_unsubscribe","params":["0xresubscribed"]}`)
+    if _, ok := session.subscriptions["0xresubscribed"]; ok {
+        t.Error("Unsubscribed subscription was recorded")
+    }
+
+    // The upstream's unsubscribe response is forwarded to the client
+    clientMessage, upstreamMessage = session.handleUpstreamMessage([]byte(`{"jsonrpc":"2.0","id":5,"result":true}`))
+    checkWsMessage(t, "client", clientMessage, `{"jsonrpc":"2.0","id":5,"result":true}`)
+    checkWsMessage(t, "upstream", upstreamMessage, "")
+}
+
+
+// Start a test upstream which responds to subscription requests with sequential subscription IDs
+// Received requests are sent to the returned channel
+func startTestWsUpstream(t *testing.T, prefix string) (*httptest.Server, chan map[string]json.RawMessage) {
+    requests := make(chan map[string]json.RawMessage, 10)
+    upgrader := websocket.Upgrader{}
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        connection, err := upgrader.Upgrade(w, r, nil)
+        if err != nil {
+            return
+        }
+        defer connection.Close()
+        for subscriptionId := 1; ; {
+            _, message, err := connection.ReadMessage()
+            if err != nil {
+                return
+            }
+            var fields map[string]json.RawMessage
+            json.Unmarshal(message, &fields)
+            requests <- fields
+            if string(fields["method"]) == `"eth_subscribe"` {
+                result, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": fields["id"], "result": prefix + string(rune('0' + subscriptionId))})
+                connection.WriteMessage(websocket.TextMessage, result)
+                subscriptionId++
+            }
+        }
+    }))
+    return server, requests
+}
+
+
+// Get the next request received by a test upstream
+func getTestWsRequest(t *testing.T, requests chan map[string]json.RawMessage) map[string]json.RawMessage {
+    t.Helper()
+    select {
+        case request := <-requests:
+            return request
+        case <-time.After(5 * time.Second):
+            t.Fatal("Timed out waiting for an upstream request")
+    }
+    return nil
+}
+
+
+// A failover re-creates subscriptions on the fallback and translates their IDs back to the client's
+func TestWsFailover(t *testing.T) {
+
+    // Start upstreams
+    primary, primaryRequests := startTestWsUpstream(t, "0xprimary")
+    defer primary.Close()
+    fallback, fallbackRequests := startTestWsUpstream(t, "0xfallback")
+    defer fallback.Close()
+    session, err := newWsSession([]string{"ws" + strings.TrimPrefix(primary.URL, "http"), "ws" + strings.TrimPrefix(fallback.URL, "http")})
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer session.upstream.Close()
+
+    // Subscribe on the primary
+    if err := session.forwardClientMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`)); err != nil {
+        t.Fatal(err)
+    }
+    getTestWsRequest(t, primaryRequests)
+    upstream, generation := session.getUpstream()
+    _, response, err := upstream.ReadMessage()
+    if err != nil {
+        t.Fatal(err)
+    }
+    clientMessage, _ := session.handleUpstreamMessage(response)
+    checkWsMessage(t, "client", clientMessage, `{"jsonrpc":"2.0","id":1,"result":"0xprimary1"}`)
+
+    // Fail over to the fallback; a second failover for the same generation does nothing
+    if err := session.failover(generation); err != nil {
+        t.Fatal(err)
+    }
+    if err := session.failover(generation); err != nil {
+        t.Fatal(err)
+    }
+    request := getTestWsRequest(t, fallbackRequests)
+    if string(request["method"]) != `"eth_subscribe"` || string(request["params"]) != `["newHeads"]` {
+        t.Fatalf("Unexpected resubscribe request %v", request)
+    }
+    upstream, _ = session.getUpstream()
+    _, response, err = upstream.ReadMessage()
+    if err != nil {
+        t.Fatal(err)
+    }
+    clientMessage, _ = session.handleUpstreamMessage(response)
+    checkWsMessage(t, "client", clientMessage, "")
+
+    // Notifications from the fallback use the client's subscription ID
+    clientMessage, _ = session.handleUpstreamMessage([]byte(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xfallback1","result":{}}}`))
+    checkWsMessage(t, "client", clientMessage, `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xprimary1","result":{}}}`)
+
+    // Unsubscribing uses the fallback's subscription ID
+    if err := session.forwardClientMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":2,"method":"eth_unsubscribe","params":["0xprimary1"]}`)); err != nil {
+        t.Fatal(err)
+    }
+    request = getTestWsRequest(t, fallbackRequests)
+    if string(request["params"]) != `["0xfallback1"]` {
+        t.Errorf("Expected unsubscribe from 0xfallback1, got %s", request["params"])
+    }
+
+}
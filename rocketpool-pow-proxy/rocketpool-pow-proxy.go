@@ -1,9 +1,14 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -58,9 +63,14 @@ func main() {
             Usage: "External Eth 1.0 provider Websocket `URL`, including the remote port (ignored if 'providerType' is used)",
             Value: "",
         },
+        cli.StringFlag{
+            Name:  "wsFallbackProviderUrl",
+            Usage: "Fallback Eth 1.0 provider Websocket `URL`; websocket sessions fail over to it if their upstream fails, and their subscriptions are re-created there",
+            Value: "",
+        },
         cli.StringFlag{
             Name:  "network, n",
-            Usage: "`Network` to connect to via Infura",
+            Usage: "`Network` to connect to via Infura or Pocket, by name (e.g. 'goerli') or chain ID (e.g. '5')",
             Value: "goerli",
         },
         cli.StringFlag{
@@ -73,27 +83,167 @@ func main() {
             Usage: "Eth 1.0 provider type if not using `URL`: Infura or Pocket",
             Value: "infura",
         },
+        cli.StringFlag{
+            Name:  "wsNetwork",
+            Usage: "`Network` for the websocket upstream, if it should differ from 'network'",
+            Value: "",
+        },
+        cli.StringFlag{
+            Name:  "wsProjectId",
+            Usage: "Infura project ID for the websocket upstream, if it should differ from 'projectId' (e.g. HTTP from a local node or Pocket, websocket from Infura)",
+            Value: "",
+        },
+        cli.StringFlag{
+            Name:  "wsProviderType",
+            Usage: "Eth 1.0 provider type for the websocket upstream if not using `URL`, if it should differ from 'providerType': Infura, or any other value to disable the websocket server",
+            Value: "",
+        },
+        cli.IntFlag{
+            Name:  "maxBatchSize, b",
+            Usage: "Maximum number of requests per JSON-RPC batch forwarded to the provider; larger batches are split (0 to disable)",
+            Value: 0,
+        },
+        cli.StringFlag{
+            Name:  "corsOrigins",
+            Usage: "Comma-separated list of `origins` allowed to make cross-origin requests to the HTTP proxy, or '*' for any origin (no CORS headers are sent by default)",
+            Value: "",
+        },
+        cli.DurationFlag{
+            Name:  "slowRequestThreshold",
+            Usage: "Log a warning with the method & duration of any forwarded HTTP request whose upstream latency exceeds this `duration`, e.g. '2s' (0 to disable)",
+            Value: 0,
+        },
+        cli.StringFlag{
+            Name:  "configFile, f",
+            Usage: "Optional YAML `file` with provider settings (httpProviderUrl, wsProviderUrl, wsFallbackProviderUrl, network, projectId, providerType, wsNetwork, wsProjectId, wsProviderType) which override the matching flags; re-read on SIGHUP",
+            Value: "",
+        },
+        cli.StringFlag{
+            Name:  "clientLabel",
+            Usage: "Where to take the client label used to break down request counts from: 'ip' for the source IP, or 'header:<name>' for a request header",
+            Value: proxy.ClientLabelSourceIp,
+        },
+        cli.DurationFlag{
+            Name:  "statsInterval",
+            Usage: "How often to log request counts per client label (0 to disable)",
+            Value: 10 * time.Minute,
+        },
+        cli.StringFlag{
+            Name:  "metricsPort",
+            Usage: "Local port to serve request counts per client label on at /metrics, in the Prometheus format (disabled by default)",
+            Value: "",
+        },
+        cli.StringFlag{
+            Name:  "metricsAddress",
+            Usage: "Local `address` to serve metrics on; only reachable from the proxy's own host or container by default, use '0.0.0.0' to expose it to e.g. a Prometheus container",
+            Value: "127.0.0.1",
+        },
+        cli.BoolFlag{
+            Name:  "print-url",
+            Usage: "Print the resolved upstream provider URLs (with the project ID masked) and exit",
+        },
+        cli.BoolFlag{
+            Name:  "bench",
+            Usage: "Measure the latency of the HTTP upstream provider with eth_blockNumber requests, print a summary and exit",
+        },
+        cli.IntFlag{
+            Name:  "benchCount",
+            Usage: "Number of requests to make with '--bench'",
+            Value: 20,
+        },
     }
 
     // Set application action
     app.Action = func(c *cli.Context) error {
 
+        // Get provider settings
+        defaults := proxy.ProviderConfig{
+            HttpProviderUrl: c.GlobalString("httpProviderUrl"),
+            WsProviderUrl: c.GlobalString("wsProviderUrl"),
+            WsFallbackProviderUrl: c.GlobalString("wsFallbackProviderUrl"),
+            Network: c.GlobalString("network"),
+            ProjectId: c.GlobalString("projectId"),
+            ProviderType: c.GlobalString("providerType"),
+            WsNetwork: c.GlobalString("wsNetwork"),
+            WsProjectId: c.GlobalString("wsProjectId"),
+            WsProviderType: c.GlobalString("wsProviderType"),
+        }
+        providerConfig := defaults
+        if c.GlobalString("configFile") != "" {
+            var err error
+            providerConfig, err = proxy.LoadProviderConfig(c.GlobalString("configFile"), defaults)
+            if err != nil { return err }
+        }
+
+        // Create proxy servers
+        httpProxyServer := proxy.NewHttpProxyServer(c.GlobalString("httpPort"), providerConfig.HttpProviderUrl, providerConfig.Network, providerConfig.ProjectId, providerConfig.ProviderType, c.GlobalInt("maxBatchSize"), c.GlobalString("corsOrigins"))
+        httpProxyServer.SlowRequestThreshold = c.GlobalDuration("slowRequestThreshold")
+        wsProviderConfig := providerConfig.GetWsProviderConfig()
+        var wsProxyServer *proxy.WsProxyServer
+        if providerConfig.HasWsProvider() {
+            wsProxyServer = proxy.NewWsProxyServer(c.GlobalString("wsPort"), wsProviderConfig.WsProviderUrl, wsProviderConfig.WsFallbackProviderUrl, wsProviderConfig.Network, wsProviderConfig.ProjectId)
+        }
+
+        // Count requests by client
+        stats, err := proxy.NewClientStats(c.GlobalString("clientLabel"))
+        if err != nil { return err }
+        httpProxyServer.Stats = stats
+        if wsProxyServer != nil {
+            wsProxyServer.Stats = stats
+        }
+
+        // Print upstream URLs
+        if c.GlobalBool("print-url") {
+            fmt.Printf("HTTP upstream URL: %s\n", proxy.MaskProjectId(httpProxyServer.GetProviderUrl(), providerConfig.ProjectId))
+            if wsProxyServer != nil {
+                fmt.Printf("Websocket upstream URL: %s\n", proxy.MaskProjectId(wsProxyServer.GetProviderUrl(), wsProviderConfig.ProjectId))
+                if wsProxyServer.GetFallbackProviderUrl() != "" {
+                    fmt.Printf("Websocket fallback upstream URL: %s\n", proxy.MaskProjectId(wsProxyServer.GetFallbackProviderUrl(), wsProviderConfig.ProjectId))
+                }
+            } else {
+                fmt.Println("Websocket upstream URL: none (HTTP-only mode)")
+            }
+            return nil
+        }
+
+        // Benchmark upstream latency
+        if c.GlobalBool("bench") {
+            return bench(httpProxyServer.GetProviderUrl(), providerConfig.ProjectId, c.GlobalInt("benchCount"))
+        }
+
+        // Reload provider settings on SIGHUP
+        go reloadOnHangup(c.GlobalString("configFile"), defaults, httpProxyServer, wsProxyServer)
+
+        // Report request counts by client
+        if c.GlobalDuration("statsInterval") > 0 {
+            go stats.LogPeriodically(c.GlobalDuration("statsInterval"))
+        }
+        if c.GlobalString("metricsPort") != "" {
+            go func() {
+                if err := stats.StartMetricsServer(c.GlobalString("metricsAddress"), c.GlobalString("metricsPort")); err != nil {
+                    log.Println(fmt.Errorf("Proxy metrics server stopped: %w", err))
+                }
+            }()
+        }
+
         // We need a wait group since we have 2 HTTP listeners
         wg := new(sync.WaitGroup)
         wg.Add(2)
 
         // HTTP server
         go func() {
-            proxyServer := proxy.NewHttpProxyServer(c.GlobalString("httpPort"), c.GlobalString("httpProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"), c.GlobalString("providerType"))
-            proxyServer.Start()
+            if err := httpProxyServer.Start(); err != nil {
+                log.Println(fmt.Errorf("HTTP proxy server stopped: %w", err))
+            }
             wg.Done()
         }()
     
         // Websocket server
         go func() {
-            if c.GlobalString("providerType") == "infura" || c.GlobalString("wsProviderUrl") != "" {
-                proxyServer := proxy.NewWsProxyServer(c.GlobalString("wsPort"), c.GlobalString("wsProviderUrl"), c.GlobalString("network"), c.GlobalString("projectId"))
-                proxyServer.Start()
+            if wsProxyServer != nil {
+                if err := wsProxyServer.Start(); err != nil {
+                    log.Println(fmt.Errorf("Websocket proxy server stopped, continuing in HTTP-only mode: %w", err))
+                }
             } else {
                 log.Println("No websocket URL provided, running in HTTP-only mode.")
             }
@@ -112,3 +262,74 @@ func main() {
     }
 
 }
+
+
+// Measure and print the latency of the HTTP upstream provider
+func bench(providerUrl string, projectId string, count int) error {
+    if count <= 0 {
+        return fmt.Errorf("Invalid bench count %d; must be greater than 0", count)
+    }
+    fmt.Printf("Making %d eth_blockNumber requests to %s...\n", count, proxy.MaskProjectId(providerUrl, projectId))
+    summary := proxy.Bench(providerUrl, count)
+    for _, err := range summary.Errors {
+        fmt.Printf("Request failed: %s\n", proxy.MaskProjectId(err.Error(), projectId))
+    }
+    successes := summary.Requests - len(summary.Errors)
+    fmt.Printf("%d of %d requests succeeded.\n", successes, summary.Requests)
+    if successes == 0 {
+        return errors.New("No requests to the upstream provider succeeded")
+    }
+    fmt.Printf("Min latency:  %s\n", summary.Min.String())
+    fmt.Printf("Avg latency:  %s\n", summary.Avg.String())
+    fmt.Printf("P95 latency:  %s\n", summary.P95.String())
+    fmt.Printf("Throughput:   %.2f requests/s\n", summary.Throughput)
+    return nil
+}
+
+
+// Re-resolve the upstream provider URLs whenever a SIGHUP is received
+// New requests use the new upstreams; requests & websocket connections already in flight finish against the old ones
+func reloadOnHangup(configFile string, defaults proxy.ProviderConfig, httpProxyServer *proxy.HttpProxyServer, wsProxyServer *proxy.WsProxyServer) {
+    hangup := make(chan os.Signal, 1)
+    signal.Notify(hangup, syscall.SIGHUP)
+    for range hangup {
+
+        // Reload provider settings
+        providerConfig := defaults
+        if configFile != "" {
+            var err error
+            providerConfig, err = proxy.LoadProviderConfig(configFile, defaults)
+            if err != nil {
+                log.Println(fmt.Errorf("Could not reload proxy config, keeping the current upstreams: %w", err))
+                continue
+            }
+        }
+
+        // Update HTTP upstream
+        httpProviderUrl, err := proxy.GetHttpProviderUrl(providerConfig.HttpProviderUrl, providerConfig.Network, providerConfig.ProjectId, providerConfig.ProviderType)
+        if err != nil {
+            log.Println(fmt.Errorf("Could not reload proxy config, keeping the current upstreams: %w", err))
+            continue
+        }
+        httpProxyServer.SetProviderUrl(httpProviderUrl)
+        log.Printf("Reloaded HTTP upstream URL: %s\n", proxy.MaskProjectId(httpProviderUrl, providerConfig.ProjectId))
+
+        // Update websocket upstream
+        if wsProxyServer != nil {
+            wsProviderConfig := providerConfig.GetWsProviderConfig()
+            if !providerConfig.HasWsProvider() {
+                log.Println("The reloaded proxy config has no websocket upstream, keeping the current one.")
+                continue
+            }
+            wsProviderUrl, err := proxy.GetWsProviderUrl(wsProviderConfig.WsProviderUrl, wsProviderConfig.Network, wsProviderConfig.ProjectId)
+            if err != nil {
+                log.Println(fmt.Errorf("Could not reload websocket upstream, keeping the current one: %w", err))
+                continue
+            }
+            wsProxyServer.SetProviderUrl(wsProviderUrl)
+            log.Printf("Reloaded websocket upstream URL: %s\n", proxy.MaskProjectId(wsProviderUrl, wsProviderConfig.ProjectId))
+            wsProxyServer.SetFallbackProviderUrl(wsProviderConfig.WsFallbackProviderUrl)
+        }
+
+    }
+}
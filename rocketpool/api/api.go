@@ -2,9 +2,9 @@ package api
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/urfave/cli"
 
-	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/smartnode/rocketpool/api/auction"
 	"github.com/rocket-pool/smartnode/rocketpool/api/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool/api/network"
@@ -16,17 +16,31 @@ import (
 	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
 
 // Waits for an auction transaction
-func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse, error) {
+// The full receipt is included in the response if includeReceipt is set
+// If confirmations is more than 1, waits until the transaction's block is that many blocks deep
+func waitForTransaction(c *cli.Context, hash common.Hash, includeReceipt bool, confirmations uint64) (*apitypes.WaitForTransactionResponse, error) {
     
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
 
     // Response
-    response := apitypes.APIResponse{}
-    _, err = utils.WaitForTransaction(rp.Client, hash)
+    response := apitypes.WaitForTransactionResponse{}
+    txReceipt, err := eth1.WaitForTransaction(rp.Client, hash, confirmations)
+    if txReceipt != nil && includeReceipt {
+        receipt, receiptErr := getTransactionReceipt(c, txReceipt)
+        if receiptErr != nil {
+            response.ReceiptError = receiptErr.Error()
+        }
+        response.Receipt = receipt
+    }
+    if txReceipt != nil && txReceipt.Status == types.ReceiptStatusFailed {
+        response.Reverted = true
+        return &response, nil
+    }
     if err != nil {
         return nil, err
     }
@@ -37,6 +51,29 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 }
 
 
+// Get the details of a mined transaction's receipt
+// If the effective gas price can't be retrieved, the receipt is returned without it along with the error
+func getTransactionReceipt(c *cli.Context, txReceipt *types.Receipt) (*apitypes.TransactionReceipt, error) {
+    receipt := &apitypes.TransactionReceipt{
+        Status: txReceipt.Status,
+        TxHash: txReceipt.TxHash,
+        BlockHash: txReceipt.BlockHash,
+        BlockNumber: txReceipt.BlockNumber.Uint64(),
+        TransactionIndex: txReceipt.TransactionIndex,
+        GasUsed: txReceipt.GasUsed,
+        CumulativeGasUsed: txReceipt.CumulativeGasUsed,
+        ContractAddress: txReceipt.ContractAddress,
+        Logs: txReceipt.Logs,
+    }
+    effectiveGasPrice, err := services.GetTransactionEffectiveGasPrice(c, txReceipt.TxHash, txReceipt.BlockNumber)
+    if err != nil {
+        return receipt, err
+    }
+    receipt.EffectiveGasPrice = effectiveGasPrice
+    return receipt, nil
+}
+
+
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 
@@ -67,7 +104,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
         Name: "wait",
         Aliases: []string{"t"},
         Usage: "Wait for a transaction to complete",
-        UsageText: "rocketpool api wait tx-hash",
+        UsageText: "rocketpool api wait tx-hash [options]",
+        Flags: []cli.Flag{
+            cli.BoolFlag{
+                Name:  "receipt",
+                Usage: "Include the full transaction receipt in the response",
+            },
+            cli.Uint64Flag{
+                Name:  "confirmations",
+                Usage: "The number of blocks the transaction must be confirmed by before returning",
+                Value: 1,
+            },
+        },
         Action: func(c *cli.Context) error {
             // Validate args
             if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
@@ -75,7 +123,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
             if err != nil { return err }
 
             // Run
-            api.PrintResponse(waitForTransaction(c, hash))
+            api.PrintResponse(waitForTransaction(c, hash, c.Bool("receipt"), c.Uint64("confirmations")))
             return nil
         },
     })
@@ -0,0 +1,124 @@
+package minipool
+
+import (
+	"errors"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func checkKeys(c *cli.Context) (*api.MinipoolCheckKeysResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get selected validator client
+    validatorClient := cfg.GetSelectedValidatorClient()
+    if validatorClient == nil {
+        return nil, errors.New("No validator client is selected")
+    }
+
+    // Response
+    response := api.MinipoolCheckKeysResponse{
+        ValidatorClient: validatorClient.ID,
+    }
+
+    // Get validator keys stored for the validator client
+    keyPubkeys, err := w.GetKeystoreValidatorPubkeys(validatorClient.ID)
+    if err != nil {
+        return nil, err
+    }
+    response.StoredKeyCount = uint64(len(keyPubkeys))
+
+    // Get node minipool addresses
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+    addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+    if err != nil {
+        return nil, err
+    }
+    response.MinipoolCount = uint64(len(addresses))
+
+    // Get minipool pubkeys & statuses
+    minipools := make([]api.MinipoolKeyStatus, len(addresses))
+    var wg errgroup.Group
+    for mi, address := range addresses {
+        mi, address := mi, address
+        wg.Go(func() error {
+            pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+            if err != nil {
+                return err
+            }
+            mp, err := minipool.NewMinipool(rp, address)
+            if err != nil {
+                return err
+            }
+            status, err := mp.GetStatus(nil)
+            if err != nil {
+                return err
+            }
+            minipools[mi] = api.MinipoolKeyStatus{
+                Address: address,
+                ValidatorPubkey: pubkey,
+                MinipoolStatus: status,
+            }
+            return nil
+        })
+    }
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Compare minipools & stored keys
+    response.MissingKeys, response.UnmatchedKeys = diffMinipoolKeys(minipools, keyPubkeys)
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Compare minipools against validator keys
+// Returns the minipools without a key, and the keys which don't belong to any of the minipools
+func diffMinipoolKeys(minipools []api.MinipoolKeyStatus, pubkeys []types.ValidatorPubkey) ([]api.MinipoolKeyStatus, []types.ValidatorPubkey) {
+
+    // Get minipools without a key
+    missingKeys := []api.MinipoolKeyStatus{}
+    keyPubkeys := map[string]bool{}
+    for _, pubkey := range pubkeys {
+        keyPubkeys[pubkey.Hex()] = true
+    }
+    minipoolPubkeys := map[string]bool{}
+    for _, mp := range minipools {
+        minipoolPubkeys[mp.ValidatorPubkey.Hex()] = true
+        if !keyPubkeys[mp.ValidatorPubkey.Hex()] {
+            missingKeys = append(missingKeys, mp)
+        }
+    }
+
+    // Get keys without a minipool
+    unmatchedKeys := []types.ValidatorPubkey{}
+    for _, pubkey := range pubkeys {
+        if !minipoolPubkeys[pubkey.Hex()] {
+            unmatchedKeys = append(unmatchedKeys, pubkey)
+        }
+    }
+
+    // Return
+    return missingKeys, unmatchedKeys
+
+}
@@ -0,0 +1,99 @@
+package minipool
+
+import (
+    "bytes"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/types"
+
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+// Get a test validator pubkey filled with a byte
+func getTestPubkey(b byte) types.ValidatorPubkey {
+    return types.BytesToValidatorPubkey(bytes.Repeat([]byte{b}, 48))
+}
+
+
+// Get a test minipool with the validator pubkey for a byte
+func getTestMinipool(b byte, status types.MinipoolStatus) api.MinipoolKeyStatus {
+    return api.MinipoolKeyStatus{
+        Address: common.BytesToAddress([]byte{b}),
+        ValidatorPubkey: getTestPubkey(b),
+        MinipoolStatus: status,
+    }
+}
+
+
+func TestDiffMinipoolKeys(t *testing.T) {
+    tests := []struct {
+        name string
+        minipools []api.MinipoolKeyStatus
+        pubkeys []types.ValidatorPubkey
+        missing []byte
+        unmatched []byte
+    }{
+        {
+            name: "no minipools or keys",
+        },
+        {
+            name: "every minipool has a key",
+            minipools: []api.MinipoolKeyStatus{getTestMinipool(1, types.Staking), getTestMinipool(2, types.Prelaunch)},
+            pubkeys: []types.ValidatorPubkey{getTestPubkey(2), getTestPubkey(1)},
+        },
+        {
+            name: "missing keys",
+            minipools: []api.MinipoolKeyStatus{getTestMinipool(1, types.Staking), getTestMinipool(2, types.Dissolved), getTestMinipool(3, types.Staking)},
+            pubkeys: []types.ValidatorPubkey{getTestPubkey(3)},
+            missing: []byte{1, 2},
+        },
+        {
+            name: "unmatched keys",
+            minipools: []api.MinipoolKeyStatus{getTestMinipool(1, types.Staking)},
+            pubkeys: []types.ValidatorPubkey{getTestPubkey(4), getTestPubkey(1), getTestPubkey(5)},
+            unmatched: []byte{4, 5},
+        },
+        {
+            name: "missing & unmatched keys",
+            minipools: []api.MinipoolKeyStatus{getTestMinipool(1, types.Staking), getTestMinipool(2, types.Staking)},
+            pubkeys: []types.ValidatorPubkey{getTestPubkey(2), getTestPubkey(3)},
+            missing: []byte{1},
+            unmatched: []byte{3},
+        },
+        {
+            name: "duplicate keys",
+            minipools: []api.MinipoolKeyStatus{getTestMinipool(1, types.Staking)},
+            pubkeys: []types.ValidatorPubkey{getTestPubkey(1), getTestPubkey(1)},
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            missing, unmatched := diffMinipoolKeys(test.minipools, test.pubkeys)
+
+            // Check minipools without a key, in minipool order
+            if missing == nil || unmatched == nil {
+                t.Fatal("Expected empty lists rather than nil, so the response is encoded as empty arrays")
+            }
+            if len(missing) != len(test.missing) {
+                t.Fatalf("Expected %d minipool(s) without a key, got %d", len(test.missing), len(missing))
+            }
+            for i, b := range test.missing {
+                if missing[i] != getTestMinipool(b, missing[i].MinipoolStatus) {
+                    t.Errorf("Expected minipool %d without a key, got %s", b, missing[i].ValidatorPubkey.Hex())
+                }
+            }
+
+            // Check keys without a minipool, in key order
+            if len(unmatched) != len(test.unmatched) {
+                t.Fatalf("Expected %d key(s) without a minipool, got %d", len(test.unmatched), len(unmatched))
+            }
+            for i, b := range test.unmatched {
+                if !bytes.Equal(unmatched[i].Bytes(), getTestPubkey(b).Bytes()) {
+                    t.Errorf("Expected key %s without a minipool, got %s", getTestPubkey(b).Hex(), unmatched[i].Hex())
+                }
+            }
+        })
+    }
+}
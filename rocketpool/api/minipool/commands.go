@@ -19,12 +19,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 Name:      "status",
                 Aliases:   []string{"s"},
                 Usage:     "Get a list of the node's minipools",
-                UsageText: "rocketpool api minipool status",
+                UsageText: "rocketpool api minipool status [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "The address of a node to observe instead of the node wallet",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
                     // Run
                     api.PrintResponse(getStatus(c))
                     return nil
@@ -32,6 +43,88 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "get-minipool-by-pubkey",
+                Usage:     "Get the minipool address and status for a validator pubkey",
+                UsageText: "rocketpool api minipool get-minipool-by-pubkey validator-pubkey",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    pubkey, err := cliutils.ValidatePubkey("validator pubkey", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getMinipoolByPubkey(c, pubkey))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "get-pubkey",
+                Usage:     "Get the validator pubkey and status for a minipool",
+                UsageText: "rocketpool api minipool get-pubkey minipool-address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getMinipoolPubkey(c, minipoolAddress))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "check-keys",
+                Usage:     "Compare the node's minipools against the validator keys stored for the validator client",
+                UsageText: "rocketpool api minipool check-keys",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(checkKeys(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "history",
+                Usage:     "Get the balance events of the node's minipools within a block range",
+                UsageText: "rocketpool api minipool history from-block to-block",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "The address of a node to observe instead of the node wallet",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    fromBlock, err := cliutils.ValidateUint("from block", c.Args().Get(0))
+                    if err != nil { return err }
+                    toBlock, err := cliutils.ValidateUint("to block", c.Args().Get(1))
+                    if err != nil { return err }
+
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
+                    // Run
+                    api.PrintResponse(getHistory(c, fromBlock, toBlock))
+                    return nil
+
+                },
+            },
+
             cli.Command{
                 Name:      "can-refund",
                 Usage:     "Check whether the node can refund ETH from the minipool",
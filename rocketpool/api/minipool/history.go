@@ -0,0 +1,170 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Minipool balance event signatures
+var minipoolHistoryEvents = map[common.Hash]string{
+    crypto.Keccak256Hash([]byte("EtherReceived(address,uint256,uint256)")): "EtherReceived",
+    crypto.Keccak256Hash([]byte("EtherDeposited(address,uint256,uint256)")): "EtherDeposited",
+    crypto.Keccak256Hash([]byte("EtherWithdrawn(address,uint256,uint256)")): "EtherWithdrawn",
+    crypto.Keccak256Hash([]byte("EtherWithdrawalProcessed(address,uint256,uint256,uint256,uint256)")): "EtherWithdrawalProcessed",
+}
+
+
+func getHistory(c *cli.Context, fromBlock, toBlock uint64) (*api.MinipoolHistoryResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get node address
+    nodeAddress, err := services.GetNodeAddress(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Response
+    response := api.MinipoolHistoryResponse{
+        Events: []api.MinipoolHistoryEvent{},
+    }
+
+    // Get block range
+    if toBlock == 0 {
+        latestBlock, err := rp.Client.BlockNumber(context.Background())
+        if err != nil {
+            return nil, err
+        }
+        toBlock = latestBlock
+    }
+    if fromBlock > toBlock {
+        return nil, fmt.Errorf("The start block %d is after the end block %d.", fromBlock, toBlock)
+    }
+    response.FromBlock = fromBlock
+    response.ToBlock = toBlock
+
+    // Get minipool addresses
+    addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+    if len(addresses) == 0 {
+        return &response, nil
+    }
+
+    // Get event topics
+    eventTopics := []common.Hash{}
+    for topic := range minipoolHistoryEvents {
+        eventTopics = append(eventTopics, topic)
+    }
+
+    // Get logs
+    logs := []types.Log{}
+    err = eth1.FilterLogs(rp.Client, ethereum.FilterQuery{
+        Addresses: addresses,
+        Topics: [][]common.Hash{eventTopics},
+    }, fromBlock, toBlock, false, func(chunkLogs []types.Log) bool {
+        logs = append(logs, chunkLogs...)
+        return true
+    })
+    if err != nil {
+        return nil, fmt.Errorf("Could not get minipool logs: %w", err)
+    }
+
+    // Decode events
+    for _, log := range logs {
+        if log.Removed || len(log.Topics) == 0 {
+            continue
+        }
+        event, err := decodeMinipoolHistoryEvent(log)
+        if err != nil {
+            return nil, err
+        }
+
+        // Get minipool balance after the event's block
+        // Historical balances require an archive node, so the balance is left empty if it can't be read
+        err = eth1.RetryRateLimited(func() error {
+            var err error
+            event.Balance, err = rp.Client.BalanceAt(context.Background(), log.Address, new(big.Int).SetUint64(log.BlockNumber))
+            return err
+        })
+        if err != nil {
+            event.Balance = nil
+            event.BalanceError = fmt.Sprintf("Could not get minipool balance at block %d: %s", log.BlockNumber, err.Error())
+        }
+
+        response.Events = append(response.Events, event)
+    }
+
+    // Sort events by block
+    sort.SliceStable(response.Events, func(i, j int) bool {
+        return response.Events[i].Block < response.Events[j].Block
+    })
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Decode a minipool balance event log
+func decodeMinipoolHistoryEvent(log types.Log) (api.MinipoolHistoryEvent, error) {
+
+    // Get event name
+    name, ok := minipoolHistoryEvents[log.Topics[0]]
+    if !ok {
+        return api.MinipoolHistoryEvent{}, fmt.Errorf("Unknown minipool event %s", log.Topics[0].Hex())
+    }
+
+    // Split data into words
+    words := []*big.Int{}
+    for i := 0; i + common.HashLength <= len(log.Data); i += common.HashLength {
+        words = append(words, new(big.Int).SetBytes(log.Data[i:i + common.HashLength]))
+    }
+
+    // Decode event data
+    event := api.MinipoolHistoryEvent{
+        Address: log.Address,
+        Block: log.BlockNumber,
+        TxHash: log.TxHash,
+        Event: name,
+        Amount: big.NewInt(0),
+        NodeAmount: big.NewInt(0),
+        UserAmount: big.NewInt(0),
+    }
+    if name == "EtherWithdrawalProcessed" {
+        if len(words) < 4 {
+            return api.MinipoolHistoryEvent{}, fmt.Errorf("Invalid %s event data in transaction %s", name, log.TxHash.Hex())
+        }
+        event.NodeAmount = words[0]
+        event.UserAmount = words[1]
+        event.Amount = words[2]
+        event.Time = words[3].Uint64()
+    } else {
+        if len(words) < 2 {
+            return api.MinipoolHistoryEvent{}, fmt.Errorf("Invalid %s event data in transaction %s", name, log.TxHash.Hex())
+        }
+        event.Amount = words[0]
+        event.Time = words[1].Uint64()
+    }
+
+    // Return
+    return event, nil
+
+}
@@ -0,0 +1,84 @@
+package minipool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func getMinipoolByPubkey(c *cli.Context, pubkey types.ValidatorPubkey) (*api.MinipoolLookupResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get minipool address
+    minipoolAddress, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return lookupMinipool(rp, minipoolAddress)
+
+}
+
+
+func getMinipoolPubkey(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolLookupResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Return response
+    return lookupMinipool(rp, minipoolAddress)
+
+}
+
+
+// Get the pubkey & status of a minipool
+func lookupMinipool(rp *rocketpool.RocketPool, minipoolAddress common.Address) (*api.MinipoolLookupResponse, error) {
+
+    // Response
+    response := api.MinipoolLookupResponse{
+        Address: minipoolAddress,
+    }
+
+    // Check minipool exists
+    exists, err := minipool.GetMinipoolExists(rp, minipoolAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+    response.Exists = exists
+    if !exists {
+        return &response, nil
+    }
+
+    // Get minipool pubkey
+    response.ValidatorPubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    // Get minipool status
+    mp, err := minipool.NewMinipool(rp, minipoolAddress)
+    if err != nil {
+        return nil, err
+    }
+    response.MinipoolStatus, err = mp.GetStatus(nil)
+    if err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
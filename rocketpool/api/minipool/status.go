@@ -11,10 +11,12 @@ import (
 func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
 
     // Get services
-    if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    if c.String("node-address") == "" {
+        if err := services.RequireNodeRegistered(c); err != nil { return nil, err }
+    } else {
+        if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    }
     if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
-    if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
     bc, err := services.GetBeaconClient(c)
@@ -24,11 +26,11 @@ func getStatus(c *cli.Context) (*api.MinipoolStatusResponse, error) {
     response := api.MinipoolStatusResponse{}
 
     // Get minipool details
-    nodeAccount, err := w.GetNodeAccount()
+    nodeAddress, err := services.GetNodeAddress(c)
     if err != nil {
         return nil, err
     }
-    details, err := getNodeMinipoolDetails(rp, bc, nodeAccount.Address)
+    details, err := getNodeMinipoolDetails(rp, bc, nodeAddress)
     if err != nil {
         return nil, err
     }
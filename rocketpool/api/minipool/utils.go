@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
@@ -46,6 +47,7 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
     var eth2Config beacon.Eth2Config
     var currentEpoch uint64
     var currentBlock uint64
+    var withdrawalDelay uint64
 
     // Get minipool addresses
     wg1.Go(func() error {
@@ -79,6 +81,13 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
         return err
     })
 
+    // Get withdrawal delay
+    wg1.Go(func() error {
+        var err error
+        withdrawalDelay, err = rputils.GetMinipoolWithdrawalDelay(rp, nil)
+        return err
+    })
+
     // Wait for data
     if err := wg1.Wait(); err != nil {
         return []api.MinipoolDetails{}, err
@@ -106,7 +115,7 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
             wg.Go(func() error {
                 address := addresses[mi]
                 validator := validators[address]
-                mpDetails, err := getMinipoolDetails(rp, address, validator, eth2Config, currentEpoch, currentBlock)
+                mpDetails, err := getMinipoolDetails(rp, address, validator, eth2Config, currentEpoch, currentBlock, withdrawalDelay)
                 if err == nil { details[mi] = mpDetails }
                 return err
             })
@@ -124,7 +133,7 @@ func getNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 
 
 // Get a minipool's details
-func getMinipoolDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, validator beacon.ValidatorStatus, eth2Config beacon.Eth2Config, currentEpoch, currentBlock uint64) (api.MinipoolDetails, error) {
+func getMinipoolDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, validator beacon.ValidatorStatus, eth2Config beacon.Eth2Config, currentEpoch, currentBlock, withdrawalDelay uint64) (api.MinipoolDetails, error) {
 
     // Create minipool
     mp, err := minipool.NewMinipool(rp, minipoolAddress)
@@ -191,7 +200,10 @@ func getMinipoolDetails(rp *rocketpool.RocketPool, minipoolAddress common.Addres
     details.RefundAvailable = (details.Node.RefundBalance.Cmp(big.NewInt(0)) > 0)
     details.CloseAvailable = (details.Status.Status == types.Dissolved)
     if details.Status.Status == types.Withdrawable {
-        details.WithdrawalAvailable = true
+        details.WithdrawalDelayRemaining = rputils.GetMinipoolWithdrawalDelayRemaining(details.Status.StatusBlock, withdrawalDelay, currentBlock)
+        details.WithdrawalAvailableBlock = details.Status.StatusBlock + withdrawalDelay
+        details.WithdrawalAvailableTime = details.Status.StatusTime.Add(time.Duration(withdrawalDelay) * rputils.AverageBlockTime)
+        details.WithdrawalAvailable = (details.WithdrawalDelayRemaining == 0)
     }
     return details, nil
 
@@ -50,6 +50,58 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "stats",
+                Aliases:   []string{"s"},
+                Usage:     "Get a summary of network-wide Rocket Pool statistics",
+                UsageText: "rocketpool api network stats",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getStats(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "latest-block",
+                Aliases:   []string{"b"},
+                Usage:     "Get the latest eth1 block",
+                UsageText: "rocketpool api network latest-block",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getLatestBlock(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "eth1-latency",
+                Usage:     "Measure the round-trip latency of the eth1 client",
+                UsageText: "rocketpool api network eth1-latency count",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    count, err := cliutils.ValidatePositiveUint("request count", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getEth1Latency(c, int(count)))
+                    return nil
+
+                },
+            },
+
         },
     })
 }
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"context"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/latency"
+)
+
+
+func getEth1Latency(c *cli.Context, count int) (*api.Eth1LatencyResponse, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Measure eth_blockNumber calls
+    summary := latency.Measure(count, func() error {
+        _, err := ec.BlockNumber(context.Background())
+        return err
+    })
+
+    // Response
+    response := api.Eth1LatencyResponse{}
+    response.Requests = summary.Requests
+    response.Errors = make([]string, len(summary.Errors))
+    for ei, err := range summary.Errors {
+        response.Errors[ei] = err.Error()
+    }
+    response.MinLatency = summary.Min
+    response.AvgLatency = summary.Avg
+    response.P95Latency = summary.P95
+    response.Throughput = summary.Throughput
+
+    // Return response
+    return &response, nil
+
+}
@@ -0,0 +1,28 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func getLatestBlock(c *cli.Context) (*api.LatestBlockResponse, error) {
+
+    // Get latest block
+    header, err := services.GetLatestBlock(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Response
+    response := api.LatestBlockResponse{}
+    response.BlockNumber = header.Number.Uint64()
+    response.BlockHash = header.Hash()
+    response.BlockTime = header.Time
+
+    // Return response
+    return &response, nil
+
+}
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func getStats(c *cli.Context) (*api.NetworkStatsResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NetworkStatsResponse{}
+
+    // Sync
+    var wg errgroup.Group
+
+    // Get node & minipool counts
+    wg.Go(func() error {
+        var err error
+        response.NodeCount, err = node.GetNodeCount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinipoolCount, err = minipool.GetMinipoolCount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinipoolQueueLength, err = minipool.GetQueueTotalLength(rp, nil)
+        return err
+    })
+
+    // Get RPL staking details
+    wg.Go(func() error {
+        var err error
+        response.TotalRplStake, err = node.GetTotalRPLStake(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.TotalEffectiveRplStake, err = node.GetTotalEffectiveRPLStake(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.RplPrice, err = network.GetRPLPrice(rp, nil)
+        return err
+    })
+
+    // Get deposit pool balance
+    wg.Go(func() error {
+        var err error
+        response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
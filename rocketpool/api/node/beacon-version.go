@@ -0,0 +1,33 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func getBeaconClientVersion(c *cli.Context) (*api.NodeBeaconClientVersionResponse, error) {
+
+    // Get services
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeBeaconClientVersionResponse{}
+
+    // Get beacon client version
+    nodeVersion, err := bc.GetNodeVersion()
+    if err != nil {
+        return nil, err
+    }
+    response.ClientName = nodeVersion.ClientName
+    response.Version = nodeVersion.Version
+    response.RawVersion = nodeVersion.RawVersion
+
+    // Return response
+    return &response, nil
+
+}
+
@@ -0,0 +1,218 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const CancelGasPriceBumpPercent = 10
+
+
+func getPendingTransactions(c *cli.Context) (*api.NodePendingTransactionsResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodePendingTransactionsResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get the latest mined and next pending nonces
+    latestNonce, err := ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get latest nonce: %w", err)
+    }
+    pendingNonce, err := ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get next available nonce: %w", err)
+    }
+    response.LatestNonce = latestNonce
+    response.PendingNonce = pendingNonce
+
+    // Every nonce in the gap between them belongs to a transaction still in the mempool
+    response.PendingNonces = []uint64{}
+    for nonce := latestNonce; nonce < pendingNonce; nonce++ {
+        response.PendingNonces = append(response.PendingNonces, nonce)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func canCancelTransaction(c *cli.Context, nonce uint64) (*api.CanCancelTransactionResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CanCancelTransactionResponse{}
+
+    // Get transactor
+    opts, err := w.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, err
+    }
+
+    // Check the nonce belongs to a pending transaction
+    if err := checkCancelNonce(c, opts.From, nonce); err != nil {
+        response.NotPending = true
+        response.NotPendingReason = err.Error()
+        return &response, nil
+    }
+
+    // Get the replacement gas price
+    response.PendingGasPrice, response.GasPrice, err = getCancelGasPrice(c, opts.From, nonce, opts.GasPrice)
+    if err != nil {
+        return nil, err
+    }
+    response.GasPriceBumpPercent = CancelGasPriceBumpPercent
+    opts.GasPrice = response.GasPrice
+
+    // Get gas estimate for a zero-value self-transaction
+    gasInfo, err := eth.EstimateSendTransactionGas(ec, opts.From, opts)
+    if err != nil {
+        return nil, err
+    }
+    response.GasInfo = gasInfo
+
+    // Update & return response
+    response.CanCancel = !response.NotPending
+    return &response, nil
+
+}
+
+
+func cancelTransaction(c *cli.Context, nonce uint64) (*api.CancelTransactionResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.CancelTransactionResponse{}
+
+    // Get transactor
+    opts, err := w.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, err
+    }
+
+    // Check the nonce belongs to a pending transaction
+    if err := checkCancelNonce(c, opts.From, nonce); err != nil {
+        return nil, err
+    }
+
+    // Override the pending transaction's nonce & set the replacement gas price
+    opts.Nonce = new(big.Int).SetUint64(nonce)
+    if _, opts.GasPrice, err = getCancelGasPrice(c, opts.From, nonce, opts.GasPrice); err != nil {
+        return nil, err
+    }
+
+    // Replace the pending transaction with a zero-value transaction to self
+    hash, err := eth.SendTransaction(ec, opts.From, opts)
+    if err != nil {
+        return nil, err
+    }
+    response.TxHash = hash
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Check that a nonce refers to a transaction which is still pending
+func checkCancelNonce(c *cli.Context, nodeAddress common.Address, nonce uint64) error {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return err }
+
+    // Get the pending nonce range
+    latestNonce, err := ec.NonceAt(context.Background(), nodeAddress, nil)
+    if err != nil {
+        return fmt.Errorf("Could not get latest nonce: %w", err)
+    }
+    pendingNonce, err := ec.PendingNonceAt(context.Background(), nodeAddress)
+    if err != nil {
+        return fmt.Errorf("Could not get next available nonce: %w", err)
+    }
+    if latestNonce >= pendingNonce {
+        return fmt.Errorf("The node account has no pending transactions.")
+    }
+    if nonce < latestNonce || nonce >= pendingNonce {
+        return fmt.Errorf("Nonce %d does not belong to a pending transaction (pending nonces: %d to %d).", nonce, latestNonce, pendingNonce - 1)
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Get the gas price for a replacement transaction, which must be at least CancelGasPriceBumpPercent above the pending transaction's
+// A requested gas price is used if it is high enough; otherwise the suggested gas price is raised to the minimum if required
+// Returns the pending transaction's gas price, or nil if it couldn't be found in the eth1 client's transaction pool
+func getCancelGasPrice(c *cli.Context, nodeAddress common.Address, nonce uint64, requestedGasPrice *big.Int) (*big.Int, *big.Int, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, nil, err }
+
+    // Get the minimum replacement gas price, rounded up
+    var minGasPrice *big.Int
+    pendingGasPrice, err := services.GetPendingTransactionGasPrice(c, nodeAddress, nonce)
+    if err != nil {
+        pendingGasPrice = nil
+    } else {
+        minGasPrice = new(big.Int).Mul(pendingGasPrice, big.NewInt(100 + CancelGasPriceBumpPercent))
+        minGasPrice.Add(minGasPrice, big.NewInt(99))
+        minGasPrice.Div(minGasPrice, big.NewInt(100))
+    }
+
+    // Check the requested gas price
+    if requestedGasPrice != nil {
+        if minGasPrice != nil && requestedGasPrice.Cmp(minGasPrice) < 0 {
+            return nil, nil, fmt.Errorf("The gas price of %.6f gwei is too low to replace the pending transaction; it must be at least %.6f gwei (%d%% above the pending transaction's %.6f gwei).", eth.WeiToGwei(requestedGasPrice), eth.WeiToGwei(minGasPrice), CancelGasPriceBumpPercent, eth.WeiToGwei(pendingGasPrice))
+        }
+        return pendingGasPrice, requestedGasPrice, nil
+    }
+
+    // Get the suggested gas price, raised to the minimum
+    gasPrice, err := ec.SuggestGasPrice(context.Background())
+    if err != nil {
+        return nil, nil, fmt.Errorf("Could not get suggested gas price: %w", err)
+    }
+    if minGasPrice != nil && gasPrice.Cmp(minGasPrice) < 0 {
+        gasPrice = minGasPrice
+    }
+    return pendingGasPrice, gasPrice, nil
+
+}
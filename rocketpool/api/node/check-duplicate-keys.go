@@ -0,0 +1,129 @@
+package node
+
+import (
+	"errors"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const DuplicateKeyCheckEpochs = 2
+
+
+func checkDuplicateKeys(c *cli.Context, stoppedAt uint64) (*api.NodeCheckDuplicateKeysResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireBeaconClientSynced(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeCheckDuplicateKeysResponse{
+        CheckEpochs: DuplicateKeyCheckEpochs,
+    }
+
+    // Get validator pubkeys derived from the wallet
+    // Wallets with an imported node key can't derive validator keys, so the node's minipool pubkeys are used instead
+    pubkeys := []types.ValidatorPubkey{}
+    keyCount, err := w.GetValidatorKeyCount()
+    if err != nil {
+        return nil, err
+    }
+    for index := uint(0); index < keyCount; index++ {
+        key, err := w.GetValidatorKeyAt(index)
+        if errors.Is(err, wallet.ErrImportedNodeKey) {
+            pubkeys = []types.ValidatorPubkey{}
+            nodeAccount, err := w.GetNodeAccount()
+            if err != nil {
+                return nil, err
+            }
+            addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+            if err != nil {
+                return nil, err
+            }
+            for _, address := range addresses {
+                pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+                if err != nil {
+                    return nil, err
+                }
+                pubkeys = append(pubkeys, pubkey)
+            }
+            break
+        }
+        if err != nil {
+            return nil, err
+        }
+        pubkeys = append(pubkeys, types.BytesToValidatorPubkey(key.PublicKey().Marshal()))
+    }
+
+    // Get current epoch
+    head, err := bc.GetBeaconHead()
+    if err != nil {
+        return nil, err
+    }
+    response.CurrentEpoch = head.Epoch
+    if len(pubkeys) == 0 || head.Epoch < DuplicateKeyCheckEpochs {
+        return &response, nil
+    }
+
+    // Only compare balances from epochs after the local validator client stopped, if it has
+    // Attestation rewards are applied in the epoch after the attestation, so the epoch after it stopped is skipped too
+    if stoppedAt > 0 {
+        eth2Config, err := bc.GetEth2Config()
+        if err != nil {
+            return nil, err
+        }
+        stoppedEpoch := eth2Config.GenesisEpoch
+        if stoppedAt > eth2Config.GenesisTime && eth2Config.SecondsPerEpoch > 0 {
+            stoppedEpoch += (stoppedAt - eth2Config.GenesisTime) / eth2Config.SecondsPerEpoch
+        }
+        if settledEpoch := stoppedEpoch + 2; settledEpoch > head.Epoch - DuplicateKeyCheckEpochs {
+            response.WaitEpochs = settledEpoch - (head.Epoch - DuplicateKeyCheckEpochs)
+            return &response, nil
+        }
+    }
+
+    // Get validator statuses now and at the start of the check period
+    statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+    if err != nil {
+        return nil, err
+    }
+    previousStatuses, err := bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{Epoch: head.Epoch - DuplicateKeyCheckEpochs})
+    if err != nil {
+        return nil, err
+    }
+
+    // Check for validators whose balance increased over the check period, which means they are attesting somewhere
+    for _, pubkey := range pubkeys {
+        status, ok := statuses[pubkey]
+        if !ok || !status.Exists {
+            continue
+        }
+        validator := api.DuplicateKeyValidator{
+            Pubkey: pubkey,
+            Index: status.Index,
+            Active: (status.ActivationEpoch < head.Epoch - DuplicateKeyCheckEpochs && status.ExitEpoch > head.Epoch),
+        }
+        if previousStatus, ok := previousStatuses[pubkey]; ok && previousStatus.Exists && validator.Active {
+            validator.BalanceChange = int64(status.Balance) - int64(previousStatus.Balance)
+            validator.Attesting = (validator.BalanceChange > 0)
+        }
+        response.Validators = append(response.Validators, validator)
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/urfave/cli"
 
@@ -36,6 +37,10 @@ func canNodeClaimRpl(c *cli.Context) (*api.CanNodeClaimRplResponse, error) {
         return nil, fmt.Errorf("Error getting RPL rewards amount: %w", err)
     }
     response.RplAmount = rewardsAmountWei
+    response.CanClaim = (rewardsAmountWei.Cmp(big.NewInt(0)) > 0)
+    if !response.CanClaim {
+        return &response, nil
+    }
 
     // Get gas estimate
     opts, err := w.GetNodeAccountTransactor()
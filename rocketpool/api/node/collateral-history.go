@@ -0,0 +1,153 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const (
+    CollateralHistoryBlocksPerDay = 6500
+    MinipoolUserDepositEth = 16.0
+    MaxCollateralHistorySamples = 100
+)
+
+
+func getCollateralHistory(c *cli.Context, days, samples uint64) (*api.NodeCollateralHistoryResponse, error) {
+
+    // Check sample count
+    if samples > MaxCollateralHistorySamples {
+        return nil, fmt.Errorf("The number of samples must be no more than %d", MaxCollateralHistorySamples)
+    }
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get node address
+    nodeAddress, err := services.GetNodeAddress(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Response
+    response := api.NodeCollateralHistoryResponse{}
+
+    // Get the minimum collateral ratio
+    response.MinimumCollateralRatio, err = protocol.GetMinimumPerMinipoolStake(rp, nil)
+    if err != nil {
+        return nil, err
+    }
+
+    // Get the sample blocks
+    latestBlock, err := rp.Client.BlockNumber(context.Background())
+    if err != nil {
+        return nil, err
+    }
+    window := days * CollateralHistoryBlocksPerDay
+    if window > latestBlock {
+        window = latestBlock
+    }
+    interval := window
+    if samples > 1 {
+        interval = window / (samples - 1)
+        if interval == 0 {
+            return nil, fmt.Errorf("The history window of %d blocks is too short to take %d samples", window, samples)
+        }
+    }
+
+    // Get collateral samples, oldest first
+    for si := samples; si > 0; si-- {
+        blockNumber := latestBlock - interval * (si - 1)
+        sample, err := getCollateralSample(rp, nodeAddress, blockNumber)
+        if err != nil {
+            return nil, fmt.Errorf("Could not get collateral at block %d (historical reads require an archive node): %w", blockNumber, err)
+        }
+        response.Samples = append(response.Samples, sample)
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Get the node's collateral details at a block
+func getCollateralSample(rp *rocketpool.RocketPool, nodeAddress common.Address, blockNumber uint64) (api.CollateralSample, error) {
+
+    // Initialize call options
+    opts := &bind.CallOpts{
+        BlockNumber: new(big.Int).SetUint64(blockNumber),
+    }
+
+    // Data
+    var wg errgroup.Group
+    sample := api.CollateralSample{Block: blockNumber}
+
+    // Get block time
+    wg.Go(func() error {
+        header, err := rp.Client.HeaderByNumber(context.Background(), opts.BlockNumber)
+        if err == nil {
+            sample.Time = int64(header.Time)
+        }
+        return err
+    })
+
+    // Get collateral details
+    wg.Go(func() error {
+        var err error
+        sample.RplPrice, err = network.GetRPLPrice(rp, opts)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        sample.RplStake, err = node.GetNodeRPLStake(rp, nodeAddress, opts)
+        return err
+    })
+    wg.Go(func() error {
+        minipoolCount, err := minipool.GetNodeMinipoolCount(rp, nodeAddress, opts)
+        if err == nil {
+            sample.MinipoolCount = minipoolCount
+        }
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return api.CollateralSample{}, err
+    }
+
+    // Get the collateral ratio
+    sample.CollateralRatio = getCollateralRatio(sample.RplPrice, sample.RplStake, sample.MinipoolCount)
+
+    // Return
+    return sample, nil
+
+}
+
+
+// Get a node's collateral ratio, as the value of its staked RPL relative to the user-deposited ETH in its minipools
+// Nodes without minipools have a collateral ratio of 0
+func getCollateralRatio(rplPrice, rplStake *big.Int, minipoolCount uint64) float64 {
+    if minipoolCount == 0 {
+        return 0
+    }
+    return eth.WeiToEth(rplPrice) * eth.WeiToEth(rplStake) / (float64(minipoolCount) * MinipoolUserDepositEth)
+}
+
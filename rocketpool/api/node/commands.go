@@ -1,6 +1,8 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -19,12 +21,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 Name:      "status",
                 Aliases:   []string{"s"},
                 Usage:     "Get the node's status",
-                UsageText: "rocketpool api node status",
+                UsageText: "rocketpool api node status [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "The address of a node to observe instead of the node wallet",
+                    },
+                },
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
                     // Run
                     api.PrintResponse(getStatus(c))
                     return nil
@@ -36,14 +49,141 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 Name:      "sync",
                 Aliases:   []string{"y"},
                 Usage:     "Get the sync progress of the eth1 and eth2 clients",
-                UsageText: "rocketpool api node sync",
+                UsageText: "rocketpool api node sync [options]",
+                Flags: []cli.Flag{
+                    cli.Uint64Flag{
+                        Name:  "sync-retries",
+                        Usage: fmt.Sprintf("The number of times to retry a failed client sync check (at most %d)", MaxSyncRetries),
+                        Value: DefaultSyncRetries,
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.Uint64("sync-retries") > MaxSyncRetries {
+                        return fmt.Errorf("Invalid sync retries '%d' - cannot be greater than %d", c.Uint64("sync-retries"), MaxSyncRetries)
+                    }
+
+                    // Run
+                    api.PrintResponse(getSyncProgress(c, c.Uint64("sync-retries")))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "get-collateral-history",
+                Usage:     "Get the node's RPL collateral ratio sampled over a number of days",
+                UsageText: "rocketpool api node get-collateral-history days samples",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    days, err := cliutils.ValidatePositiveUint("days", c.Args().Get(0))
+                    if err != nil { return err }
+                    samples, err := cliutils.ValidatePositiveUint("samples", c.Args().Get(1))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getCollateralHistory(c, days, samples))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "get-rpl-required",
+                Usage:     "Get the RPL stake required to run a number of additional minipools",
+                UsageText: "rocketpool api node get-rpl-required minipools",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    minipools, err := cliutils.ValidatePositiveUint("minipool count", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getRplRequired(c, minipools))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "registration",
+                Usage:     "Get the block & transaction in which the node was registered",
+                UsageText: "rocketpool api node registration [options]",
+                Flags: []cli.Flag{
+                    cli.StringFlag{
+                        Name:  "node-address",
+                        Usage: "The address of a node to observe instead of the node wallet",
+                    },
+                },
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Validate flags
+                    if c.String("node-address") != "" {
+                        if _, err := cliutils.ValidateAddress("node address", c.String("node-address")); err != nil { return err }
+                    }
+
+                    // Run
+                    api.PrintResponse(getRegistration(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "get-beacon-client-version",
+                Usage:     "Get the eth2 beacon client name and version",
+                UsageText: "rocketpool api node get-beacon-client-version",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getBeaconClientVersion(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "test-connectivity",
+                Usage:     "Test connectivity to the eth1 and eth2 providers",
+                UsageText: "rocketpool api node test-connectivity",
                 Action: func(c *cli.Context) error {
 
                     // Validate args
                     if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
 
                     // Run
-                    api.PrintResponse(getSyncProgress(c))
+                    api.PrintResponse(testConnectivity(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "check-duplicate-keys",
+                Usage:     "Check whether the node's validator keys are attesting, to detect keys running elsewhere",
+                UsageText: "rocketpool api node check-duplicate-keys stopped-at",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    stoppedAt, err := cliutils.ValidateUint("stopped-at", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(checkDuplicateKeys(c, stoppedAt))
                     return nil
 
                 },
@@ -388,6 +528,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
                 },
             },
+            cli.Command{
+                Name:      "token-info",
+                Usage:     "Get the symbol, decimals and node balance of an ERC-20 token",
+                UsageText: "rocketpool api node token-info token-address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    tokenAddress, err := cliutils.ValidateAddress("token address", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getTokenInfo(c, tokenAddress))
+                    return nil
+
+                },
+            },
             cli.Command{
                 Name:      "send",
                 Aliases:   []string{"n"},
@@ -411,6 +568,96 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "sign-and-send",
+                Usage:     "Sign a transaction with the node account and broadcast it",
+                UsageText: "rocketpool api node sign-and-send to value data",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 3); err != nil { return err }
+                    toAddress, err := cliutils.ValidateAddress("to address", c.Args().Get(0))
+                    if err != nil { return err }
+                    value, err := cliutils.ValidateWeiAmount("value", c.Args().Get(1))
+                    if err != nil { return err }
+                    data, err := cliutils.ValidateHexBytes("transaction data", c.Args().Get(2))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(signAndSendTransaction(c, toAddress, value, data))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "send-raw-transaction",
+                Usage:     "Broadcast an already-signed raw transaction",
+                UsageText: "rocketpool api node send-raw-transaction signed-tx",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    rawTx, err := cliutils.ValidateHexBytes("signed transaction", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(sendRawTransaction(c, rawTx))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "get-pending-transactions",
+                Usage:     "Get the nonces of the node account's pending transactions",
+                UsageText: "rocketpool api node get-pending-transactions",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getPendingTransactions(c))
+                    return nil
+
+                },
+            },
+
+            cli.Command{
+                Name:      "can-cancel-transaction",
+                Usage:     "Check whether the pending transaction with the specified nonce can be cancelled",
+                UsageText: "rocketpool api node can-cancel-transaction nonce",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(canCancelTransaction(c, nonce))
+                    return nil
+
+                },
+            },
+            cli.Command{
+                Name:      "cancel-transaction",
+                Usage:     "Replace the pending transaction with the specified nonce with a zero-value transaction to the node account",
+                UsageText: "rocketpool api node cancel-transaction nonce",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    nonce, err := cliutils.ValidateUint("nonce", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(cancelTransaction(c, nonce))
+                    return nil
+
+                },
+            },
+
             cli.Command{
                 Name:      "can-burn",
                 Usage:     "Check whether the node can burn tokens for ETH",
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func signAndSendTransaction(c *cli.Context, to common.Address, value *big.Int, data []byte) (*api.SendRawTransactionResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SendRawTransactionResponse{}
+
+    // Get transactor
+    opts, err := w.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, err
+    }
+
+    // Override the provided pending TX if requested
+    err = eth1.CheckForNonceOverride(c, opts)
+    if err != nil {
+        return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+    }
+
+    // Sign & send the transaction; the nonce, gas price and gas limit are set by the transactor if not specified
+    opts.Value = value
+    contract := bind.NewBoundContract(to, abi.ABI{}, ec, ec, ec)
+    tx, err := contract.RawTransact(opts, data)
+    if err != nil {
+        return nil, fmt.Errorf("Could not send transaction: %w", err)
+    }
+    response.TxHash = tx.Hash()
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func sendRawTransaction(c *cli.Context, rawTx []byte) (*api.SendRawTransactionResponse, error) {
+
+    // Get services
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.SendRawTransactionResponse{}
+
+    // Decode the signed transaction
+    tx, err := decodeRawTransaction(rawTx)
+    if err != nil {
+        return nil, err
+    }
+
+    // Broadcast the transaction
+    if err := ec.SendTransaction(context.Background(), tx); err != nil {
+        return nil, fmt.Errorf("Could not send transaction: %w", err)
+    }
+    response.TxHash = tx.Hash()
+
+    // Return response
+    return &response, nil
+
+}
+
+
+
+// Decode a signed transaction in its eth_sendRawTransaction encoding
+// Legacy transactions are plain RLP, and typed (EIP-2718) transactions are a type byte followed by their RLP payload
+func decodeRawTransaction(rawTx []byte) (*types.Transaction, error) {
+    tx := new(types.Transaction)
+    if err := tx.UnmarshalBinary(rawTx); err != nil {
+        return nil, fmt.Errorf("Could not decode signed transaction: %w", err)
+    }
+    return tx, nil
+}
+
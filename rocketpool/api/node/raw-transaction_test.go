@@ -0,0 +1,95 @@
+package node
+
+import (
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/crypto"
+)
+
+
+func TestDecodeRawTransaction(t *testing.T) {
+
+    // Get signing key
+    key, err := crypto.GenerateKey()
+    if err != nil {
+        t.Fatalf("Could not generate key: %s", err)
+    }
+    chainID := big.NewInt(5)
+    to := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+    tests := []struct {
+        name string
+        tx *types.Transaction
+        signer types.Signer
+    }{
+        {
+            name: "legacy",
+            tx: types.NewTx(&types.LegacyTx{
+                Nonce: 1,
+                GasPrice: big.NewInt(1000000000),
+                Gas: 21000,
+                To: &to,
+                Value: big.NewInt(1),
+            }),
+            signer: types.NewEIP155Signer(chainID),
+        },
+        {
+            name: "access list",
+            tx: types.NewTx(&types.AccessListTx{
+                ChainID: chainID,
+                Nonce: 2,
+                GasPrice: big.NewInt(1000000000),
+                Gas: 30000,
+                To: &to,
+                Value: big.NewInt(1),
+                AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}},
+            }),
+            signer: types.NewEIP2930Signer(chainID),
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+
+            // Sign & encode transaction
+            signedTx, err := types.SignTx(test.tx, test.signer, key)
+            if err != nil {
+                t.Fatalf("Could not sign transaction: %s", err)
+            }
+            rawTx, err := signedTx.MarshalBinary()
+            if err != nil {
+                t.Fatalf("Could not encode transaction: %s", err)
+            }
+
+            // Decode transaction
+            tx, err := decodeRawTransaction(rawTx)
+            if err != nil {
+                t.Fatalf("Could not decode transaction: %s", err)
+            }
+            if tx.Type() != signedTx.Type() {
+                t.Errorf("Expected transaction type %d, got %d", signedTx.Type(), tx.Type())
+            }
+            if tx.Hash() != signedTx.Hash() {
+                t.Errorf("Expected transaction hash %s, got %s", signedTx.Hash().Hex(), tx.Hash().Hex())
+            }
+            sender, err := types.Sender(test.signer, tx)
+            if err != nil {
+                t.Fatalf("Could not recover transaction sender: %s", err)
+            }
+            if sender != crypto.PubkeyToAddress(key.PublicKey) {
+                t.Errorf("Expected sender %s, got %s", crypto.PubkeyToAddress(key.PublicKey).Hex(), sender.Hex())
+            }
+
+        })
+    }
+
+    // Invalid encodings
+    for _, rawTx := range [][]byte{{}, {0x01}, {0x7f, 0xc0}, {0xc0}} {
+        if _, err := decodeRawTransaction(rawTx); err == nil {
+            t.Errorf("Decoded invalid transaction %x", rawTx)
+        }
+    }
+
+}
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Node registered event signature
+var nodeRegisteredEvent = crypto.Keccak256Hash([]byte("NodeRegistered(address,uint256)"))
+
+
+func getRegistration(c *cli.Context) (*api.NodeRegistrationResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get node address
+    nodeAddress, err := services.GetNodeAddress(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Response
+    response := api.NodeRegistrationResponse{}
+
+    // Check node is registered
+    response.Registered, err = node.GetNodeExists(rp, nodeAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+    if !response.Registered {
+        return &response, nil
+    }
+
+    // Get node manager contract addresses, including those it was upgraded from
+    rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+    if err != nil {
+        return nil, err
+    }
+    addresses := []common.Address{*rocketNodeManager.Address}
+    for _, address := range cfg.Rocketpool.PreviousContractAddresses["rocketNodeManager"] {
+        addresses = append(addresses, common.HexToAddress(address))
+    }
+
+    // Get latest block
+    latestBlock, err := rp.Client.BlockNumber(context.Background())
+    if err != nil {
+        return nil, err
+    }
+
+    // Get logs from the latest block back to the deployment block, until the registration is found
+    err = eth1.FilterLogs(rp.Client, ethereum.FilterQuery{
+        Addresses: addresses,
+        Topics: [][]common.Hash{{nodeRegisteredEvent}, {nodeAddress.Hash()}},
+    }, cfg.Rocketpool.DeployBlock, latestBlock, true, func(logs []types.Log) bool {
+
+        // Use the latest registration log in the chunk
+        for li := len(logs) - 1; li >= 0; li-- {
+            log := logs[li]
+            if log.Removed {
+                continue
+            }
+            response.Found = true
+            response.Block = log.BlockNumber
+            response.TxHash = log.TxHash
+            if len(log.Data) >= common.HashLength {
+                response.Time = new(big.Int).SetBytes(log.Data[:common.HashLength]).Uint64()
+            }
+            return false
+        }
+        return true
+
+    })
+    if err != nil {
+        return nil, fmt.Errorf("Could not get node registration logs: %w", err)
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func getRplRequired(c *cli.Context, additionalMinipools uint64) (*api.NodeRplRequiredResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Get node address
+    nodeAddress, err := services.GetNodeAddress(c)
+    if err != nil {
+        return nil, err
+    }
+
+    // Response
+    response := api.NodeRplRequiredResponse{
+        AdditionalMinipools: additionalMinipools,
+    }
+
+    // Data
+    var wg errgroup.Group
+    var minipoolUserAmount *big.Int
+    var minPerMinipoolStake float64
+
+    // Get data
+    wg.Go(func() error {
+        var err error
+        response.MinipoolCount, err = minipool.GetNodeMinipoolCount(rp, nodeAddress, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.RplStake, err = node.GetNodeRPLStake(rp, nodeAddress, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.RplPrice, err = network.GetRPLPrice(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        minipoolUserAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        minPerMinipoolStake, err = protocol.GetMinimumPerMinipoolStake(rp, nil)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Check the RPL price is set; it is zero until the first price update
+    if response.RplPrice.Sign() == 0 {
+        return nil, errors.New("The RPL price has not been set by the Oracle DAO yet, so the required RPL stake can't be calculated")
+    }
+
+    // Calculate the minimum RPL stake for the target minipool count, rounding up
+    var tmp big.Int
+    var requiredRplStake big.Int
+    tmp.Mul(minipoolUserAmount, eth.EthToWei(minPerMinipoolStake))
+    tmp.Mul(&tmp, new(big.Int).SetUint64(response.MinipoolCount + additionalMinipools))
+    requiredRplStake.Quo(&tmp, response.RplPrice)
+    requiredRplStake.Add(&requiredRplStake, big.NewInt(1))
+    response.RequiredRplStake = &requiredRplStake
+
+    // Calculate the additional RPL to stake
+    response.AdditionalRplStake = big.NewInt(0)
+    if requiredRplStake.Cmp(response.RplStake) > 0 {
+        response.AdditionalRplStake.Sub(&requiredRplStake, response.RplStake)
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 
@@ -101,6 +102,27 @@ func canNodeSend(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
             }
             response.GasInfo = gasInfo
 
+        default:
+
+            // Check node ERC-20 token balance
+            tokenAddress, err := getERC20TokenAddress(token)
+            if err != nil {
+                return nil, err
+            }
+            tokenBalanceWei, err := rputils.GetERC20Balance(rp, tokenAddress, nodeAccount.Address, nil)
+            if err != nil {
+                return nil, err
+            }
+            response.InsufficientBalance = (amountWei.Cmp(tokenBalanceWei) > 0)
+            if response.InsufficientBalance {
+                break
+            }
+            gasInfo, err := rputils.EstimateTransferERC20Gas(rp, tokenAddress, nodeAccount.Address, amountWei, opts)
+            if err != nil {
+                return nil, err
+            }
+            response.GasInfo = gasInfo
+
     }
 
     // Update & return response
@@ -176,6 +198,53 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
             }
             response.TxHash = hash
 
+        default:
+
+            // Transfer ERC-20 tokens
+            tokenAddress, err := getERC20TokenAddress(token)
+            if err != nil {
+                return nil, err
+            }
+            hash, err := rputils.TransferERC20(rp, tokenAddress, to, amountWei, opts)
+            if err != nil {
+                return nil, err
+            }
+            response.TxHash = hash
+
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+func getTokenInfo(c *cli.Context, tokenAddress common.Address) (*api.NodeTokenInfoResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeTokenInfoResponse{}
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get token details & balance
+    response.Symbol, response.Decimals, err = rputils.GetERC20Details(rp, tokenAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+    response.Balance, err = rputils.GetERC20Balance(rp, tokenAddress, nodeAccount.Address, nil)
+    if err != nil {
+        return nil, err
     }
 
     // Return response
@@ -183,3 +252,11 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
 }
 
+
+// Get the contract address of an ERC-20 token type
+func getERC20TokenAddress(token string) (common.Address, error) {
+    if !common.IsHexAddress(token) {
+        return common.Address{}, fmt.Errorf("Unsupported token type '%s'", token)
+    }
+    return common.HexToAddress(token), nil
+}
@@ -1,8 +1,11 @@
 package node
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/urfave/cli"
@@ -24,6 +27,20 @@ func canSetWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, c
     // Response
     response := api.CanSetNodeWithdrawalAddressResponse{}
 
+    // Check the withdrawal address is not the zero address
+    if withdrawalAddress == (common.Address{}) {
+        response.ZeroAddress = true
+        response.CanSet = false
+        return &response, nil
+    }
+
+    // Check whether the withdrawal address is a contract
+    code, err := rp.Client.CodeAt(context.Background(), withdrawalAddress, nil)
+    if err != nil {
+        return nil, err
+    }
+    response.IsContract = (len(code) > 0)
+
     // Get transactor
     opts, err := w.GetNodeAccountTransactor()
     if err != nil {
@@ -36,6 +53,36 @@ func canSetWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, c
         return nil, err
     }
 
+    // Check the withdrawal address can be set from the node
+    response.CurrentWithdrawalAddress, err = node.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+    if err != nil {
+        return nil, err
+    }
+    response.WithdrawalAddressSet = (response.CurrentWithdrawalAddress != nodeAccount.Address)
+    response.Immediate = confirm
+
+    // Simulate the call to check it won't revert
+    rocketNodeManager, err := rp.GetContract("rocketNodeManager")
+    if err != nil {
+        return nil, err
+    }
+    callData, err := rocketNodeManager.ABI.Pack("setWithdrawalAddress", nodeAccount.Address, withdrawalAddress, confirm)
+    if err != nil {
+        return nil, fmt.Errorf("Could not encode set withdrawal address call: %w", err)
+    }
+    if _, err := rp.Client.CallContract(context.Background(), ethereum.CallMsg{
+        From: nodeAccount.Address,
+        To: rocketNodeManager.Address,
+        Data: callData,
+    }, nil); err != nil {
+        response.WouldRevert = true
+        response.RevertReason = err.Error()
+    }
+    response.CanSet = !(response.WithdrawalAddressSet || response.WouldRevert)
+    if !response.CanSet {
+        return &response, nil
+    }
+
     // Check withdrawal address setting
     gasInfo, err := node.EstimateSetWithdrawalAddressGas(rp, nodeAccount.Address, withdrawalAddress, confirm, opts)
     if err != nil {
@@ -44,7 +91,6 @@ func canSetWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, c
     response.GasInfo = gasInfo
 
     // Return response
-    response.CanSet = true
     return &response, nil
 }
 
@@ -61,6 +107,11 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddress common.Address, conf
     // Response
     response := api.SetNodeWithdrawalAddressResponse{}
 
+    // Check the withdrawal address is not the zero address
+    if withdrawalAddress == (common.Address{}) {
+        return nil, errors.New("The withdrawal address cannot be the zero address.")
+    }
+
     // Get transactor
     opts, err := w.GetNodeAccountTransactor()
     if err != nil {
@@ -17,6 +17,10 @@ import (
 )
 
 
+// Gas limit used to estimate the cost of an RPL stake before its approval is mined
+const stakeRplGasLimit = 250000
+
+
 func canNodeStakeRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeStakeRplResponse, error) {
 
     // Get services
@@ -64,12 +68,15 @@ func canNodeStakeRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeStakeRplRe
     if err != nil {
         return nil, err
     }
-    /*stakeGasInfo, err := node.EstimateStakeGas(rp, amountWei, opts)
-    if err != nil {
-        return nil, err
-    }*/
     response.GasInfo = approveGasInfo
-    //response.GasInfo.EstGasLimit += stakeGasInfo.EstGasLimit
+
+    // The stake can't be simulated until the approval is mined, so its estimate falls back to a fixed gas limit
+    stakeGasInfo, err := node.EstimateStakeGas(rp, amountWei, opts)
+    if err != nil {
+        stakeGasInfo = approveGasInfo
+        stakeGasInfo.EstGasLimit = stakeRplGasLimit
+    }
+    response.StakeGasInfo = stakeGasInfo
 
     // Update & return response
     response.CanStake = !(response.InsufficientBalance || !response.InConsensus)
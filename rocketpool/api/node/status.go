@@ -6,23 +6,28 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/network"
 	"github.com/rocket-pool/rocketpool-go/node"
-	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/rocket-pool/rocketpool-go/types"
-	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 
+// Get the node status for use outside of the API, e.g. by the node daemon's metrics server
+func GetStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
+    return getStatus(c)
+}
+
+
 func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
     // Get services
-    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
-    w, err := services.GetWallet(c)
+    cfg, err := services.GetConfig(c)
     if err != nil { return nil, err }
     rp, err := services.GetRocketPool(c)
     if err != nil { return nil, err }
@@ -30,19 +35,19 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
     // Response
     response := api.NodeStatusResponse{}
 
-    // Get node account
-    nodeAccount, err := w.GetNodeAccount()
+    // Get node address
+    nodeAddress, err := services.GetNodeAddress(c)
     if err != nil {
         return nil, err
     }
-    response.AccountAddress = nodeAccount.Address
+    response.AccountAddress = nodeAddress
 
     // Sync
     var wg errgroup.Group
 
     // Get node trusted status
     wg.Go(func() error {
-        trusted, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+        trusted, err := trustednode.GetMemberExists(rp, nodeAddress, nil)
         if err == nil {
             response.Trusted = trusted
         }
@@ -51,7 +56,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
     // Get node details
     wg.Go(func() error {
-        details, err := node.GetNodeDetails(rp, nodeAccount.Address, nil)
+        details, err := node.GetNodeDetails(rp, nodeAddress, nil)
         if err == nil {
             response.Registered = details.Exists
             response.WithdrawalAddress = details.WithdrawalAddress
@@ -63,38 +68,44 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
     // Get node account balances
     wg.Go(func() error {
         var err error
-        response.AccountBalances, err = tokens.GetBalances(rp, nodeAccount.Address, nil)
+        response.AccountBalances, err = rputils.GetBalances(rp, cfg, nodeAddress, nil)
         return err
     })
 
     // Get staking details
     wg.Go(func() error {
         var err error
-        response.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
+        response.RplStake, err = node.GetNodeRPLStake(rp, nodeAddress, nil)
         return err
     })
     wg.Go(func() error {
         var err error
-        response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAccount.Address, nil)
+        response.EffectiveRplStake, err = node.GetNodeEffectiveRPLStake(rp, nodeAddress, nil)
         return err
     })
     wg.Go(func() error {
         var err error
-        response.MinimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAccount.Address, nil)
+        response.MinimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAddress, nil)
         return err
     })
     wg.Go(func() error {
         var err error
-        response.MinipoolLimit, err = node.GetNodeMinipoolLimit(rp, nodeAccount.Address, nil)
+        response.MinipoolLimit, err = node.GetNodeMinipoolLimit(rp, nodeAddress, nil)
         return err
     })
 
     // Get node minipool counts
     wg.Go(func() error {
-        details, err := getNodeMinipoolCountDetails(rp, nodeAccount.Address)
+        details, err := getNodeMinipoolCountDetails(rp, nodeAddress)
         if err == nil {
             response.MinipoolCounts.Total = len(details)
-            for _, mpDetails := range details {
+            for mi, mpDetails := range details {
+                if mi == 0 || mpDetails.NodeFee < response.MinipoolNodeFees.Min {
+                    response.MinipoolNodeFees.Min = mpDetails.NodeFee
+                }
+                if mi == 0 || mpDetails.NodeFee > response.MinipoolNodeFees.Max {
+                    response.MinipoolNodeFees.Max = mpDetails.NodeFee
+                }
                 switch mpDetails.Status {
                     case types.Initialized:  response.MinipoolCounts.Initialized++
                     case types.Prelaunch:    response.MinipoolCounts.Prelaunch++
@@ -116,14 +127,36 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
         return err
     })
 
+    // Get network node fees
+    wg.Go(func() error {
+        var err error
+        response.NodeFee, err = network.GetNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MinNodeFee, err = protocol.GetMinimumNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.TargetNodeFee, err = protocol.GetTargetNodeFee(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        response.MaxNodeFee, err = protocol.GetMaximumNodeFee(rp, nil)
+        return err
+    })
+
     // Wait for data
     if err := wg.Wait(); err != nil {
         return nil, err
     }
 
     // Get withdrawal address balances
-    if !bytes.Equal(nodeAccount.Address.Bytes(), response.WithdrawalAddress.Bytes()) {
-        withdrawalBalances, err := tokens.GetBalances(rp, response.WithdrawalAddress, nil)
+    if !bytes.Equal(nodeAddress.Bytes(), response.WithdrawalAddress.Bytes()) {
+        withdrawalBalances, err := rputils.GetBalances(rp, cfg, response.WithdrawalAddress, nil)
         if err != nil {
             return nil, err
         }
@@ -135,7 +168,7 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
     if err != nil {
         return nil, err
     }
-    response.CollateralRatio = eth.WeiToEth(rplPrice) * eth.WeiToEth(response.RplStake) / (float64(response.MinipoolCounts.Total) * 16.0)
+    response.CollateralRatio = getCollateralRatio(rplPrice, response.RplStake, uint64(response.MinipoolCounts.Total))
 
     // Return response
     return &response, nil
@@ -2,18 +2,37 @@ package node
 
 import (
 	"context"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
 
 
-func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
+// Settings
+const (
+    DefaultSyncRetries = 3
+    MaxSyncRetries = 10
+    SyncRetryInitialDelay = time.Second
+    SyncRetryMaxDelay = 30 * time.Second
+    CheckpointSyncMaxHeadDistance = 64
+)
+
+
+// Get the client sync progress for use outside of the API, e.g. by the node daemon's metrics server
+func GetSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
+    return getSyncProgress(c, DefaultSyncRetries)
+}
+
+
+func getSyncProgress(c *cli.Context, retries uint64) (*api.NodeSyncProgressResponse, error) {
 
     // Get services
-    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
     if err := services.RequireRocketStorage(c); err != nil { return nil, err }
 
     // Response
@@ -26,16 +45,19 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
     }
 
     // Get eth1 sync progress
-    progress, err := ec.SyncProgress(context.Background())
-    if err != nil {
+    var progress *ethereum.SyncProgress
+    if err := retrySyncCheck(retries, func() error {
+        progress, err = ec.SyncProgress(context.Background())
+        return err
+    }); err != nil {
         return nil, err
     }
     if progress != nil {
-        p := float64(progress.CurrentBlock - progress.StartingBlock) / float64(progress.HighestBlock - progress.StartingBlock)
-        if p > 1 {
-            p = 1
-        } 
-        response.Eth1Progress = p
+        clientType, _, err := eth1.GetClientType(c)
+        if err != nil {
+            clientType = eth1.UnknownClient
+        }
+        response.Eth1Progress = getEth1SyncFraction(clientType, progress)
         response.Eth1Synced = false
     } else {
         response.Eth1Progress = 1
@@ -49,8 +71,11 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
     }
 
     // Get eth2 sync progress
-    syncStatus, err := bc.GetSyncStatus()
-    if err != nil {
+    var syncStatus beacon.SyncStatus
+    if err := retrySyncCheck(retries, func() error {
+        syncStatus, err = bc.GetSyncStatus()
+        return err
+    }); err != nil {
         return nil, err
     }
     if syncStatus.Syncing {
@@ -61,8 +86,69 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
         response.Eth2Synced = true
     }
 
+    // Detect a checkpoint sync which has reached the chain head but is still backfilling historical blocks
+    // The client is not considered synced until the backfill is complete
+    if syncStatus.Backfilling && syncStatus.SyncDistance <= CheckpointSyncMaxHeadDistance {
+        response.Eth2Backfilling = true
+        response.Eth2BackfillProgress = syncStatus.BackfillProgress
+        response.Eth2Synced = false
+    }
+
+    // Report whether the fallback eth2 client was used
+    if fallbackClient, ok := bc.(*beacon.FallbackClient); ok {
+        response.Eth2UsedFallback = fallbackClient.UsedFallback()
+    }
+
     // Return response
     return &response, nil
 
 }
 
+
+// Get the eth1 sync progress as a fraction, accounting for differences in how clients report it
+func getEth1SyncFraction(clientType eth1.ClientType, progress *ethereum.SyncProgress) float64 {
+
+    // Some clients report a highest block of 0 until they have found a peer
+    if progress.HighestBlock == 0 {
+        return 0
+    }
+
+    // Nethermind & Besu don't reliably report the block syncing started from, so use the overall fraction
+    var p float64
+    switch clientType {
+        case eth1.Nethermind, eth1.Besu:
+            p = float64(progress.CurrentBlock) / float64(progress.HighestBlock)
+        default:
+            if progress.HighestBlock <= progress.StartingBlock {
+                return 0
+            }
+            p = float64(progress.CurrentBlock - progress.StartingBlock) / float64(progress.HighestBlock - progress.StartingBlock)
+    }
+    if p > 1 {
+        p = 1
+    }
+    return p
+
+}
+
+
+// Run a sync check, retrying with exponential backoff so transient client errors aren't reported
+// Retries and the delay between them are capped
+func retrySyncCheck(retries uint64, check func() error) error {
+    if retries > MaxSyncRetries {
+        retries = MaxSyncRetries
+    }
+    delay := SyncRetryInitialDelay
+    for attempt := uint64(0); ; attempt++ {
+        err := check()
+        if err == nil || attempt >= retries {
+            return err
+        }
+        time.Sleep(delay)
+        delay *= 2
+        if delay > SyncRetryMaxDelay {
+            delay = SyncRetryMaxDelay
+        }
+    }
+}
+
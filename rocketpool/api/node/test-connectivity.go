@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+
+func testConnectivity(c *cli.Context) (*api.NodeTestConnectivityResponse, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.NodeTestConnectivityResponse{}
+
+    // Get expected eth1 chain ID
+    if cfg.Chains.Eth1.ChainID != "" {
+        expectedChainId, err := config.ParseChainID(cfg.Chains.Eth1.ChainID)
+        if err != nil {
+            return nil, err
+        }
+        if !expectedChainId.IsUint64() {
+            return nil, fmt.Errorf("Invalid chain ID '%s' - it is too large", cfg.Chains.Eth1.ChainID)
+        }
+        response.Eth1ExpectedChainID = expectedChainId.Uint64()
+    }
+
+    // Test eth1 provider
+    if err := testEth1Connectivity(c, &response); err != nil {
+        response.Eth1Error = err.Error()
+    } else {
+        response.Eth1Reachable = true
+        response.Eth1ChainIDMatch = (response.Eth1ExpectedChainID == 0 || response.Eth1ChainID == response.Eth1ExpectedChainID)
+    }
+
+    // Test eth2 provider
+    if err := testEth2Connectivity(c, &response); err != nil {
+        response.Eth2Error = err.Error()
+    } else {
+        response.Eth2Reachable = true
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Query the eth1 provider's network & chain IDs
+func testEth1Connectivity(c *cli.Context, response *api.NodeTestConnectivityResponse) error {
+
+    // Get eth1 client
+    ec, err := services.GetEthClient(c)
+    if err != nil {
+        return err
+    }
+
+    // Get network ID
+    start := time.Now()
+    networkId, err := ec.NetworkID(context.Background())
+    if err != nil {
+        return fmt.Errorf("Could not get eth1 network ID: %w", err)
+    }
+    response.Eth1Latency = time.Since(start)
+    response.Eth1NetworkID = networkId.Uint64()
+
+    // Get chain ID
+    chainId, err := ec.ChainID(context.Background())
+    if err != nil {
+        return fmt.Errorf("Could not get eth1 chain ID: %w", err)
+    }
+    response.Eth1ChainID = chainId.Uint64()
+
+    // Get client type; not all providers support web3_clientVersion
+    if clientType, clientVersion, err := eth1.GetClientType(c); err == nil {
+        response.Eth1ClientType = string(clientType)
+        response.Eth1ClientVersion = clientVersion
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Query the eth2 provider's sync status
+func testEth2Connectivity(c *cli.Context, response *api.NodeTestConnectivityResponse) error {
+
+    // Get eth2 client
+    bc, err := services.GetBeaconClient(c)
+    if err != nil {
+        return err
+    }
+
+    // Get sync status
+    start := time.Now()
+    if _, err := bc.GetSyncStatus(); err != nil {
+        return fmt.Errorf("Could not get eth2 sync status: %w", err)
+    }
+    response.Eth2Latency = time.Since(start)
+
+    // Return
+    return nil
+
+}
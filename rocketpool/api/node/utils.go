@@ -9,6 +9,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"golang.org/x/sync/errgroup"
+
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 // Settings
@@ -21,6 +23,7 @@ type minipoolCountDetails struct {
     RefundAvailable bool
     WithdrawalAvailable bool
     CloseAvailable bool
+    NodeFee float64
 }
 
 
@@ -31,6 +34,7 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
     var wg1 errgroup.Group
     var addresses []common.Address
     var currentBlock uint64
+    var withdrawalDelay uint64
 
     // Get minipool addresses
     wg1.Go(func() error {
@@ -48,6 +52,13 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
         return err
     })
 
+    // Get withdrawal delay
+    wg1.Go(func() error {
+        var err error
+        withdrawalDelay, err = rputils.GetMinipoolWithdrawalDelay(rp, nil)
+        return err
+    })
+
     // Wait for data
     if err := wg1.Wait(); err != nil {
         return []minipoolCountDetails{}, err
@@ -68,7 +79,7 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
             mi := mi
             wg.Go(func() error {
                 address := addresses[mi]
-                mpDetails, err := getMinipoolCountDetails(rp, address, currentBlock)
+                mpDetails, err := getMinipoolCountDetails(rp, address, currentBlock, withdrawalDelay)
                 if err == nil { details[mi] = mpDetails }
                 return err
             })
@@ -86,7 +97,7 @@ func getNodeMinipoolCountDetails(rp *rocketpool.RocketPool, nodeAddress common.A
 
 
 // Get a minipool's count details
-func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, currentBlock uint64) (minipoolCountDetails, error) {
+func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.Address, currentBlock, withdrawalDelay uint64) (minipoolCountDetails, error) {
 
     // Create minipool
     mp, err := minipool.NewMinipool(rp, minipoolAddress)
@@ -96,13 +107,14 @@ func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.A
 
     // Data
     var wg errgroup.Group
-    var status types.MinipoolStatus
+    var status minipool.StatusDetails
     var refundBalance *big.Int
+    var nodeFee float64
 
     // Load data
     wg.Go(func() error {
         var err error
-        status, err = mp.GetStatus(nil)
+        status, err = mp.GetStatusDetails(nil)
         return err
     })
     wg.Go(func() error {
@@ -110,6 +122,11 @@ func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.A
         refundBalance, err = mp.GetNodeRefundBalance(nil)
         return err
     })
+    wg.Go(func() error {
+        var err error
+        nodeFee, err = mp.GetNodeFee(nil)
+        return err
+    })
 
     // Wait for data
     if err := wg.Wait(); err != nil {
@@ -118,10 +135,11 @@ func getMinipoolCountDetails(rp *rocketpool.RocketPool, minipoolAddress common.A
 
     // Return
     return minipoolCountDetails{
-        Status: status,
+        Status: status.Status,
         RefundAvailable: (refundBalance.Cmp(big.NewInt(0)) > 0),
-        WithdrawalAvailable: (status == types.Withdrawable),
-        CloseAvailable: (status == types.Dissolved),
+        WithdrawalAvailable: (status.Status == types.Withdrawable && rputils.GetMinipoolWithdrawalDelayRemaining(status.StatusBlock, withdrawalDelay, currentBlock) == 0),
+        CloseAvailable: (status.Status == types.Dissolved),
+        NodeFee: nodeFee,
     }, nil
 
 }
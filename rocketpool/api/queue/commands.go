@@ -32,6 +32,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "position",
+                Usage:     "Get the queue position and estimated wait for ETH assignment of a new minipool",
+                UsageText: "rocketpool api queue position amount",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    amountWei, err := cliutils.ValidateDepositWeiAmount("deposit amount", c.Args().Get(0))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(getPosition(c, amountWei))
+                    return nil
+
+                },
+            },
+
             cli.Command{
                 Name:      "can-process",
                 Usage:     "Check whether the deposit pool can be processed",
@@ -0,0 +1,203 @@
+package queue
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/rocket-pool/rocketpool-go/deposit"
+    "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/settings/protocol"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    "github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Config
+const DepositRateBlocks = 50000
+
+
+// Deposit pool deposit event signature
+var depositReceivedEvent = crypto.Keccak256Hash([]byte("DepositReceived(address,uint256,uint256)"))
+
+
+func getPosition(c *cli.Context, amountWei *big.Int) (*api.QueuePositionResponse, error) {
+
+    // Get services
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.QueuePositionResponse{}
+
+    // Data
+    var wg errgroup.Group
+    var queueLengths minipool.QueueLengths
+    var fullNodeAmount, halfNodeAmount, emptyNodeAmount *big.Int
+    var fullUserAmount, halfUserAmount, emptyUserAmount *big.Int
+
+    // Get deposit pool balance
+    wg.Go(func() error {
+        var err error
+        response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+        return err
+    })
+
+    // Get minipool queue lengths
+    wg.Go(func() error {
+        var err error
+        queueLengths, err = minipool.GetQueueLengths(rp, nil)
+        return err
+    })
+
+    // Get minipool deposit amounts
+    wg.Go(func() error {
+        var err error
+        fullNodeAmount, err = protocol.GetMinipoolFullDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        halfNodeAmount, err = protocol.GetMinipoolHalfDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        emptyNodeAmount, err = protocol.GetMinipoolEmptyDepositNodeAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        fullUserAmount, err = protocol.GetMinipoolFullDepositUserAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        halfUserAmount, err = protocol.GetMinipoolHalfDepositUserAmount(rp, nil)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        emptyUserAmount, err = protocol.GetMinipoolEmptyDepositUserAmount(rp, nil)
+        return err
+    })
+
+    // Get recent deposit rate
+    wg.Go(func() error {
+        var err error
+        response.DepositRate, response.DepositRatePeriod, err = getDepositRate(rp)
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+    response.QueueLengths.Full = queueLengths.FullDeposit
+    response.QueueLengths.Half = queueLengths.HalfDeposit
+    response.QueueLengths.Empty = queueLengths.EmptyDeposit
+
+    // Get the user ETH required by the new minipool and the minipools assigned before it
+    // Queues are assigned in the order half, full, empty deposit
+    halfQueueAmount := new(big.Int).Mul(halfUserAmount, new(big.Int).SetUint64(queueLengths.HalfDeposit))
+    fullQueueAmount := new(big.Int).Mul(fullUserAmount, new(big.Int).SetUint64(queueLengths.FullDeposit))
+    emptyQueueAmount := new(big.Int).Mul(emptyUserAmount, new(big.Int).SetUint64(queueLengths.EmptyDeposit))
+    required := big.NewInt(0)
+    switch {
+        case amountWei.Cmp(halfNodeAmount) == 0:
+            response.DepositType = rptypes.Half
+            response.Position = queueLengths.HalfDeposit + 1
+            required.Add(halfQueueAmount, halfUserAmount)
+        case amountWei.Cmp(fullNodeAmount) == 0:
+            response.DepositType = rptypes.Full
+            response.Position = queueLengths.HalfDeposit + queueLengths.FullDeposit + 1
+            required.Add(halfQueueAmount, fullQueueAmount)
+            required.Add(required, fullUserAmount)
+        case amountWei.Cmp(emptyNodeAmount) == 0:
+            response.DepositType = rptypes.Empty
+            response.Position = queueLengths.HalfDeposit + queueLengths.FullDeposit + queueLengths.EmptyDeposit + 1
+            required.Add(halfQueueAmount, fullQueueAmount)
+            required.Add(required, emptyQueueAmount)
+            required.Add(required, emptyUserAmount)
+        default:
+            return nil, fmt.Errorf("Invalid deposit amount %s wei; it must match a full, half or empty minipool deposit", amountWei.String())
+    }
+    response.RequiredBalance = required
+
+    // Get the shortfall and estimated wait at the recent deposit rate
+    response.Shortfall = big.NewInt(0)
+    if required.Cmp(response.DepositPoolBalance) > 0 {
+        response.Shortfall.Sub(required, response.DepositPoolBalance)
+    }
+    if response.Shortfall.Sign() == 0 {
+        response.WaitEstimated = true
+    } else if response.DepositRate.Sign() > 0 && response.DepositRatePeriod > 0 {
+        wait := new(big.Int).Mul(response.Shortfall, new(big.Int).SetUint64(response.DepositRatePeriod))
+        wait.Div(wait, response.DepositRate)
+        response.WaitEstimated = true
+        response.EstimatedWait = wait.Uint64()
+    }
+
+    // Return response
+    return &response, nil
+
+}
+
+
+// Get the total ETH deposited into the deposit pool over recent blocks, and the period in seconds they cover
+func getDepositRate(rp *rocketpool.RocketPool) (*big.Int, uint64, error) {
+
+    // Get deposit pool contract
+    rocketDepositPool, err := rp.GetContract("rocketDepositPool")
+    if err != nil {
+        return nil, 0, err
+    }
+
+    // Get block range & period
+    latestHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return nil, 0, err
+    }
+    toBlock := latestHeader.Number.Uint64()
+    fromBlock := uint64(0)
+    if toBlock >= DepositRateBlocks {
+        fromBlock = toBlock - DepositRateBlocks + 1
+    }
+    fromHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(fromBlock))
+    if err != nil {
+        return nil, 0, err
+    }
+    period := latestHeader.Time - fromHeader.Time
+
+    // Sum deposits
+    total := big.NewInt(0)
+    err = eth1.FilterLogs(rp.Client, ethereum.FilterQuery{
+        Addresses: []common.Address{*rocketDepositPool.Address},
+        Topics: [][]common.Hash{{depositReceivedEvent}},
+    }, fromBlock, toBlock, false, func(logs []types.Log) bool {
+        for _, log := range logs {
+            if log.Removed || len(log.Data) < common.HashLength {
+                continue
+            }
+            total.Add(total, new(big.Int).SetBytes(log.Data[:common.HashLength]))
+        }
+        return true
+    })
+    if err != nil {
+        return nil, 0, fmt.Errorf("Could not get deposit pool logs: %w", err)
+    }
+
+    // Return
+    return total, period, nil
+
+}
@@ -1,8 +1,11 @@
 package wallet
 
 import (
+    "fmt"
+
     "github.com/urfave/cli"
 
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/utils/api"
     cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -88,6 +91,26 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "rebuild-from-keystore",
+                Usage:     "Initialize the node wallet from a hex-encoded V3 keystore and hex-encoded keystore password",
+                UsageText: "rocketpool api wallet rebuild-from-keystore keystore password",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil { return err }
+                    keystoreJson, err := cliutils.ValidateHexBytes("keystore", c.Args().Get(0))
+                    if err != nil { return err }
+                    keystorePassword, err := cliutils.ValidateHexBytes("keystore password", c.Args().Get(1))
+                    if err != nil { return err }
+
+                    // Run
+                    api.PrintResponse(rebuildFromKeystore(c, keystoreJson, string(keystorePassword)))
+                    return nil
+
+                },
+            },
+
             cli.Command{
                 Name:      "rebuild",
                 Aliases:   []string{"b"},
@@ -105,6 +128,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
                 },
             },
 
+            cli.Command{
+                Name:      "derived-addresses",
+                Usage:     "Get the node account addresses derived at the first count indices, with their balances",
+                UsageText: "rocketpool api wallet derived-addresses count",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil { return err }
+                    count, err := cliutils.ValidatePositiveUint("address count", c.Args().Get(0))
+                    if err != nil { return err }
+                    if count > wallet.MaxDerivedNodeAddresses {
+                        return fmt.Errorf("Invalid address count '%d' - cannot be greater than %d", count, wallet.MaxDerivedNodeAddresses)
+                    }
+
+                    // Run
+                    api.PrintResponse(getDerivedAddresses(c, count))
+                    return nil
+
+                },
+            },
+
             cli.Command{
                 Name:      "export",
                 Aliases:   []string{"e"},
@@ -0,0 +1,68 @@
+package wallet
+
+import (
+    "github.com/urfave/cli"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+    rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+
+// Config
+const DerivedAddressBalanceWorkers = 10
+
+
+func getDerivedAddresses(c *cli.Context, count uint64) (*api.DerivedAddressesResponse, error) {
+
+    // Get services
+    if err := services.RequireNodeWallet(c); err != nil { return nil, err }
+    if err := services.RequireRocketStorage(c); err != nil { return nil, err }
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.DerivedAddressesResponse{}
+
+    // Derive addresses
+    addresses, paths, err := w.GetDerivedNodeAddresses(uint(count))
+    if err != nil {
+        return nil, err
+    }
+    response.Addresses = make([]api.DerivedAddressBalance, len(addresses))
+
+    // Get balances with a fixed number of workers
+    indices := make(chan int, len(addresses))
+    for ai, address := range addresses {
+        response.Addresses[ai].Path = paths[ai]
+        response.Addresses[ai].Address = address
+        indices <- ai
+    }
+    close(indices)
+    var wg errgroup.Group
+    for wi := 0; wi < DerivedAddressBalanceWorkers; wi++ {
+        wg.Go(func() error {
+            for ai := range indices {
+                balances, err := rputils.GetBalances(rp, cfg, response.Addresses[ai].Address, nil)
+                if err != nil {
+                    return err
+                }
+                response.Addresses[ai].EthBalance = balances.ETH
+                response.Addresses[ai].RplBalance = balances.RPL
+            }
+            return nil
+        })
+    }
+    if err := wg.Wait(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
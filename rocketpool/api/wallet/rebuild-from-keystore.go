@@ -0,0 +1,48 @@
+package wallet
+
+import (
+    "errors"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+
+func rebuildFromKeystore(c *cli.Context, keystoreJson []byte, keystorePassword string) (*api.RebuildFromKeystoreResponse, error) {
+
+    // Get services
+    if err := services.RequireNodePassword(c); err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+
+    // Response
+    response := api.RebuildFromKeystoreResponse{}
+
+    // Check if wallet is already initialized
+    if w.IsInitialized() {
+        return nil, errors.New("The wallet is already initialized")
+    }
+
+    // Import node keystore; the keystore password is checked before anything is saved
+    if err := w.ImportNodeKeystore(keystoreJson, keystorePassword); err != nil {
+        return nil, err
+    }
+
+    // Get node account
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return nil, err
+    }
+    response.AccountAddress = nodeAccount.Address
+
+    // Save wallet
+    if err := w.Save(); err != nil {
+        return nil, err
+    }
+
+    // Return response
+    return &response, nil
+
+}
@@ -1,9 +1,12 @@
 package wallet
 
 import (
+    "fmt"
+
     "github.com/urfave/cli"
 
     "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/wallet"
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -33,6 +36,16 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
         }
         response.AccountAddress = nodeAccount.Address
 
+        // Get node key derivation path
+        response.NodeKeyImported = w.IsNodeKeyImported()
+        if !response.NodeKeyImported {
+            response.NodeKeyPath, response.NodeKeyIndex, err = w.GetNodeKeyPath()
+            if err != nil {
+                return nil, err
+            }
+            response.NodeKeyDefaultPath = fmt.Sprintf(wallet.NodeKeyPath, 0)
+        }
+
     }
 
     // Return response
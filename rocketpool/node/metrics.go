@@ -0,0 +1,240 @@
+package node
+
+import (
+    "bytes"
+    "fmt"
+    "math/big"
+    "net/http"
+    "sync"
+    "time"
+
+    "github.com/rocket-pool/rocketpool-go/tokens"
+    "github.com/rocket-pool/rocketpool-go/utils/eth"
+    "github.com/urfave/cli"
+
+    apinode "github.com/rocket-pool/smartnode/rocketpool/api/node"
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/poll"
+)
+
+
+// Node metrics server
+// Serves node metrics in the Prometheus text exposition format
+type metricsServer struct {
+    c *cli.Context
+    log log.ColorLogger
+    port uint16
+    interval time.Duration
+    schedule *poll.Schedule
+
+    // Rendered metrics from the last refresh
+    lock sync.RWMutex
+    metrics []byte
+}
+
+
+// Create node metrics server
+// Returns nil if no metrics port is configured
+func newMetricsServer(c *cli.Context, logger log.ColorLogger, jitter time.Duration) (*metricsServer, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+
+    // Check if metrics are disabled
+    if cfg.Smartnode.MetricsPort == 0 {
+        return nil, nil
+    }
+
+    // Get refresh interval
+    interval, err := cfg.GetMetricsInterval()
+    if err != nil { return nil, err }
+    if jitter >= interval {
+        jitter = interval / 2
+    }
+
+    // Return server
+    return &metricsServer{
+        c: c,
+        log: logger,
+        port: cfg.Smartnode.MetricsPort,
+        interval: interval,
+        schedule: poll.NewSchedule(interval, jitter),
+    }, nil
+
+}
+
+
+// Start serving metrics and refreshing them in the background
+func (m *metricsServer) start() {
+
+    // Refresh metrics periodically
+    go func() {
+        for {
+            m.schedule.Update(m.refresh())
+            time.Sleep(m.schedule.Next())
+        }
+    }()
+
+    // Serve metrics
+    go func() {
+        mux := http.NewServeMux()
+        mux.Handle("/metrics", m)
+        m.log.Printlnf("Serving node metrics on port %d, refreshing every %s.", m.port, m.interval.String())
+        if err := http.ListenAndServe(fmt.Sprintf(":%d", m.port), mux); err != nil {
+            m.log.Printlnf("Could not serve node metrics: %s", err.Error())
+        }
+    }()
+
+}
+
+
+// Handle a metrics request
+func (m *metricsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    m.lock.RLock()
+    defer m.lock.RUnlock()
+    w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+    w.Write(m.metrics)
+}
+
+
+// Refresh the rendered metrics
+// Returns whether the providers rate-limited the refresh
+func (m *metricsServer) refresh() bool {
+
+    // Render metrics
+    w := &metricsWriter{}
+    up := 1
+    rateLimited := false
+    if err := m.writeSyncMetrics(w); err != nil {
+        m.log.Printlnf("Could not get sync metrics: %s", err.Error())
+        up = 0
+        rateLimited = rateLimited || poll.IsRateLimitError(err)
+    }
+    if err := m.writeStatusMetrics(w); err != nil {
+        m.log.Printlnf("Could not get node status metrics: %s", err.Error())
+        up = 0
+        rateLimited = rateLimited || poll.IsRateLimitError(err)
+    }
+    w.gauge("rocketpool_node_metrics_up", "Whether the last metrics refresh succeeded")
+    w.sample("", float64(up))
+    w.gauge("rocketpool_node_metrics_last_refresh_timestamp_seconds", "The time of the last metrics refresh")
+    w.sample("", float64(time.Now().Unix()))
+
+    // Update metrics
+    m.lock.Lock()
+    defer m.lock.Unlock()
+    m.metrics = w.buf.Bytes()
+    return rateLimited
+
+}
+
+
+// Write client sync metrics
+func (m *metricsServer) writeSyncMetrics(w *metricsWriter) error {
+
+    // Get sync progress
+    progress, err := apinode.GetSyncProgress(m.c)
+    if err != nil {
+        return err
+    }
+
+    // Write metrics
+    w.gauge("rocketpool_node_sync_progress", "The sync progress of the client")
+    w.sample(`client="eth1"`, progress.Eth1Progress)
+    w.sample(`client="eth2"`, progress.Eth2Progress)
+    w.gauge("rocketpool_node_synced", "Whether the client is fully synced")
+    w.sample(`client="eth1"`, boolToFloat(progress.Eth1Synced))
+    w.sample(`client="eth2"`, boolToFloat(progress.Eth2Synced))
+    return nil
+
+}
+
+
+// Write node status metrics
+func (m *metricsServer) writeStatusMetrics(w *metricsWriter) error {
+
+    // Get node status
+    status, err := apinode.GetStatus(m.c)
+    if err != nil {
+        return err
+    }
+
+    // Write minipool metrics
+    w.gauge("rocketpool_node_minipools", "The number of node minipools by status")
+    w.sample(`status="initialized"`, float64(status.MinipoolCounts.Initialized))
+    w.sample(`status="prelaunch"`, float64(status.MinipoolCounts.Prelaunch))
+    w.sample(`status="staking"`, float64(status.MinipoolCounts.Staking))
+    w.sample(`status="withdrawable"`, float64(status.MinipoolCounts.Withdrawable))
+    w.sample(`status="dissolved"`, float64(status.MinipoolCounts.Dissolved))
+    w.gauge("rocketpool_node_minipool_limit", "The number of minipools the node's RPL stake allows it to run")
+    w.sample("", float64(status.MinipoolLimit))
+
+    // Write staking metrics
+    w.gauge("rocketpool_node_collateral_ratio", "The node's RPL collateral ratio")
+    w.sample("", status.CollateralRatio)
+    w.gauge("rocketpool_node_rpl_stake", "The node's RPL stake")
+    w.sample(`type="total"`, weiToEthFloat(status.RplStake))
+    w.sample(`type="effective"`, weiToEthFloat(status.EffectiveRplStake))
+    w.sample(`type="minimum"`, weiToEthFloat(status.MinimumRplStake))
+
+    // Write balance metrics
+    w.gauge("rocketpool_node_balance", "The token balances of the node & withdrawal accounts")
+    writeBalanceMetrics(w, "node", status.AccountBalances)
+    if !bytes.Equal(status.AccountAddress.Bytes(), status.WithdrawalAddress.Bytes()) {
+        writeBalanceMetrics(w, "withdrawal", status.WithdrawalBalances)
+    }
+    return nil
+
+}
+
+
+// Write the token balance metric samples for an account
+func writeBalanceMetrics(w *metricsWriter, account string, balances tokens.Balances) {
+    w.sample(fmt.Sprintf(`account="%s",token="eth"`, account), weiToEthFloat(balances.ETH))
+    w.sample(fmt.Sprintf(`account="%s",token="rpl"`, account), weiToEthFloat(balances.RPL))
+    w.sample(fmt.Sprintf(`account="%s",token="reth"`, account), weiToEthFloat(balances.RETH))
+}
+
+
+// Prometheus text format writer
+type metricsWriter struct {
+    buf bytes.Buffer
+    name string
+}
+
+
+// Start a gauge metric; following samples belong to it
+func (w *metricsWriter) gauge(name, help string) {
+    fmt.Fprintf(&w.buf, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+    w.name = name
+}
+
+
+// Write a sample of the current metric
+func (w *metricsWriter) sample(labels string, value float64) {
+    if labels != "" {
+        fmt.Fprintf(&w.buf, "%s{%s} %g\n", w.name, labels, value)
+    } else {
+        fmt.Fprintf(&w.buf, "%s %g\n", w.name, value)
+    }
+}
+
+
+// Convert a wei amount to a float ETH value
+func weiToEthFloat(value *big.Int) float64 {
+    if value == nil {
+        return 0
+    }
+    return eth.WeiToEth(value)
+}
+
+
+// Convert a bool to a metric value
+func boolToFloat(value bool) float64 {
+    if value {
+        return 1
+    }
+    return 0
+}
@@ -9,17 +9,19 @@ import (
 
     "github.com/rocket-pool/smartnode/shared/services"
     "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/poll"
 )
 
 
 // Config
-var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 const (
     MaxConcurrentEth1Requests = 200
 
     ClaimRplRewardsColor = color.FgGreen
     StakePrelaunchMinipoolsColor = color.FgBlue
+    NotifyNodeEventsColor = color.FgMagenta
+    MetricsColor = color.FgCyan
     ErrorColor = color.FgRed
 )
 
@@ -43,28 +45,62 @@ func run(c *cli.Context) error {
     // Configure
     configureHTTP()
 
+    // Get task poll schedule
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+    pollInterval, pollJitter, err := cfg.GetPollInterval()
+    if err != nil { return err }
+    schedule := poll.NewSchedule(pollInterval, pollJitter)
+
     // Wait until node is registered
     if err := services.WaitNodeRegistered(c, true); err != nil { return err }
 
+    // Lock the node wallet on request
+    if err := services.LockWalletOnSignal(c); err != nil { return err }
+
     // Initialize tasks
     claimRplRewards, err := newClaimRplRewards(c, log.NewColorLogger(ClaimRplRewardsColor))
     if err != nil { return err }
     stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
     if err != nil { return err }
+    notifyNodeEvents, err := newNotifyNodeEvents(c, log.NewColorLogger(NotifyNodeEventsColor))
+    if err != nil { return err }
+
+    // Start metrics server
+    metrics, err := newMetricsServer(c, log.NewColorLogger(MetricsColor), pollJitter)
+    if err != nil { return err }
+    if metrics != nil {
+        metrics.start()
+    }
 
     // Initialize error logger
     errorLog := log.NewColorLogger(ErrorColor)
 
     // Run task loop
     for {
+        rateLimited := false
         if err := claimRplRewards.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := stakePrelaunchMinipools.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
+        }
+        time.Sleep(taskCooldown)
+        if err := notifyNodeEvents.run(); err != nil {
+            errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
+        }
+
+        // Back off while the providers are rate-limiting requests
+        schedule.Update(rateLimited)
+        delay := schedule.Next()
+        if schedule.IsBackingOff() {
+            errorLog.Printlnf("The eth1 or eth2 provider is rate-limiting requests, waiting %s before the next run.", delay.Round(time.Second))
         }
-        time.Sleep(tasksInterval)
+        time.Sleep(delay)
     }
 
 }
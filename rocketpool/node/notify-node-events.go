@@ -0,0 +1,279 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+
+// Config
+const MaxPendingNotifications = 100
+
+
+// Notify node events task
+type notifyNodeEvents struct {
+    c *cli.Context
+    log log.ColorLogger
+    w *wallet.Wallet
+    ec *ethclient.Client
+    rp *rocketpool.RocketPool
+    bc beacon.Client
+    senders []notifications.Sender
+
+    // Events which could not be sent, per channel, retried on each run
+    pending [][]notifications.Event
+
+    // Previous state, so that events are only sent when they first occur
+    clientsSynced bool
+    collateralLow bool
+    withdrawableMinipools map[common.Address]bool
+}
+
+
+// Create notify node events task
+func newNotifyNodeEvents(c *cli.Context, logger log.ColorLogger) (*notifyNodeEvents, error) {
+
+    // Get services
+    cfg, err := services.GetConfig(c)
+    if err != nil { return nil, err }
+    w, err := services.GetWallet(c)
+    if err != nil { return nil, err }
+    ec, err := services.GetEthClient(c)
+    if err != nil { return nil, err }
+    rp, err := services.GetRocketPool(c)
+    if err != nil { return nil, err }
+    bc, err := services.GetBeaconClient(c)
+    if err != nil { return nil, err }
+
+    // Check if notifications are disabled
+    senders := notifications.NewSenders(cfg)
+    if len(senders) == 0 {
+        logger.Println("No notification channels are configured, node event notifications will be disabled.")
+    }
+
+    // Return task
+    return &notifyNodeEvents{
+        c: c,
+        log: logger,
+        w: w,
+        ec: ec,
+        rp: rp,
+        bc: bc,
+        senders: senders,
+        pending: make([][]notifications.Event, len(senders)),
+        clientsSynced: true,
+        withdrawableMinipools: map[common.Address]bool{},
+    }, nil
+
+}
+
+
+// Notify node events
+func (t *notifyNodeEvents) run() error {
+
+    // Check to see if notifications are disabled
+    if len(t.senders) == 0 {
+        return nil
+    }
+
+    // Retry events which could not be sent on previous runs
+    t.retryPending()
+
+    // Get node account
+    nodeAccount, err := t.w.GetNodeAccount()
+    if err != nil {
+        return err
+    }
+
+    // Check client sync status; skip remaining checks while syncing
+    synced, err := t.checkClientsSynced(nodeAccount.Address)
+    if err != nil {
+        return err
+    }
+    if !synced {
+        return nil
+    }
+
+    // Check RPL collateral & minipools
+    if err := t.checkRplCollateral(nodeAccount.Address); err != nil {
+        return err
+    }
+    if err := t.checkWithdrawableMinipools(nodeAccount.Address); err != nil {
+        return err
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Notify if either client has fallen out of sync
+func (t *notifyNodeEvents) checkClientsSynced(nodeAddress common.Address) (bool, error) {
+
+    // Get client sync status
+    eth1Progress, err := t.ec.SyncProgress(context.Background())
+    if err != nil {
+        return false, fmt.Errorf("Could not get eth1 client sync progress: %w", err)
+    }
+    eth2Status, err := t.bc.GetSyncStatus()
+    if err != nil {
+        return false, fmt.Errorf("Could not get eth2 client sync status: %w", err)
+    }
+    synced := (eth1Progress == nil && !eth2Status.Syncing)
+
+    // Notify on loss of sync
+    if t.clientsSynced && !synced {
+        var message string
+        if eth1Progress != nil {
+            message = "The eth1 client is not synced."
+        } else {
+            message = "The eth2 client is not synced."
+        }
+        t.send(notifications.Event{
+            Type: notifications.ClientNotSynced,
+            Message: message,
+            NodeAddress: nodeAddress,
+        })
+    }
+    t.clientsSynced = synced
+
+    // Return
+    return synced, nil
+
+}
+
+
+// Notify if the node's RPL stake has dropped below the minimum
+func (t *notifyNodeEvents) checkRplCollateral(nodeAddress common.Address) error {
+
+    // Get RPL stake
+    rplStake, err := node.GetNodeRPLStake(t.rp, nodeAddress, nil)
+    if err != nil {
+        return err
+    }
+    minimumRplStake, err := node.GetNodeMinimumRPLStake(t.rp, nodeAddress, nil)
+    if err != nil {
+        return err
+    }
+    collateralLow := (rplStake.Cmp(minimumRplStake) < 0)
+
+    // Notify when collateral first drops below the minimum
+    if collateralLow && !t.collateralLow {
+        t.send(notifications.Event{
+            Type: notifications.RplCollateralLow,
+            Message: fmt.Sprintf("The node's RPL stake of %.6f RPL is below the minimum of %.6f RPL.", eth.WeiToEth(rplStake), eth.WeiToEth(minimumRplStake)),
+            NodeAddress: nodeAddress,
+        })
+    }
+    t.collateralLow = collateralLow
+
+    // Return
+    return nil
+
+}
+
+
+// Notify of minipools which have become withdrawable
+func (t *notifyNodeEvents) checkWithdrawableMinipools(nodeAddress common.Address) error {
+
+    // Get node minipool addresses
+    addresses, err := minipool.GetNodeMinipoolAddresses(t.rp, nodeAddress, nil)
+    if err != nil {
+        return err
+    }
+
+    // Check minipool statuses
+    for _, address := range addresses {
+        if t.withdrawableMinipools[address] { continue }
+        mp, err := minipool.NewMinipool(t.rp, address)
+        if err != nil {
+            return err
+        }
+        status, err := mp.GetStatus(nil)
+        if err != nil {
+            return err
+        }
+        if status != rptypes.Withdrawable { continue }
+
+        // Notify
+        minipoolAddress := address
+        t.send(notifications.Event{
+            Type: notifications.MinipoolWithdrawable,
+            Message: fmt.Sprintf("Minipool %s is now withdrawable.", address.Hex()),
+            NodeAddress: nodeAddress,
+            MinipoolAddress: &minipoolAddress,
+        })
+        t.withdrawableMinipools[address] = true
+
+    }
+
+    // Return
+    return nil
+
+}
+
+
+// Send an event notification to all channels
+// Events which fail to send are queued and retried on the failed channel only, so other channels don't get duplicates
+func (t *notifyNodeEvents) send(event notifications.Event) {
+    event.Time = time.Now()
+    t.log.Printlnf("Sending %s notification...", event.Type)
+    for si, sender := range t.senders {
+
+        // Queue behind pending events to keep them in order
+        if len(t.pending[si]) > 0 {
+            t.queue(si, event)
+            continue
+        }
+
+        // Send event
+        if err := sender.Send(event); err != nil {
+            t.log.Println(err)
+            t.queue(si, event)
+        }
+
+    }
+}
+
+
+// Retry sending pending events to each channel in order, stopping at the first failure
+func (t *notifyNodeEvents) retryPending() {
+    for si, sender := range t.senders {
+        for len(t.pending[si]) > 0 {
+            event := t.pending[si][0]
+            t.log.Printlnf("Retrying %s notification to %s...", event.Type, sender.Name())
+            if err := sender.Send(event); err != nil {
+                t.log.Println(err)
+                break
+            }
+            t.pending[si] = t.pending[si][1:]
+        }
+    }
+}
+
+
+// Queue an event to retry on a channel, dropping the oldest event if the queue is full
+func (t *notifyNodeEvents) queue(si int, event notifications.Event) {
+    if len(t.pending[si]) >= MaxPendingNotifications {
+        t.log.Printlnf("Dropping pending %s notification to %s.", t.pending[si][0].Type, t.senders[si].Name())
+        t.pending[si] = t.pending[si][1:]
+    }
+    t.pending[si] = append(t.pending[si], event)
+}
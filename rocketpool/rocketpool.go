@@ -82,14 +82,26 @@ func main() {
             Name:  "eth2Provider, b",
             Usage: "Eth 2.0 provider `address`",
         },
+        cli.StringFlag{
+            Name:  "eth2FallbackProvider",
+            Usage: "Fallback Eth 2.0 provider `address`, used when the primary provider cannot be reached",
+        },
+        cli.StringFlag{
+            Name:  "chainId",
+            Usage: "Eth 1.0 chain `ID` to sign transactions for, for custom networks; must match the Eth 1.0 provider's chain ID",
+        },
         cli.StringFlag{
             Name:  "gasPrice, g",
-            Usage: "Desired gas price in gwei",
+            Usage: "Desired gas price in gwei (e.g. '30' or '30gwei') or wei (e.g. '30000000000wei')",
         },
         cli.StringFlag{
             Name:  "gasLimit, l",
             Usage: "Desired gas limit",
         },
+        cli.StringFlag{
+            Name:  "webhookUrl",
+            Usage: "Webhook `URL` to POST node event notifications to",
+        },
         cli.Uint64Flag{
             Name: "nonce",
             Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
@@ -108,6 +120,16 @@ func main() {
         return nil
     }
 
+    // Print API command panics as error responses so that consumers always receive a JSON response
+    defer func() {
+        if r := recover(); r != nil {
+            if commandName != "api" {
+                panic(r)
+            }
+            apiutils.PrintErrorResponse(fmt.Errorf("Unexpected error: %v", r))
+        }
+    }()
+
     // Run application
     if err := app.Run(os.Args); err != nil {
         if commandName == "api" {
@@ -9,11 +9,11 @@ import (
 
     "github.com/rocket-pool/smartnode/shared/services"
     "github.com/rocket-pool/smartnode/shared/utils/log"
+    "github.com/rocket-pool/smartnode/shared/utils/poll"
 )
 
 
 // Config
-var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 const (
     MaxConcurrentEth1Requests = 200
@@ -48,9 +48,19 @@ func run(c *cli.Context) error {
     // Configure
     configureHTTP()
 
+    // Get task poll schedule
+    cfg, err := services.GetConfig(c)
+    if err != nil { return err }
+    pollInterval, pollJitter, err := cfg.GetPollInterval()
+    if err != nil { return err }
+    schedule := poll.NewSchedule(pollInterval, pollJitter)
+
     // Wait until node is registered
     if err := services.WaitNodeRegistered(c, true); err != nil { return err }
 
+    // Lock the node wallet on request
+    if err := services.LockWalletOnSignal(c); err != nil { return err }
+
     // Initialize tasks
     respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
     if err != nil { return err }
@@ -72,34 +82,49 @@ func run(c *cli.Context) error {
 
     // Run task loop
     for {
+        rateLimited := false
         if err := respondChallenges.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := claimRplRewards.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := submitRplPrice.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := submitNetworkBalances.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := submitWithdrawableMinipools.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := dissolveTimedOutMinipools.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
         }
         time.Sleep(taskCooldown)
         if err := processWithdrawals.run(); err != nil {
             errorLog.Println(err)
+            rateLimited = rateLimited || poll.IsRateLimitError(err)
+        }
+
+        // Back off while the providers are rate-limiting requests
+        schedule.Update(rateLimited)
+        delay := schedule.Next()
+        if schedule.IsBackingOff() {
+            errorLog.Printlnf("The eth1 or eth2 provider is rate-limiting requests, waiting %s before the next run.", delay.Round(time.Second))
         }
-        time.Sleep(tasksInterval)
+        time.Sleep(delay)
     }
 
 }
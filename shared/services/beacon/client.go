@@ -1,6 +1,8 @@
 package beacon
 
 import (
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
 )
@@ -15,6 +17,9 @@ type ValidatorStatusOptions struct {
 type SyncStatus struct {
     Syncing bool
     Progress float64
+    SyncDistance uint64
+    Backfilling bool
+    BackfillProgress float64
 }
 type Eth2Config struct {
     GenesisForkVersion []byte
@@ -29,6 +34,11 @@ type BeaconHead struct {
     JustifiedEpoch uint64
     PreviousJustifiedEpoch uint64
 }
+type NodeVersion struct {
+    ClientName string
+    Version string
+    RawVersion string
+}
 type ValidatorStatus struct {
     Pubkey types.ValidatorPubkey
     Index uint64
@@ -63,6 +73,7 @@ const(
 type Client interface {
     GetClientType() (BeaconClientType)
     GetSyncStatus() (SyncStatus, error)
+    GetNodeVersion() (NodeVersion, error)
     GetEth2Config() (Eth2Config, error)
     GetBeaconHead() (BeaconHead, error)
     GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error)
@@ -73,3 +84,24 @@ type Client interface {
     Close() error
 }
 
+
+// Parse a node version string (e.g. "Lighthouse/v1.1.0-e4b6213/x86_64-linux") into the client name & version
+func ParseNodeVersion(rawVersion string) NodeVersion {
+    nodeVersion := NodeVersion{RawVersion: rawVersion}
+    parts := strings.SplitN(rawVersion, "/", 3)
+    nodeVersion.ClientName = parts[0]
+    if len(parts) > 1 {
+        nodeVersion.Version = parts[1]
+    }
+    return nodeVersion
+}
+
+
+// Split a beacon provider address into its protocol and host
+// Addresses without a protocol use the default protocol
+func ParseProviderAddress(providerAddress string, defaultProtocol string) (string, string) {
+    if parts := strings.SplitN(providerAddress, "://", 2); len(parts) == 2 {
+        return parts[0], parts[1]
+    }
+    return defaultProtocol, providerAddress
+}
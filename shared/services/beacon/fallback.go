@@ -0,0 +1,189 @@
+package beacon
+
+import (
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Error message fragments which indicate a connection-level failure
+var connectionErrorMessages = []string{"connection refused", "connection reset", "no such host", "i/o timeout", "EOF", "code = Unavailable"}
+
+
+// Beacon client which falls back to a secondary beacon node on connection-level failures
+// Requests are only retried against the fallback if the primary could not be reached; other errors (e.g. invalid responses) are returned as-is
+type FallbackClient struct {
+    primary Client
+    fallback Client
+    usedFallback int32
+}
+
+
+// Create new fallback client
+func NewFallbackClient(primary, fallback Client) *FallbackClient {
+    return &FallbackClient{
+        primary: primary,
+        fallback: fallback,
+    }
+}
+
+
+// Check whether any request has been served by the fallback client
+func (c *FallbackClient) UsedFallback() bool {
+    return atomic.LoadInt32(&c.usedFallback) == 1
+}
+
+
+// Close the client connections
+func (c *FallbackClient) Close() error {
+    primaryErr := c.primary.Close()
+    fallbackErr := c.fallback.Close()
+    if primaryErr != nil {
+        return primaryErr
+    }
+    return fallbackErr
+}
+
+
+// Get the beacon client type
+func (c *FallbackClient) GetClientType() (BeaconClientType) {
+    return c.primary.GetClientType()
+}
+
+
+// Get the node's sync status
+func (c *FallbackClient) GetSyncStatus() (SyncStatus, error) {
+    var syncStatus SyncStatus
+    err := c.run("GetSyncStatus", func(client Client) error {
+        var err error
+        syncStatus, err = client.GetSyncStatus()
+        return err
+    })
+    return syncStatus, err
+}
+
+
+// Get the node's client version
+func (c *FallbackClient) GetNodeVersion() (NodeVersion, error) {
+    var nodeVersion NodeVersion
+    err := c.run("GetNodeVersion", func(client Client) error {
+        var err error
+        nodeVersion, err = client.GetNodeVersion()
+        return err
+    })
+    return nodeVersion, err
+}
+
+
+// Get the eth2 config
+func (c *FallbackClient) GetEth2Config() (Eth2Config, error) {
+    var eth2Config Eth2Config
+    err := c.run("GetEth2Config", func(client Client) error {
+        var err error
+        eth2Config, err = client.GetEth2Config()
+        return err
+    })
+    return eth2Config, err
+}
+
+
+// Get the beacon head
+func (c *FallbackClient) GetBeaconHead() (BeaconHead, error) {
+    var beaconHead BeaconHead
+    err := c.run("GetBeaconHead", func(client Client) error {
+        var err error
+        beaconHead, err = client.GetBeaconHead()
+        return err
+    })
+    return beaconHead, err
+}
+
+
+// Get a validator's status
+func (c *FallbackClient) GetValidatorStatus(pubkey types.ValidatorPubkey, opts *ValidatorStatusOptions) (ValidatorStatus, error) {
+    var validatorStatus ValidatorStatus
+    err := c.run("GetValidatorStatus", func(client Client) error {
+        var err error
+        validatorStatus, err = client.GetValidatorStatus(pubkey, opts)
+        return err
+    })
+    return validatorStatus, err
+}
+
+
+// Get multiple validators' statuses
+func (c *FallbackClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error) {
+    var validatorStatuses map[types.ValidatorPubkey]ValidatorStatus
+    err := c.run("GetValidatorStatuses", func(client Client) error {
+        var err error
+        validatorStatuses, err = client.GetValidatorStatuses(pubkeys, opts)
+        return err
+    })
+    return validatorStatuses, err
+}
+
+
+// Get a validator's index
+func (c *FallbackClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (uint64, error) {
+    var validatorIndex uint64
+    err := c.run("GetValidatorIndex", func(client Client) error {
+        var err error
+        validatorIndex, err = client.GetValidatorIndex(pubkey)
+        return err
+    })
+    return validatorIndex, err
+}
+
+
+// Get domain data for a domain type at a given epoch
+func (c *FallbackClient) GetDomainData(domainType []byte, epoch uint64) ([]byte, error) {
+    var domainData []byte
+    err := c.run("GetDomainData", func(client Client) error {
+        var err error
+        domainData, err = client.GetDomainData(domainType, epoch)
+        return err
+    })
+    return domainData, err
+}
+
+
+// Perform a validator exit
+// Voluntary exits are idempotent, so it is safe to resubmit one to the fallback client
+func (c *FallbackClient) ExitValidator(validatorIndex, epoch uint64, signature types.ValidatorSignature) error {
+    return c.run("ExitValidator", func(client Client) error {
+        return client.ExitValidator(validatorIndex, epoch, signature)
+    })
+}
+
+
+// Run a request against the primary client, and against the fallback client if the primary could not be reached
+func (c *FallbackClient) run(method string, request func(client Client) error) error {
+    err := request(c.primary)
+    if err == nil || !isConnectionError(err) {
+        return err
+    }
+    log.Printf("Could not reach the primary beacon node for %s (%s), using the fallback beacon node.\n", method, err.Error())
+    atomic.StoreInt32(&c.usedFallback, 1)
+    return request(c.fallback)
+}
+
+
+// Check whether an error is a connection-level failure
+func isConnectionError(err error) bool {
+    var netErr net.Error
+    if errors.As(err, &netErr) {
+        return true
+    }
+    message := err.Error()
+    for _, fragment := range connectionErrorMessages {
+        if strings.Contains(message, fragment) {
+            return true
+        }
+    }
+    return false
+}
+
@@ -27,6 +27,8 @@ const (
     RequestContentType = "application/json"
 
     RequestSyncStatusPath = "/eth/v1/node/syncing"
+    RequestLighthouseSyncStatusPath = "/lighthouse/syncing"
+    RequestNodeVersionPath = "/eth/v1/node/version"
     RequestEth2ConfigPath = "/eth/v1/config/spec"
     RequestGenesisPath = "/eth/v1/beacon/genesis"
     RequestFinalityCheckpointsPath = "/eth/v1/beacon/states/%s/finality_checkpoints"
@@ -40,14 +42,20 @@ const (
 
 // Lighthouse client
 type Client struct {
+    protocol string
     providerAddress string
+    headers map[string]string
 }
 
 
 // Create new lighthouse client
-func NewClient(providerAddress string) *Client {
+// The headers are sent with every request, e.g. to authenticate with a hosted beacon node
+func NewClient(providerAddress string, headers map[string]string) *Client {
+    protocol, address := beacon.ParseProviderAddress(providerAddress, RequestProtocol)
     return &Client{
-        providerAddress: providerAddress,
+        protocol: protocol,
+        providerAddress: address,
+        headers: headers,
     }
 }
 
@@ -76,15 +84,39 @@ func (c *Client) GetSyncStatus() (beacon.SyncStatus, error) {
     // Calculate the progress
     progress := float64(syncStatus.Data.HeadSlot) / float64(syncStatus.Data.HeadSlot + syncStatus.Data.SyncDistance)
 
+    // Get backfill progress after a checkpoint sync; this is not available on all versions
+    backfilling := false
+    backfillProgress := float64(-1)
+    if backfillState, err := c.getBackfillSyncState(); err == nil && backfillState.BackFillSyncing != nil {
+        backfilling = true
+        total := backfillState.BackFillSyncing.Completed + backfillState.BackFillSyncing.Remaining
+        if total > 0 {
+            backfillProgress = float64(backfillState.BackFillSyncing.Completed) / float64(total)
+        }
+    }
+
     // Return response
     return beacon.SyncStatus{
         Syncing: syncStatus.Data.IsSyncing,
         Progress: progress,
+        SyncDistance: uint64(syncStatus.Data.SyncDistance),
+        Backfilling: backfilling,
+        BackfillProgress: backfillProgress,
     }, nil
 
 }
 
 
+// Get the node's client version
+func (c *Client) GetNodeVersion() (beacon.NodeVersion, error) {
+    nodeVersion, err := c.getNodeVersion()
+    if err != nil {
+        return beacon.NodeVersion{}, err
+    }
+    return beacon.ParseNodeVersion(nodeVersion.Data.Version), nil
+}
+
+
 // Get the eth2 config
 func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -322,6 +354,44 @@ func (c *Client) getSyncStatus() (SyncStatusResponse, error) {
 }
 
 
+// Get the node's backfill sync state
+func (c *Client) getBackfillSyncState() (LighthouseBackfillSyncState, error) {
+    responseBody, status, err := c.getRequest(RequestLighthouseSyncStatusPath)
+    if err != nil {
+        return LighthouseBackfillSyncState{}, fmt.Errorf("Could not get node backfill sync state: %w", err)
+    } else if status != http.StatusOK {
+        return LighthouseBackfillSyncState{}, fmt.Errorf("Could not get node backfill sync state: HTTP status %d; response body: '%s'", status, string(responseBody))
+    }
+    var syncStatus LighthouseSyncStatusResponse
+    if err := json.Unmarshal(responseBody, &syncStatus); err != nil {
+        return LighthouseBackfillSyncState{}, fmt.Errorf("Could not decode node backfill sync state: %w", err)
+    }
+
+    // The state is either a plain string (e.g. "Synced") or an object keyed by state name
+    var backfillState LighthouseBackfillSyncState
+    if err := json.Unmarshal(syncStatus.Data, &backfillState); err != nil {
+        return LighthouseBackfillSyncState{}, nil
+    }
+    return backfillState, nil
+}
+
+
+// Get the node's client version
+func (c *Client) getNodeVersion() (NodeVersionResponse, error) {
+    responseBody, status, err := c.getRequest(RequestNodeVersionPath)
+    if err != nil {
+        return NodeVersionResponse{}, fmt.Errorf("Could not get node version: %w", err)
+    } else if status != http.StatusOK {
+        return NodeVersionResponse{}, fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", status, string(responseBody))
+    }
+    var nodeVersion NodeVersionResponse
+    if err := json.Unmarshal(responseBody, &nodeVersion); err != nil {
+        return NodeVersionResponse{}, fmt.Errorf("Could not decode node version: %w", err)
+    }
+    return nodeVersion, nil
+}
+
+
 // Get the eth2 config
 func (c *Client) getEth2Config() (Eth2ConfigResponse, error) {
     responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -471,7 +541,11 @@ func (c *Client) postVoluntaryExit(request VoluntaryExitRequest) error {
 func (c *Client) getRequest(requestPath string) ([]byte, int, error) {
 
     // Send request
-    response, err := http.Get(fmt.Sprintf(RequestUrlFormat, RequestProtocol, c.providerAddress, requestPath))
+    request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.protocol, c.providerAddress, requestPath), nil)
+    if err != nil {
+        return []byte{}, 0, err
+    }
+    response, err := c.sendRequest(request)
     if err != nil {
         return []byte{}, 0, err
     }
@@ -500,7 +574,12 @@ func (c *Client) postRequest(requestPath string, requestBody interface{}) ([]byt
     requestBodyReader := bytes.NewReader(requestBodyBytes)
 
     // Send request
-    response, err := http.Post(fmt.Sprintf(RequestUrlFormat, RequestProtocol, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+    request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.protocol, c.providerAddress, requestPath), requestBodyReader)
+    if err != nil {
+        return []byte{}, 0, err
+    }
+    request.Header.Set("Content-Type", RequestContentType)
+    response, err := c.sendRequest(request)
     if err != nil {
         return []byte{}, 0, err
     }
@@ -517,3 +596,11 @@ func (c *Client) postRequest(requestPath string, requestBody interface{}) ([]byt
 
 }
 
+
+// Send a request to the beacon node with the configured headers
+func (c *Client) sendRequest(request *http.Request) (*http.Response, error) {
+    for name, value := range c.headers {
+        request.Header.Set(name, value)
+    }
+    return http.DefaultClient.Do(request)
+}
@@ -28,6 +28,20 @@ type SyncStatusResponse struct {
         SyncDistance uinteger               `json:"sync_distance"`
     }                                   `json:"data"`
 }
+type LighthouseSyncStatusResponse struct {
+    Data json.RawMessage                `json:"data"`
+}
+type LighthouseBackfillSyncState struct {
+    BackFillSyncing *struct {
+        Completed uint64                    `json:"completed"`
+        Remaining uint64                    `json:"remaining"`
+    }                                   `json:"BackFillSyncing"`
+}
+type NodeVersionResponse struct {
+    Data struct {
+        Version string                      `json:"version"`
+    }                                   `json:"data"`
+}
 type Eth2ConfigResponse struct {
     Data struct {
         SecondsPerSlot uinteger             `json:"SECONDS_PER_SLOT"`
@@ -19,6 +19,7 @@ import (
 // Config
 const (
     RequestSyncStatusMethod          = "get_v1_node_syncing"
+    RequestNodeVersionMethod         = "get_v1_node_version"
     RequestEth2ConfigMethod          = "get_v1_config_spec"
     RequestGenesisMethod             = "get_v1_beacon_genesis"
     RequestFinalityCheckpointsMethod = "get_v1_beacon_states_finality_checkpoints"
@@ -35,13 +36,18 @@ type Client struct {
 }
 
 // Create new Nimbus client
-func NewClient(providerAddress string) (*Client, error) {
+// The headers are sent with every request, e.g. to authenticate with a hosted beacon node
+func NewClient(providerAddress string, headers map[string]string) (*Client, error) {
 
     // Start the RPC connection
-    client, err := rpc.DialHTTP("http://" + providerAddress)
+    protocol, address := beacon.ParseProviderAddress(providerAddress, "http")
+    client, err := rpc.DialHTTP(protocol + "://" + address)
     if err != nil {
         return nil, fmt.Errorf("Could not connect to Nimbus RPC server: %s", err)
     }
+    for name, value := range headers {
+        client.SetHeader(name, value)
+    }
     return &Client{
         client: client,
     }, nil
@@ -74,10 +80,21 @@ func (c *Client) GetSyncStatus() (beacon.SyncStatus, error) {
     return beacon.SyncStatus{
         Syncing: syncStatus.IsSyncing,
         Progress: progress,
+        SyncDistance: uint64(syncStatus.SyncDistance),
+        BackfillProgress: -1,
     }, nil
 
 }
 
+// Get the node's client version
+func (c *Client) GetNodeVersion() (beacon.NodeVersion, error) {
+    nodeVersion, err := c.getNodeVersion()
+    if err != nil {
+        return beacon.NodeVersion{}, err
+    }
+    return beacon.ParseNodeVersion(nodeVersion.Version), nil
+}
+
 // Get the eth2 config
 func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -302,6 +319,16 @@ func (c *Client) getSyncStatus() (SyncStatusResponse, error) {
     return syncStatusResponse, nil
 }
 
+// Get the node's client version
+func (c *Client) getNodeVersion() (NodeVersionResponse, error) {
+    var nodeVersion NodeVersionResponse
+    if err := c.client.Call(&nodeVersion, RequestNodeVersionMethod); err != nil {
+        message := c.getErrorString(err)
+        return NodeVersionResponse{}, fmt.Errorf("Could not get node version: %s", message)
+    }
+    return nodeVersion, nil
+}
+
 // Get the eth2 config
 func (c *Client) getEth2Config() (Eth2ConfigResponse, error) {
     var eth2Config Eth2ConfigResponse
@@ -24,6 +24,9 @@ type SyncStatusResponse struct {
     HeadSlot uint64                     `json:"head_slot"`
     SyncDistance uint64                 `json:"sync_distance"`
 }
+type NodeVersionResponse struct {
+    Version string                      `json:"version"`
+}
 type Eth2ConfigResponse struct {
     SecondsPerSlot uinteger `json:"SECONDS_PER_SLOT"`
     SlotsPerEpoch  uinteger `json:"SLOTS_PER_EPOCH"`
@@ -2,6 +2,7 @@ package prysm
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -9,6 +10,7 @@ import (
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 )
@@ -23,10 +25,27 @@ type Client struct {
 
 
 // Create new prysm client
-func NewClient(providerAddress string) (*Client, error) {
+// The headers are sent as metadata with every call, e.g. to authenticate with a hosted beacon node
+// TLS is used for https:// addresses and whenever headers are set, so that credentials are never sent in cleartext
+func NewClient(providerAddress string, headers map[string]string) (*Client, error) {
+
+    // Get gRPC target & transport security
+    target, useTLS := getDialTarget(providerAddress)
+    if len(headers) > 0 {
+        useTLS = true
+    }
 
     // Initialize gRPC connection
-    conn, err := grpc.Dial(providerAddress, grpc.WithInsecure(), grpc.WithBlock())
+    options := []grpc.DialOption{grpc.WithBlock()}
+    if useTLS {
+        options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+    } else {
+        options = append(options, grpc.WithInsecure())
+    }
+    if len(headers) > 0 {
+        options = append(options, grpc.WithPerRPCCredentials(headerCredentials(headers)))
+    }
+    conn, err := grpc.Dial(target, options...)
     if err != nil {
         return nil, fmt.Errorf("Could not connect to gRPC server: %w", err)
     }
@@ -72,11 +91,22 @@ func (c *Client) GetSyncStatus() (beacon.SyncStatus, error) {
     return beacon.SyncStatus{
         Syncing: syncStatus.Syncing,
         Progress: -1, // Prysm doesn't support this yet
+        BackfillProgress: -1,
     }, nil
 
 }
 
 
+// Get the node's client version
+func (c *Client) GetNodeVersion() (beacon.NodeVersion, error) {
+    version, err := c.nc.GetVersion(context.Background(), &pbtypes.Empty{})
+    if err != nil {
+        return beacon.NodeVersion{}, fmt.Errorf("Could not get node version: %w", err)
+    }
+    return beacon.ParseNodeVersion(version.Version), nil
+}
+
+
 // Get the eth2 config
 func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 
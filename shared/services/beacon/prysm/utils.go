@@ -1,6 +1,7 @@
 package prysm
 
 import (
+    "context"
     "errors"
     "fmt"
     "regexp"
@@ -9,6 +10,34 @@ import (
 )
 
 
+// gRPC credentials which attach static headers to every call
+type headerCredentials map[string]string
+func (h headerCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+    metadata := map[string]string{}
+    for name, value := range h {
+        metadata[strings.ToLower(name)] = value
+    }
+    return metadata, nil
+}
+func (h headerCredentials) RequireTransportSecurity() bool {
+    return true
+}
+
+
+// Get the gRPC dial target for a provider address, and whether it requires TLS
+// https:// addresses default to port 443; http:// prefixes are stripped
+func getDialTarget(providerAddress string) (string, bool) {
+    if strings.HasPrefix(providerAddress, "https://") {
+        target := strings.TrimSuffix(strings.TrimPrefix(providerAddress, "https://"), "/")
+        if !strings.Contains(target, ":") {
+            target += ":443"
+        }
+        return target, true
+    }
+    return strings.TrimSuffix(strings.TrimPrefix(providerAddress, "http://"), "/"), false
+}
+
+
 // Get a byte slice value from a config map
 func getConfigBytes(cfg map[string]string, key string) ([]byte, error) {
     valueStr, err := getConfigString(cfg, key)
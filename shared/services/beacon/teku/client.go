@@ -27,6 +27,7 @@ const (
     RequestContentType = "application/json"
 
     RequestSyncStatusPath          = "/eth/v1/node/syncing"
+    RequestNodeVersionPath         = "/eth/v1/node/version"
     RequestEth2ConfigPath          = "/eth/v1/config/spec"
     RequestGenesisPath             = "/eth/v1/beacon/genesis"
     RequestFinalityCheckpointsPath = "/eth/v1/beacon/states/%s/finality_checkpoints"
@@ -39,13 +40,19 @@ const (
 
 // Teku client
 type Client struct {
+    protocol string
     providerAddress string
+    headers map[string]string
 }
 
 // Create new Teku client
-func NewClient(providerAddress string) *Client {
+// The headers are sent with every request, e.g. to authenticate with a hosted beacon node
+func NewClient(providerAddress string, headers map[string]string) *Client {
+    protocol, address := beacon.ParseProviderAddress(providerAddress, RequestProtocol)
     return &Client{
-        providerAddress: providerAddress,
+        protocol: protocol,
+        providerAddress: address,
+        headers: headers,
     }
 }
 
@@ -76,10 +83,21 @@ func (c *Client) GetSyncStatus() (beacon.SyncStatus, error) {
     return beacon.SyncStatus{
         Syncing: isSyncing,
         Progress: progress,
+        SyncDistance: uint64(syncStatus.Data.SyncDistance),
+        BackfillProgress: -1,
     }, nil
 
 }
 
+// Get the node's client version
+func (c *Client) GetNodeVersion() (beacon.NodeVersion, error) {
+    nodeVersion, err := c.getNodeVersion()
+    if err != nil {
+        return beacon.NodeVersion{}, err
+    }
+    return beacon.ParseNodeVersion(nodeVersion.Data.Version), nil
+}
+
 // Get the eth2 config
 func (c *Client) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -333,6 +351,21 @@ func (c *Client) getSyncStatus() (SyncStatusResponse, error) {
     return syncStatus, nil
 }
 
+// Get the node's client version
+func (c *Client) getNodeVersion() (NodeVersionResponse, error) {
+    responseBody, status, err := c.getRequest(RequestNodeVersionPath)
+    if err != nil {
+        return NodeVersionResponse{}, fmt.Errorf("Could not get node version: %w", err)
+    } else if status != http.StatusOK {
+        return NodeVersionResponse{}, fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", status, string(responseBody))
+    }
+    var nodeVersion NodeVersionResponse
+    if err := json.Unmarshal(responseBody, &nodeVersion); err != nil {
+        return NodeVersionResponse{}, fmt.Errorf("Could not decode node version: %w", err)
+    }
+    return nodeVersion, nil
+}
+
 // Get the eth2 config
 func (c *Client) getEth2Config() (Eth2ConfigResponse, error) {
     responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -475,7 +508,11 @@ func (c *Client) postVoluntaryExit(request VoluntaryExitRequest) error {
 func (c *Client) getRequest(requestPath string) ([]byte, int, error) {
 
     // Send request
-    response, err := http.Get(fmt.Sprintf(RequestUrlFormat, RequestProtocol, c.providerAddress, requestPath))
+    request, err := http.NewRequest(http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.protocol, c.providerAddress, requestPath), nil)
+    if err != nil {
+        return []byte{}, 0, err
+    }
+    response, err := c.sendRequest(request)
     if err != nil {
         return []byte{}, 0, err
     }
@@ -503,7 +540,12 @@ func (c *Client) postRequest(requestPath string, requestBody interface{}) ([]byt
     requestBodyReader := bytes.NewReader(requestBodyBytes)
 
     // Send request
-    response, err := http.Post(fmt.Sprintf(RequestUrlFormat, RequestProtocol, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+    request, err := http.NewRequest(http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.protocol, c.providerAddress, requestPath), requestBodyReader)
+    if err != nil {
+        return []byte{}, 0, err
+    }
+    request.Header.Set("Content-Type", RequestContentType)
+    response, err := c.sendRequest(request)
     if err != nil {
         return []byte{}, 0, err
     }
@@ -519,3 +561,11 @@ func (c *Client) postRequest(requestPath string, requestBody interface{}) ([]byt
     return body, response.StatusCode, nil
 
 }
+
+// Send a request to the beacon node with the configured headers
+func (c *Client) sendRequest(request *http.Request) (*http.Response, error) {
+    for name, value := range c.headers {
+        request.Header.Set(name, value)
+    }
+    return http.DefaultClient.Do(request)
+}
@@ -3,9 +3,14 @@ package config
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/imdario/mergo"
 	"github.com/urfave/cli"
@@ -14,17 +19,44 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 )
 
+// The largest gas price without a unit which is interpreted as gwei
+const MaxGweiGasPrice = 1000000
+
+// The maximum validator graffiti length in bytes
+const MaxGraffitiLength = 32
+
+// The default interval at which node metrics are refreshed
+const DefaultMetricsInterval = time.Minute
+
+// The default interval at which the node daemon runs its tasks & checks for events
+const DefaultPollInterval = 5 * time.Minute
+
+// A reference to a secret in a provider setting, e.g. ${INFURA_KEY}
+var secretReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// A pinned image digest, e.g. sha256:<64 hex characters>
+var imageDigestRegex = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// A docker compose project name, e.g. rocketpool
+var projectNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+
 // Rocket Pool config
 type RocketPoolConfig struct {
     Rocketpool struct {
         StorageAddress string           `yaml:"storageAddress,omitempty"`
         OneInchOracleAddress string     `yaml:"oneInchOracleAddress,omitempty"`
         RplTokenAddress string          `yaml:"rplTokenAddress,omitempty"`
+        FixedSupplyRplTokenAddress string `yaml:"fixedSupplyRplTokenAddress,omitempty"`
+        DeployBlock uint64              `yaml:"deployBlock,omitempty"`
+        PreviousContractAddresses map[string][]string `yaml:"previousContractAddresses,omitempty"`
     }                                   `yaml:"rocketpool,omitempty"`
     Smartnode struct {
         ProjectName string              `yaml:"projectName,omitempty"`
         GraffitiVersion string          `yaml:"graffitiVersion,omitempty"`
+        Graffiti string                 `yaml:"graffiti,omitempty"`
         Image string                    `yaml:"image,omitempty"`
+        ImageDigests map[string]string  `yaml:"imageDigests,omitempty"`
         PasswordPath string             `yaml:"passwordPath,omitempty"`
         WalletPath string               `yaml:"walletPath,omitempty"`
         ValidatorKeychainPath string    `yaml:"validatorKeychainPath,omitempty"`
@@ -33,6 +65,19 @@ type RocketPoolConfig struct {
         GasLimit string                 `yaml:"gasLimit,omitempty"`
         RplClaimGasThreshold string     `yaml:"rplClaimGasThreshold,omitempty"`
         TxWatchUrl string               `yaml:"txWatchUrl,omitempty"`
+        WebhookUrl string               `yaml:"webhookUrl,omitempty"`
+        WebhookSecret string            `yaml:"webhookSecret,omitempty"`
+        DiscordWebhookUrl string        `yaml:"discordWebhookUrl,omitempty"`
+        TelegramBotToken string         `yaml:"telegramBotToken,omitempty"`
+        TelegramChatId string           `yaml:"telegramChatId,omitempty"`
+        AddressBook map[string]string   `yaml:"addressBook,omitempty"`
+        ExtraComposeFiles []string      `yaml:"extraComposeFiles,omitempty"`
+        MetricsPort uint16              `yaml:"metricsPort,omitempty"`
+        MetricsInterval string          `yaml:"metricsInterval,omitempty"`
+        WalletAutoLockTimeout string    `yaml:"walletAutoLockTimeout,omitempty"`
+        SecretsFile string              `yaml:"secretsFile,omitempty"`
+        PollInterval string             `yaml:"pollInterval,omitempty"`
+        PollJitter string               `yaml:"pollJitter,omitempty"`
     }                                   `yaml:"smartnode,omitempty"`
     Chains struct {
         Eth1 Chain                      `yaml:"eth1,omitempty"`
@@ -41,12 +86,16 @@ type RocketPoolConfig struct {
 }
 type Chain struct {
     Provider string                     `yaml:"provider,omitempty"`
+    FallbackProvider string             `yaml:"fallbackProvider,omitempty"`
+    ProviderHeaders map[string]string   `yaml:"providerHeaders,omitempty"`
+    ProviderToken string                `yaml:"providerToken,omitempty"`
     WsProvider string                   `yaml:"wsProvider,omitempty"`
     MainnetProvider string              `yaml:"mainnetProvider,omitempty"`
     ChainID string                      `yaml:"chainID,omitempty"`
     Client struct {
         Options []ClientOption          `yaml:"options,omitempty"`
         Selected string                 `yaml:"selected,omitempty"`
+        SelectedValidator string        `yaml:"selectedValidator,omitempty"`
         Params []UserParam              `yaml:"params,omitempty"`
     }                                   `yaml:"client,omitempty"`
 }
@@ -59,6 +108,7 @@ type ClientOption struct {
     ValidatorImage string               `yaml:"validatorImage,omitempty"`
     Link string                         `yaml:"link,omitempty"`
     CompatibleEth2Clients string        `yaml:"compatibleEth2Clients"`
+    CompatibleValidatorClients string   `yaml:"compatibleValidatorClients,omitempty"`
     Params []ClientParam                `yaml:"params,omitempty"`
 }
 type ClientParam struct {
@@ -85,6 +135,34 @@ func (config *RocketPoolConfig) GetSelectedEth1Client() *ClientOption {
 func (config *RocketPoolConfig) GetSelectedEth2Client() *ClientOption {
     return config.Chains.Eth2.GetSelectedClient()
 }
+func (config *RocketPoolConfig) GetSelectedValidatorClient() *ClientOption {
+    return config.Chains.Eth2.GetSelectedValidatorClient()
+}
+
+
+// Get the name of the service running the validator client
+// Single process eth2 clients run their validator in the eth2 service
+func (config *RocketPoolConfig) GetValidatorServiceName() string {
+    if config.Chains.Eth2.Client.Selected == "nimbus" {
+        return "eth2"
+    }
+    return "validator"
+}
+
+// Get the headers to send with every request to the chain's primary provider
+// A provider token is sent as a bearer token in the Authorization header
+func (chain *Chain) GetProviderHeaders() map[string]string {
+    headers := map[string]string{}
+    for name, value := range chain.ProviderHeaders {
+        headers[name] = value
+    }
+    if chain.ProviderToken != "" {
+        headers["Authorization"] = fmt.Sprintf("Bearer %s", chain.ProviderToken)
+    }
+    return headers
+}
+
+
 func (chain *Chain) GetSelectedClient() *ClientOption {
     for _, option := range chain.Client.Options {
         if option.ID == chain.Client.Selected {
@@ -94,6 +172,36 @@ func (chain *Chain) GetSelectedClient() *ClientOption {
     return nil
 }
 
+// Get the selected validator client, which defaults to the selected client
+func (chain *Chain) GetSelectedValidatorClient() *ClientOption {
+    if chain.Client.SelectedValidator == "" {
+        return chain.GetSelectedClient()
+    }
+    for _, option := range chain.Client.Options {
+        if option.ID == chain.Client.SelectedValidator {
+            return &option
+        }
+    }
+    return nil
+}
+
+
+// Check whether a validator client can be run against this beacon client
+func (option *ClientOption) SupportsValidatorClient(validatorClientId string) bool {
+    if validatorClientId == option.ID {
+        return true
+    }
+    if option.CompatibleValidatorClients == "" {
+        return false
+    }
+    for _, id := range strings.Split(option.CompatibleValidatorClients, ";") {
+        if id == validatorClientId {
+            return true
+        }
+    }
+    return false
+}
+
 
 // Get the beacon & validator images for a client
 func (client *ClientOption) GetBeaconImage() string {
@@ -112,6 +220,93 @@ func (client *ClientOption) GetValidatorImage() string {
 }
 
 
+// Pin an image to a digest if one is set
+func PinImage(image, digest string) string {
+    if digest == "" {
+        return image
+    }
+    return fmt.Sprintf("%s@%s", image, digest)
+}
+
+
+// Get an image pinned to its digest in the config, if one is set
+// Digests are keyed by the full image name, so a digest only applies to the image it was taken from
+func (config *RocketPoolConfig) GetPinnedImage(image string) string {
+    return PinImage(image, config.Smartnode.ImageDigests[image])
+}
+
+
+// Check that the image digests in the config are valid
+func (config *RocketPoolConfig) ValidateImageDigests() error {
+    for image, digest := range config.Smartnode.ImageDigests {
+        if err := ValidateImageDigest(digest); err != nil {
+            return fmt.Errorf("Invalid digest for image %s: %w", image, err)
+        }
+    }
+    return nil
+}
+
+
+// Check that an image digest is a sha256 digest
+func ValidateImageDigest(digest string) error {
+    if !imageDigestRegex.MatchString(digest) {
+        return fmt.Errorf("'%s' is not in the format 'sha256:<64 lowercase hex characters>'", digest)
+    }
+    return nil
+}
+
+
+// Check that a docker compose project name is valid, so it is safe to use in container names & commands
+func ValidateProjectName(name string) error {
+    if !projectNameRegex.MatchString(name) {
+        return fmt.Errorf("Invalid project name '%s' - it must start with a lowercase letter or number and contain only lowercase letters, numbers, '_' and '-'", name)
+    }
+    return nil
+}
+
+
+// Parse a chain ID as a positive decimal or 0x-prefixed hex number
+func ParseChainID(chainID string) (*big.Int, error) {
+    value, base := chainID, 10
+    if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+        value, base = value[2:], 16
+    }
+    id, ok := new(big.Int).SetString(value, base)
+    if !ok || id.Sign() <= 0 {
+        return nil, fmt.Errorf("Invalid chain ID '%s' - it must be a positive decimal or 0x-prefixed hex number", chainID)
+    }
+    return id, nil
+}
+
+
+// Get the validator graffiti from custom text and the Rocket Pool version
+// Returns an error if the graffiti would be truncated
+func (config *RocketPoolConfig) GetGraffiti() (string, error) {
+    return GetGraffiti(config.Smartnode.Graffiti, config.Smartnode.GraffitiVersion)
+}
+func GetGraffiti(customGraffiti, version string) (string, error) {
+    graffiti := fmt.Sprintf("RP %s", version)
+    if customGraffiti != "" {
+        graffiti = fmt.Sprintf("%s (%s)", customGraffiti, graffiti)
+    }
+    if len(graffiti) > MaxGraffitiLength {
+        return "", fmt.Errorf("Graffiti '%s' is %d bytes long, which exceeds the maximum of %d bytes; custom graffiti can be at most %d bytes long.", graffiti, len(graffiti), MaxGraffitiLength, MaxGraffitiLength - (len(graffiti) - len(customGraffiti)))
+    }
+    return graffiti, nil
+}
+
+
+// Get the address book label for an address, or an empty string if it has none
+func (config *RocketPoolConfig) GetAddressLabel(address string) string {
+    for labelAddress, label := range config.Smartnode.AddressBook {
+        if strings.EqualFold(labelAddress, address) {
+            return label
+        }
+    }
+    return ""
+}
+
+
 // Serialize a config to yaml bytes
 func (config *RocketPoolConfig) Serialize() ([]byte, error) {
     bytes, err := yaml.Marshal(config)
@@ -194,8 +389,86 @@ func Load(c *cli.Context) (RocketPoolConfig, error) {
     }
     cliConfig := getCliConfig(c)
 
-    // Merge and return
-    return Merge(&globalConfig, &userConfig, &cliConfig)
+    // Merge configs
+    config, err := Merge(&globalConfig, &userConfig, &cliConfig)
+    if err != nil {
+        return RocketPoolConfig{}, err
+    }
+
+    // Resolve provider secrets and return
+    if err := config.ResolveSecrets(filepath.Dir(os.ExpandEnv(c.GlobalString("settings")))); err != nil {
+        return RocketPoolConfig{}, err
+    }
+    return config, nil
+
+}
+
+
+// Check whether a setting references a secret (e.g. ${INFURA_KEY}) rather than holding it inline
+func HasSecretReference(value string) bool {
+    return secretReferenceRegex.MatchString(value)
+}
+
+
+// Replace secret references (e.g. ${INFURA_KEY}) in the provider settings with their values
+// Secrets are read from the secrets file, resolved relative to baseDir, falling back to environment variables
+func (config *RocketPoolConfig) ResolveSecrets(baseDir string) error {
+    _, err := config.ResolveSecretValues(baseDir)
+    return err
+}
+
+
+// Replace secret references in the provider settings with their values, and return the values of the referenced secrets
+func (config *RocketPoolConfig) ResolveSecretValues(baseDir string) ([]string, error) {
+
+    // Load secrets file
+    secrets := map[string]string{}
+    if config.Smartnode.SecretsFile != "" {
+        path := os.ExpandEnv(config.Smartnode.SecretsFile)
+        if !filepath.IsAbs(path) {
+            path = filepath.Join(baseDir, path)
+        }
+        bytes, err := ioutil.ReadFile(path)
+        if err != nil {
+            return nil, fmt.Errorf("Could not read secrets file at %s: %w", path, err)
+        }
+        if err := yaml.Unmarshal(bytes, &secrets); err != nil {
+            return nil, fmt.Errorf("Could not parse secrets file at %s: %w", path, err)
+        }
+    }
+
+    // Resolve references
+    settings := []*string{
+        &config.Chains.Eth1.Provider,
+        &config.Chains.Eth1.WsProvider,
+        &config.Chains.Eth1.FallbackProvider,
+        &config.Chains.Eth1.MainnetProvider,
+        &config.Chains.Eth1.ProviderToken,
+        &config.Chains.Eth2.Provider,
+        &config.Chains.Eth2.FallbackProvider,
+        &config.Chains.Eth2.ProviderToken,
+    }
+    values := []string{}
+    for _, setting := range settings {
+        var resolveErr error
+        *setting = secretReferenceRegex.ReplaceAllStringFunc(*setting, func(reference string) string {
+            name := secretReferenceRegex.FindStringSubmatch(reference)[1]
+            if value, ok := secrets[name]; ok {
+                values = append(values, value)
+                return value
+            }
+            if value, ok := os.LookupEnv(name); ok {
+                values = append(values, value)
+                return value
+            }
+            resolveErr = fmt.Errorf("Secret '%s' referenced by a provider setting was not found in the secrets file or the environment", name)
+            return reference
+        })
+        if resolveErr != nil {
+            return nil, resolveErr
+        }
+    }
+    return values, nil
 
 }
 
@@ -213,6 +486,14 @@ func loadFile(path string, required bool) (RocketPoolConfig, error) {
         }
     }
 
+    // Convert json & toml configs to yaml
+    if format := DetectFormat(path); format != FormatYAML {
+        bytes, err = toYaml(bytes, format)
+        if err != nil {
+            return RocketPoolConfig{}, fmt.Errorf("Could not parse config file at %s: %w", path, err)
+        }
+    }
+
     // Parse config
     var config RocketPoolConfig
     if err := yaml.Unmarshal(bytes, &config); err != nil {
@@ -236,8 +517,11 @@ func getCliConfig(c *cli.Context) RocketPoolConfig {
     config.Smartnode.ValidatorKeychainPath = c.GlobalString("validatorKeychain")
     config.Smartnode.GasPrice = c.GlobalString("gasPrice")
     config.Smartnode.GasLimit = c.GlobalString("gasLimit")
+    config.Smartnode.WebhookUrl = c.GlobalString("webhookUrl")
     config.Chains.Eth1.Provider = c.GlobalString("eth1Provider")
+    config.Chains.Eth1.ChainID = c.GlobalString("chainId")
     config.Chains.Eth2.Provider = c.GlobalString("eth2Provider")
+    config.Chains.Eth2.FallbackProvider = c.GlobalString("eth2FallbackProvider")
     return config
 }
 
@@ -250,18 +534,56 @@ func (config *RocketPoolConfig) GetGasPrice() (*big.Int, error) {
         return nil, nil
     }
 
-    // Parse gas price in gwei
-    gasPriceGwei, err := strconv.ParseFloat(config.Smartnode.GasPrice, 64)
+    // Parse gas price
+    gasPrice, err := ParseGasPrice(config.Smartnode.GasPrice)
     if err != nil {
-        return nil, fmt.Errorf("Invalid gas price '%s': %w", config.Smartnode.GasPrice, err)
+        return nil, err
     }
 
     // Return nil if gas price is set to zero
-    if gasPriceGwei == 0 {
+    if gasPrice.Sign() == 0 {
         return nil, nil
     }
 
     // Return gas price in wei
+    return gasPrice, nil
+
+}
+
+
+// Parse a gas price into wei
+// Values may have a "gwei" or "wei" unit suffix; values without a unit are interpreted as gwei,
+// unless they are whole numbers greater than MaxGweiGasPrice, which are interpreted as wei
+func ParseGasPrice(value string) (*big.Int, error) {
+    trimmed := strings.ToLower(strings.TrimSpace(value))
+
+    // Parse gas price in wei
+    if strings.HasSuffix(trimmed, "wei") && !strings.HasSuffix(trimmed, "gwei") {
+        gasPriceWei, ok := new(big.Int).SetString(strings.TrimSpace(strings.TrimSuffix(trimmed, "wei")), 10)
+        if !ok || gasPriceWei.Sign() < 0 {
+            return nil, fmt.Errorf("Invalid gas price '%s': wei values must be non-negative whole numbers", value)
+        }
+        return gasPriceWei, nil
+    }
+
+    // Parse gas price without a unit in wei if it's too large to be a gwei value
+    if !strings.HasSuffix(trimmed, "gwei") {
+        if gasPriceWei, ok := new(big.Int).SetString(trimmed, 10); ok && gasPriceWei.Cmp(big.NewInt(MaxGweiGasPrice)) > 0 {
+            return gasPriceWei, nil
+        }
+    }
+
+    // Parse gas price in gwei
+    gasPriceGwei, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(trimmed, "gwei")), 64)
+    if err != nil {
+        return nil, fmt.Errorf("Invalid gas price '%s' - expected a value in gwei (e.g. '30' or '30gwei') or wei (e.g. '30000000000wei'): %w", value, err)
+    }
+    if math.IsNaN(gasPriceGwei) || math.IsInf(gasPriceGwei, 0) {
+        return nil, fmt.Errorf("Invalid gas price '%s': gas price must be a finite number", value)
+    }
+    if gasPriceGwei < 0 {
+        return nil, fmt.Errorf("Invalid gas price '%s': gas price cannot be negative", value)
+    }
     return eth.GweiToWei(gasPriceGwei), nil
 
 }
@@ -286,3 +608,84 @@ func (config *RocketPoolConfig) GetGasLimit() (uint64, error) {
 
 }
 
+
+// Parse and return the node metrics refresh interval
+func (config *RocketPoolConfig) GetMetricsInterval() (time.Duration, error) {
+
+    // No interval specified
+    if config.Smartnode.MetricsInterval == "" {
+        return DefaultMetricsInterval, nil
+    }
+
+    // Parse interval
+    interval, err := time.ParseDuration(config.Smartnode.MetricsInterval)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid metrics interval '%s': %w", config.Smartnode.MetricsInterval, err)
+    }
+    if interval <= 0 {
+        return 0, fmt.Errorf("Invalid metrics interval '%s': must be positive", config.Smartnode.MetricsInterval)
+    }
+
+    // Return
+    return interval, nil
+
+}
+
+
+// Parse and return the node daemon poll interval & jitter
+func (config *RocketPoolConfig) GetPollInterval() (time.Duration, time.Duration, error) {
+
+    // Parse interval
+    interval := DefaultPollInterval
+    if config.Smartnode.PollInterval != "" {
+        var err error
+        interval, err = time.ParseDuration(config.Smartnode.PollInterval)
+        if err != nil {
+            return 0, 0, fmt.Errorf("Invalid poll interval '%s': %w", config.Smartnode.PollInterval, err)
+        }
+        if interval <= 0 {
+            return 0, 0, fmt.Errorf("Invalid poll interval '%s': must be positive", config.Smartnode.PollInterval)
+        }
+    }
+
+    // Parse jitter
+    var jitter time.Duration
+    if config.Smartnode.PollJitter != "" {
+        var err error
+        jitter, err = time.ParseDuration(config.Smartnode.PollJitter)
+        if err != nil {
+            return 0, 0, fmt.Errorf("Invalid poll jitter '%s': %w", config.Smartnode.PollJitter, err)
+        }
+        if jitter < 0 || jitter >= interval {
+            return 0, 0, fmt.Errorf("Invalid poll jitter '%s': must not be negative, and must be less than the poll interval", config.Smartnode.PollJitter)
+        }
+    }
+
+    // Return
+    return interval, jitter, nil
+
+}
+
+
+// Parse and return the wallet auto-lock timeout
+func (config *RocketPoolConfig) GetWalletAutoLockTimeout() (time.Duration, error) {
+
+    // No timeout specified
+    if config.Smartnode.WalletAutoLockTimeout == "" {
+        return 0, nil
+    }
+
+    // Parse timeout
+    timeout, err := time.ParseDuration(config.Smartnode.WalletAutoLockTimeout)
+    if err != nil {
+        return 0, fmt.Errorf("Invalid wallet auto-lock timeout '%s': %w", config.Smartnode.WalletAutoLockTimeout, err)
+    }
+    if timeout < 0 {
+        return 0, fmt.Errorf("Invalid wallet auto-lock timeout '%s': must not be negative", config.Smartnode.WalletAutoLockTimeout)
+    }
+
+    // Return
+    return timeout, nil
+
+}
+
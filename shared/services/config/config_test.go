@@ -0,0 +1,145 @@
+package config
+
+import (
+    "math/big"
+    "strings"
+    "testing"
+)
+
+
+func TestGetPinnedImage(t *testing.T) {
+    digest := "sha256:" + strings.Repeat("ab", 32)
+    var config RocketPoolConfig
+    config.Smartnode.ImageDigests = map[string]string{"ethereum/client-go:v1.10.3": digest}
+
+    // Digests only apply to the image they are keyed by
+    for image, expected := range map[string]string{
+        "ethereum/client-go:v1.10.3": "ethereum/client-go:v1.10.3@" + digest,
+        "ethereum/client-go:v1.10.4": "ethereum/client-go:v1.10.4",
+        "hyperledger/besu:21.1.6": "hyperledger/besu:21.1.6",
+    } {
+        if pinnedImage := config.GetPinnedImage(image); pinnedImage != expected {
+            t.Errorf("Expected pinned image %s for %s, got %s", expected, image, pinnedImage)
+        }
+    }
+}
+
+
+func TestValidateImageDigest(t *testing.T) {
+    for digest, valid := range map[string]bool{
+        "sha256:" + strings.Repeat("0123456789abcdef", 4): true,
+        "sha256:" + strings.Repeat("0123456789ABCDEF", 4): false,
+        "sha256:" + strings.Repeat("ab", 31): false,
+        "sha256:" + strings.Repeat("ab", 33): false,
+        "sha512:" + strings.Repeat("ab", 32): false,
+        strings.Repeat("ab", 32): false,
+        "sha256:" + strings.Repeat("ab", 31) + "zz": false,
+        "": false,
+    } {
+        if err := ValidateImageDigest(digest); (err == nil) != valid {
+            t.Errorf("Expected digest %q to be valid: %t, got error %v", digest, valid, err)
+        }
+    }
+
+    // Every configured digest is checked
+    var config RocketPoolConfig
+    config.Smartnode.ImageDigests = map[string]string{
+        "rocketpool/smartnode:v1.0.0-rc1": "sha256:" + strings.Repeat("ab", 32),
+    }
+    if err := config.ValidateImageDigests(); err != nil {
+        t.Errorf("Could not validate image digests: %s", err)
+    }
+    config.Smartnode.ImageDigests["ethereum/client-go:v1.10.3"] = "latest"
+    if err := config.ValidateImageDigests(); err == nil {
+        t.Error("Validated an invalid image digest")
+    }
+}
+
+
+func TestValidateProjectName(t *testing.T) {
+    for name, valid := range map[string]bool{
+        "rocketpool": true,
+        "rocketpool2": true,
+        "rp_node-1": true,
+        "1node": true,
+        "": false,
+        "RocketPool": false,
+        "-rocketpool": false,
+        "_rocketpool": false,
+        "rocket pool": false,
+        "rocketpool;rm -rf ~": false,
+        "rocketpool$(id)": false,
+        "rocket.pool": false,
+    } {
+        if err := ValidateProjectName(name); (err == nil) != valid {
+            t.Errorf("Expected project name %q to be valid: %t, got error %v", name, valid, err)
+        }
+    }
+}
+
+
+func TestParseChainID(t *testing.T) {
+    for chainID, expected := range map[string]int64{
+        "1": 1,
+        "5": 5,
+        "0x5": 5,
+        "0X1a4": 420,
+        "1337": 1337,
+        "": 0,
+        "0": 0,
+        "0x": 0,
+        "-1": 0,
+        "0x-1": 0,
+        "5a": 0,
+        "0o7": 0,
+    } {
+        id, err := ParseChainID(chainID)
+        if expected == 0 {
+            if err == nil {
+                t.Errorf("Parsed invalid chain ID %q as %s", chainID, id)
+            }
+        } else if err != nil {
+            t.Errorf("Could not parse chain ID %q: %s", chainID, err)
+        } else if id.Int64() != expected {
+            t.Errorf("Expected chain ID %d for %q, got %s", expected, chainID, id)
+        }
+    }
+}
+
+
+func TestParseGasPrice(t *testing.T) {
+    for value, expected := range map[string]string{
+        "30": "30000000000",
+        "30gwei": "30000000000",
+        " 1.5 GWEI ": "1500000000",
+        "30000000000wei": "30000000000",
+        "30000000000": "30000000000",
+        "0": "0",
+        "": "",
+        "-1": "",
+        "-1wei": "",
+        "1.5wei": "",
+        "thirty": "",
+        "nan": "",
+        "NaN": "",
+        "inf": "",
+        "+inf": "",
+        "-Inf": "",
+        "infinity": "",
+        "infgwei": "",
+    } {
+        gasPrice, err := ParseGasPrice(value)
+        if expected == "" {
+            if err == nil {
+                t.Errorf("Parsed invalid gas price %q as %s wei", value, gasPrice)
+            }
+            continue
+        }
+        expectedWei, _ := new(big.Int).SetString(expected, 10)
+        if err != nil {
+            t.Errorf("Could not parse gas price %q: %s", value, err)
+        } else if gasPrice.Cmp(expectedWei) != 0 {
+            t.Errorf("Expected gas price %s wei for %q, got %s", expected, value, gasPrice)
+        }
+    }
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+// Config file formats
+type ConfigFormat string
+const (
+    FormatYAML ConfigFormat = "yaml"
+    FormatJSON ConfigFormat = "json"
+    FormatTOML ConfigFormat = "toml"
+)
+
+
+// Parse a config format name
+func ParseFormat(name string) (ConfigFormat, error) {
+    switch strings.ToLower(name) {
+        case "yaml", "yml": return FormatYAML, nil
+        case "json": return FormatJSON, nil
+        case "toml": return FormatTOML, nil
+    }
+    return "", fmt.Errorf("Invalid config format '%s' - valid formats are 'yaml', 'json' and 'toml'", name)
+}
+
+
+// Get the format of a config file by its extension; files without a json or toml extension are yaml
+func DetectFormat(path string) ConfigFormat {
+    switch strings.ToLower(filepath.Ext(path)) {
+        case ".json": return FormatJSON
+        case ".toml": return FormatTOML
+    }
+    return FormatYAML
+}
+
+
+// Serialize a config in a format
+// Configs are always serialized via yaml, so all formats use the same field names & omit the same empty fields
+func (config *RocketPoolConfig) SerializeAs(format ConfigFormat) ([]byte, error) {
+    yamlBytes, err := config.Serialize()
+    if err != nil {
+        return []byte{}, err
+    }
+    if format == FormatYAML {
+        return yamlBytes, nil
+    }
+    var value interface{}
+    if err := yaml.Unmarshal(yamlBytes, &value); err != nil {
+        return []byte{}, fmt.Errorf("Could not serialize config: %w", err)
+    }
+    if format == FormatTOML {
+        var tomlBytes bytes.Buffer
+        if err := toml.NewEncoder(&tomlBytes).Encode(yamlToJsonValue(value)); err != nil {
+            return []byte{}, fmt.Errorf("Could not serialize config: %w", err)
+        }
+        return tomlBytes.Bytes(), nil
+    }
+    jsonBytes, err := json.MarshalIndent(yamlToJsonValue(value), "", "    ")
+    if err != nil {
+        return []byte{}, fmt.Errorf("Could not serialize config: %w", err)
+    }
+    return append(jsonBytes, '\n'), nil
+}
+
+
+// Parse a config from bytes in a format
+func ParseAs(content []byte, format ConfigFormat) (RocketPoolConfig, error) {
+    yamlBytes, err := toYaml(content, format)
+    if err != nil {
+        return RocketPoolConfig{}, fmt.Errorf("Could not parse config: %w", err)
+    }
+    return Parse(yamlBytes)
+}
+
+
+// Convert config bytes in a format to yaml bytes
+func toYaml(content []byte, format ConfigFormat) ([]byte, error) {
+    switch format {
+        case FormatJSON:
+            return jsonToYaml(content)
+        case FormatTOML:
+            var value map[string]interface{}
+            if _, err := toml.Decode(string(content), &value); err != nil {
+                return []byte{}, err
+            }
+            return yaml.Marshal(value)
+    }
+    return content, nil
+}
+
+
+// Convert json bytes to yaml bytes, so they can be decoded using the config's yaml field names
+func jsonToYaml(content []byte) ([]byte, error) {
+    decoder := json.NewDecoder(bytes.NewReader(content))
+    decoder.UseNumber()
+    var value interface{}
+    if err := decoder.Decode(&value); err != nil {
+        return []byte{}, err
+    }
+    return yaml.Marshal(jsonToYamlValue(value))
+}
+
+
+// Convert decoded yaml values to values which can be encoded as json
+func yamlToJsonValue(value interface{}) interface{} {
+    switch v := value.(type) {
+        case map[interface{}]interface{}:
+            converted := map[string]interface{}{}
+            for key, item := range v {
+                converted[fmt.Sprint(key)] = yamlToJsonValue(item)
+            }
+            return converted
+        case []interface{}:
+            for i, item := range v {
+                v[i] = yamlToJsonValue(item)
+            }
+            return v
+    }
+    return value
+}
+
+
+// Convert decoded json values to values which are encoded as yaml scalars of the same type
+func jsonToYamlValue(value interface{}) interface{} {
+    switch v := value.(type) {
+        case map[string]interface{}:
+            for key, item := range v {
+                v[key] = jsonToYamlValue(item)
+            }
+            return v
+        case []interface{}:
+            for i, item := range v {
+                v[i] = jsonToYamlValue(item)
+            }
+            return v
+        case json.Number:
+            if i, err := v.Int64(); err == nil {
+                return i
+            }
+            if f, err := v.Float64(); err == nil {
+                return f
+            }
+            return v.String()
+    }
+    return value
+}
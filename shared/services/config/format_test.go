@@ -0,0 +1,173 @@
+package config
+
+import (
+    "reflect"
+    "testing"
+)
+
+
+// Get a config with nested tables, arrays of tables, maps & numbers to round-trip
+func getTestConfig() RocketPoolConfig {
+    var config RocketPoolConfig
+    config.Rocketpool.StorageAddress = "0x1234567890123456789012345678901234567890"
+    config.Smartnode.ProjectName = "rocketpool"
+    config.Smartnode.Graffiti = "Hello \"world\" # not a comment"
+    config.Smartnode.AddressBook = map[string]string{
+        "0x1234567890123456789012345678901234567890": "Cold Wallet",
+        "0xabcdefabcdefabcdefabcdefabcdefabcdefabcd": "Hot Wallet",
+    }
+    config.Smartnode.ExtraComposeFiles = []string{"a.yml", "b.yml"}
+    config.Smartnode.MetricsPort = 9102
+    config.Chains.Eth1.Provider = "http://eth1:8545"
+    config.Chains.Eth1.ProviderHeaders = map[string]string{"X-Api-Key": "secret"}
+    config.Chains.Eth1.Client.Selected = "geth"
+    config.Chains.Eth1.Client.Options = []ClientOption{
+        {
+            ID: "geth",
+            Name: "Geth",
+            Image: "ethereum/client-go:v1.10.3",
+            Params: []ClientParam{
+                {Name: "Max Peers", Env: "ETH1_MAX_PEERS", Type: "uint", Default: "50", Required: true},
+            },
+        },
+        {
+            ID: "infura",
+            Name: "Infura",
+            CompatibleEth2Clients: "lighthouse;prysm",
+        },
+    }
+    config.Chains.Eth1.Client.Params = []UserParam{
+        {Env: "ETH1_MAX_PEERS", Value: "25"},
+        {Env: "ETH1_EMPTY", Value: ""},
+    }
+    return config
+}
+
+
+func TestParseFormat(t *testing.T) {
+    for name, expected := range map[string]ConfigFormat{
+        "yaml": FormatYAML,
+        "YML": FormatYAML,
+        "json": FormatJSON,
+        "Toml": FormatTOML,
+    } {
+        if format, err := ParseFormat(name); err != nil {
+            t.Errorf("Could not parse format %q: %s", name, err)
+        } else if format != expected {
+            t.Errorf("Expected format %q for %q, got %q", expected, name, format)
+        }
+    }
+    if _, err := ParseFormat("xml"); err == nil {
+        t.Error("Parsed invalid format 'xml'")
+    }
+}
+
+
+// Formats are detected by extension only, regardless of the file content
+func TestDetectFormat(t *testing.T) {
+    for path, expected := range map[string]ConfigFormat{
+        "settings.yml": FormatYAML,
+        "settings.yaml": FormatYAML,
+        "/home/user/.rocketpool/settings.json": FormatJSON,
+        "settings.JSON": FormatJSON,
+        "settings.toml": FormatTOML,
+        "settings.json.bak": FormatYAML,
+        "settings": FormatYAML,
+        "": FormatYAML,
+    } {
+        if format := DetectFormat(path); format != expected {
+            t.Errorf("Expected format %q for %q, got %q", expected, path, format)
+        }
+    }
+}
+
+
+func TestFormatRoundTrip(t *testing.T) {
+    expected := getTestConfig()
+    for _, format := range []ConfigFormat{FormatYAML, FormatJSON, FormatTOML} {
+        t.Run(string(format), func(t *testing.T) {
+            configBytes, err := expected.SerializeAs(format)
+            if err != nil {
+                t.Fatalf("Could not serialize config: %s", err)
+            }
+            config, err := ParseAs(configBytes, format)
+            if err != nil {
+                t.Fatalf("Could not parse config: %s\n%s", err, configBytes)
+            }
+            if !reflect.DeepEqual(config, expected) {
+                t.Errorf("Config changed in round trip:\n%s\nExpected %+v\nGot %+v", configBytes, expected, config)
+            }
+        })
+    }
+}
+
+
+// An empty config serializes to an empty document in every format
+func TestFormatRoundTripEmpty(t *testing.T) {
+    for _, format := range []ConfigFormat{FormatYAML, FormatJSON, FormatTOML} {
+        var expected RocketPoolConfig
+        configBytes, err := expected.SerializeAs(format)
+        if err != nil {
+            t.Fatalf("Could not serialize empty %s config: %s", format, err)
+        }
+        config, err := ParseAs(configBytes, format)
+        if err != nil {
+            t.Fatalf("Could not parse empty %s config: %s", format, err)
+        }
+        if !reflect.DeepEqual(config, expected) {
+            t.Errorf("Empty %s config changed in round trip: %+v", format, config)
+        }
+    }
+}
+
+
+// Hand-written configs in each format parse to the same config
+func TestParseFormats(t *testing.T) {
+    configs := map[ConfigFormat]string{
+        FormatYAML: `
+smartnode:
+  metricsPort: 9102
+  addressBook:
+    "0x1234567890123456789012345678901234567890": Cold Wallet
+chains:
+  eth1:
+    client:
+      params:
+      - env: ETH1_MAX_PEERS
+        value: "25"
+`,
+        FormatJSON: `{
+    "smartnode": {
+        "metricsPort": 9102,
+        "addressBook": {"0x1234567890123456789012345678901234567890": "Cold Wallet"}
+    },
+    "chains": {"eth1": {"client": {"params": [{"env": "ETH1_MAX_PEERS", "value": "25"}]}}}
+}`,
+        FormatTOML: `
+# Generated config
+[smartnode]
+metricsPort = 9102
+
+[smartnode.addressBook]
+0x1234567890123456789012345678901234567890 = "Cold Wallet"
+
+[[chains.eth1.client.params]]
+env = "ETH1_MAX_PEERS"
+value = "25"
+`,
+    }
+    var expected RocketPoolConfig
+    expected.Smartnode.MetricsPort = 9102
+    expected.Smartnode.AddressBook = map[string]string{"0x1234567890123456789012345678901234567890": "Cold Wallet"}
+    expected.Chains.Eth1.Client.Params = []UserParam{{Env: "ETH1_MAX_PEERS", Value: "25"}}
+    for format, content := range configs {
+        config, err := ParseAs([]byte(content), format)
+        if err != nil {
+            t.Errorf("Could not parse %s config: %s", format, err)
+            continue
+        }
+        if !reflect.DeepEqual(config, expected) {
+            t.Errorf("Unexpected %s config: %+v", format, config)
+        }
+    }
+}
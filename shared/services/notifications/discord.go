@@ -0,0 +1,38 @@
+package notifications
+
+import (
+    "fmt"
+    "net/http"
+)
+
+
+// Discord webhook notification sender
+type Discord struct {
+    url string
+    client http.Client
+}
+
+
+// Create new Discord sender
+func NewDiscord(url string) *Discord {
+    return &Discord{
+        url: url,
+        client: http.Client{Timeout: RequestTimeout},
+    }
+}
+
+
+// Get the sender name
+func (d *Discord) Name() string {
+    return "Discord"
+}
+
+
+// Post an event to the Discord webhook
+func (d *Discord) Send(event Event) error {
+    content := fmt.Sprintf("**%s**\n%s\nNode: `%s`", event.Title(), event.Message, event.NodeAddress.Hex())
+    if err := postJSON(&d.client, d.url, map[string]string{"content": content}, nil); err != nil {
+        return fmt.Errorf("Could not send %s event to Discord: %w", event.Type, err)
+    }
+    return nil
+}
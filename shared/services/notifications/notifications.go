@@ -0,0 +1,113 @@
+package notifications
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Config
+const RequestTimeout = 10 * time.Second
+
+
+// Event types
+const (
+    MinipoolWithdrawable = "minipool_withdrawable"
+    RplCollateralLow = "rpl_collateral_low"
+    ClientNotSynced = "client_not_synced"
+)
+
+
+// Node event
+type Event struct {
+    Type string                         `json:"type"`
+    Message string                      `json:"message"`
+    Time time.Time                      `json:"time"`
+    NodeAddress common.Address          `json:"nodeAddress"`
+    MinipoolAddress *common.Address     `json:"minipoolAddress,omitempty"`
+}
+
+
+// Notification sender interface
+type Sender interface {
+    Name() string
+    Send(event Event) error
+}
+
+
+// Create the notification senders enabled in a config
+func NewSenders(cfg config.RocketPoolConfig) []Sender {
+    senders := []Sender{}
+    if cfg.Smartnode.WebhookUrl != "" {
+        senders = append(senders, NewWebhook(cfg.Smartnode.WebhookUrl, cfg.Smartnode.WebhookSecret))
+    }
+    if cfg.Smartnode.DiscordWebhookUrl != "" {
+        senders = append(senders, NewDiscord(cfg.Smartnode.DiscordWebhookUrl))
+    }
+    if cfg.Smartnode.TelegramBotToken != "" && cfg.Smartnode.TelegramChatId != "" {
+        senders = append(senders, NewTelegram(cfg.Smartnode.TelegramBotToken, cfg.Smartnode.TelegramChatId))
+    }
+    return senders
+}
+
+
+// Get a human-readable event title
+func (e Event) Title() string {
+    switch e.Type {
+        case MinipoolWithdrawable: return "Minipool withdrawable"
+        case RplCollateralLow:     return "RPL collateral low"
+        case ClientNotSynced:      return "Client not synced"
+    }
+    return e.Type
+}
+
+
+// POST a JSON payload and check the response status
+func postJSON(client *http.Client, url string, payload interface{}, headers map[string]string) error {
+
+    // Encode payload
+    body, err := json.Marshal(payload)
+    if err != nil {
+        return fmt.Errorf("Could not encode payload: %w", err)
+    }
+
+    // Send payload
+    return post(client, url, body, headers)
+
+}
+
+
+// POST an encoded JSON body and check the response status
+func post(client *http.Client, url string, body []byte, headers map[string]string) error {
+
+    // Build request
+    request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+    if err != nil {
+        return fmt.Errorf("Could not create request: %w", err)
+    }
+    request.Header.Set("Content-Type", "application/json")
+    for name, value := range headers {
+        request.Header.Set(name, value)
+    }
+
+    // Send request
+    response, err := client.Do(request)
+    if err != nil {
+        return err
+    }
+    defer response.Body.Close()
+    if response.StatusCode < 200 || response.StatusCode >= 300 {
+        return fmt.Errorf("Received status %s", response.Status)
+    }
+
+    // Return
+    return nil
+
+}
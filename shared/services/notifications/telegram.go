@@ -0,0 +1,49 @@
+package notifications
+
+import (
+    "fmt"
+    "net/http"
+)
+
+
+// Config
+const TelegramAPIURL = "https://api.telegram.org/bot%s/sendMessage"
+
+
+// Telegram bot notification sender
+type Telegram struct {
+    token string
+    chatId string
+    client http.Client
+}
+
+
+// Create new Telegram sender
+func NewTelegram(token, chatId string) *Telegram {
+    return &Telegram{
+        token: token,
+        chatId: chatId,
+        client: http.Client{Timeout: RequestTimeout},
+    }
+}
+
+
+// Get the sender name
+func (t *Telegram) Name() string {
+    return "Telegram"
+}
+
+
+// Send an event to the Telegram chat
+func (t *Telegram) Send(event Event) error {
+    text := fmt.Sprintf("%s\n%s\nNode: %s", event.Title(), event.Message, event.NodeAddress.Hex())
+    payload := map[string]string{
+        "chat_id": t.chatId,
+        "text": text,
+    }
+    // Don't wrap the request error, as its message contains the bot token
+    if err := postJSON(&t.client, fmt.Sprintf(TelegramAPIURL, t.token), payload, nil); err != nil {
+        return fmt.Errorf("Could not send %s event to Telegram", event.Type)
+    }
+    return nil
+}
@@ -0,0 +1,65 @@
+package notifications
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "net/http"
+)
+
+
+// Config
+const SignatureHeader = "X-Rocketpool-Signature"
+
+
+// Webhook notification sender
+type Webhook struct {
+    url string
+    secret string
+    client http.Client
+}
+
+
+// Create new webhook sender
+func NewWebhook(url, secret string) *Webhook {
+    return &Webhook{
+        url: url,
+        secret: secret,
+        client: http.Client{Timeout: RequestTimeout},
+    }
+}
+
+
+// Get the sender name
+func (w *Webhook) Name() string {
+    return "webhook"
+}
+
+
+// POST an event to the webhook as JSON
+// The body is signed with an HMAC-SHA256 signature header if a secret is set
+func (w *Webhook) Send(event Event) error {
+
+    // Encode event
+    body, err := json.Marshal(event)
+    if err != nil {
+        return fmt.Errorf("Could not encode %s event: %w", event.Type, err)
+    }
+
+    // Sign the exact body sent
+    headers := map[string]string{}
+    if w.secret != "" {
+        mac := hmac.New(sha256.New, []byte(w.secret))
+        mac.Write(body)
+        headers[SignatureHeader] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+    }
+
+    // Send event
+    if err := post(&w.client, w.url, body, headers); err != nil {
+        return fmt.Errorf("Could not send %s event to webhook: %w", event.Type, err)
+    }
+    return nil
+
+}
@@ -38,6 +38,11 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+    // Check file permissions
+    if err := CheckFilePermissions(pm.passwordPath); err != nil {
+        return "", err
+    }
+
     // Read from disk
     password, err := ioutil.ReadFile(pm.passwordPath)
     if err != nil {
@@ -0,0 +1,21 @@
+// +build !windows
+
+package passwords
+
+import (
+    "fmt"
+    "os"
+)
+
+
+// Check that a password file is not accessible by users other than its owner
+func CheckFilePermissions(path string) error {
+    info, err := os.Stat(path)
+    if err != nil {
+        return fmt.Errorf("Could not read password file %s: %w", path, err)
+    }
+    if info.Mode().Perm() & 0077 != 0 {
+        return fmt.Errorf("Password file %s has insecure permissions %04o; it must only be accessible by its owner (e.g. 'chmod 600 %s')", path, info.Mode().Perm(), path)
+    }
+    return nil
+}
@@ -0,0 +1,18 @@
+// +build windows
+
+package passwords
+
+import (
+    "fmt"
+    "os"
+)
+
+
+// Check that a password file can be read
+// Windows reports NTFS files as 0666 regardless of their ACLs, so permissions can't be checked
+func CheckFilePermissions(path string) error {
+    if _, err := os.Stat(path); err != nil {
+        return fmt.Errorf("Could not read password file %s: %w", path, err)
+    }
+    return nil
+}
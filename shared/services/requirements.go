@@ -3,6 +3,7 @@ package services
 import (
     "context"
     "errors"
+    "fmt"
     "log"
     "sync"
     "time"
@@ -12,6 +13,9 @@ import (
     "github.com/rocket-pool/rocketpool-go/dao/trustednode"
     "github.com/rocket-pool/rocketpool-go/node"
     "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+    "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
@@ -26,6 +30,11 @@ var ethClientSyncPollInterval, _ = time.ParseDuration("5s")
 var beaconClientSyncPollInterval, _ = time.ParseDuration("5s")
 
 
+// Whether the eth client's chain ID has been checked successfully
+var ethClientChainIDChecked bool
+var ethClientChainIDLock sync.Mutex
+
+
 //
 // Service requirements
 //
@@ -64,7 +73,7 @@ func RequireEthClientSynced(c *cli.Context) error {
         return err
     }
     if !ethClientSynced {
-        return errors.New("The Eth 1.0 node is currently syncing. Please try again later.")
+        return api.NewCodedError(api.ErrorCodeNotSynced, "The Eth 1.0 node is currently syncing. Please try again later.")
     }
     return nil
 }
@@ -76,7 +85,7 @@ func RequireMainnetEthClientSynced(c *cli.Context) error {
         return err
     }
     if !mainnetEthClientSynced {
-        return errors.New("The mainnet Eth 1.0 node is currently syncing. Please try again later.")
+        return api.NewCodedError(api.ErrorCodeNotSynced, "The mainnet Eth 1.0 node is currently syncing. Please try again later.")
     }
     return nil
 }
@@ -88,7 +97,7 @@ func RequireBeaconClientSynced(c *cli.Context) error {
         return err
     }
     if !beaconClientSynced {
-        return errors.New("The Eth 2.0 node is currently syncing. Please try again later.")
+        return api.NewCodedError(api.ErrorCodeNotSynced, "The Eth 2.0 node is currently syncing. Please try again later.")
     }
     return nil
 }
@@ -98,6 +107,9 @@ func RequireRocketStorage(c *cli.Context) error {
     if err := RequireEthClientSynced(c); err != nil {
         return err
     }
+    if err := checkEthClientChainID(c); err != nil {
+        return err
+    }
     rocketStorageLoaded, err := getRocketStorageLoaded(c)
     if err != nil {
         return err
@@ -218,6 +230,9 @@ func WaitRocketStorage(c *cli.Context, verbose bool) error {
     if err := WaitEthClientSynced(c, verbose); err != nil {
         return err
     }
+    if err := checkEthClientChainID(c); err != nil {
+        return err
+    }
     for {
         rocketStorageLoaded, err := getRocketStorageLoaded(c)
         if err != nil {
@@ -282,6 +297,41 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 }
 
 
+// Check that the eth client's chain ID matches the chain ID the wallet signs transactions for
+// Only the first successful check queries the eth client
+func checkEthClientChainID(c *cli.Context) error {
+    ethClientChainIDLock.Lock()
+    defer ethClientChainIDLock.Unlock()
+    if ethClientChainIDChecked {
+        return nil
+    }
+    cfg, err := GetConfig(c)
+    if err != nil {
+        return err
+    }
+    if cfg.Chains.Eth1.ChainID == "" {
+        return nil
+    }
+    expectedChainID, err := config.ParseChainID(cfg.Chains.Eth1.ChainID)
+    if err != nil {
+        return err
+    }
+    ec, err := GetEthClient(c)
+    if err != nil {
+        return err
+    }
+    chainID, err := ec.ChainID(context.Background())
+    if err != nil {
+        return fmt.Errorf("Could not get the Eth 1.0 node's chain ID: %w", err)
+    }
+    if chainID.Cmp(expectedChainID) != 0 {
+        return fmt.Errorf("The Eth 1.0 node is on chain ID %s, but the node wallet is configured to sign transactions for chain ID %s. Please check the 'chainId' option or the eth1 chainID setting in your config.", chainID.String(), expectedChainID.String())
+    }
+    ethClientChainIDChecked = true
+    return nil
+}
+
+
 // Check if the RocketStorage contract is loaded
 func getRocketStorageLoaded(c *cli.Context) (bool, error) {
     cfg, err := GetConfig(c)
@@ -3,24 +3,84 @@ package rocketpool
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
+// Read-only API commands which don't have a can- or get- prefix
+var ReadOnlyAPICommands = []string{"status", "sync", "test-connectivity", "check-duplicate-keys", "check-keys", "lots", "members", "proposals", "node-fee", "rpl-price", "position"}
+
+
 // Wait for a transaction
-func (c *Client) WaitForTransaction(txHash common.Hash) (api.APIResponse, error) {
-    responseBytes, err := c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
+// Returns an error with the TransactionReverted exit code if the transaction was reverted
+// The full receipt is printed as JSON once mined if receipts are enabled
+func (c *Client) WaitForTransaction(txHash common.Hash) (api.WaitForTransactionResponse, error) {
+    args := fmt.Sprintf("wait %s", txHash.String())
+    if c.printReceipts {
+        args += " --receipt"
+    }
+    if c.confirmations > 1 {
+        args += fmt.Sprintf(" --confirmations %d", c.confirmations)
+    }
+    responseBytes, err := c.callAPI(args)
     if err != nil {
-        return api.APIResponse{}, fmt.Errorf("Error waiting for tx: %w", err)
+        return api.WaitForTransactionResponse{}, fmt.Errorf("Error waiting for tx: %w", err)
     }
-    var response api.APIResponse
+    var response api.WaitForTransactionResponse
     if err := json.Unmarshal(responseBytes, &response); err != nil {
-        return api.APIResponse{}, fmt.Errorf("Error decoding wait response: %w", err)
+        return api.WaitForTransactionResponse{}, fmt.Errorf("Error decoding wait response: %w", err)
     }
     if response.Error != "" {
-        return api.APIResponse{}, fmt.Errorf("Error waiting for tx: %s", response.Error)
+        return api.WaitForTransactionResponse{}, fmt.Errorf("Error waiting for tx: %s", response.Error)
+    }
+    if c.printReceipts && response.Receipt != nil {
+        receiptBytes, err := json.MarshalIndent(response.Receipt, "", "    ")
+        if err != nil {
+            return api.WaitForTransactionResponse{}, fmt.Errorf("Error encoding tx receipt: %w", err)
+        }
+        fmt.Fprintln(c.jsonWriter, string(receiptBytes))
+        if response.ReceiptError != "" {
+            fmt.Fprintf(c.stderr, "Warning: the receipt is incomplete: %s\n", response.ReceiptError)
+        }
+    }
+    if response.Reverted {
+        return response, exit.NewError(exit.TransactionReverted, fmt.Errorf("Transaction %s was reverted", txHash.String()))
     }
     return response, nil
-}
\ No newline at end of file
+}
+
+// Call a read-only API command and return the raw JSON response
+func (c *Client) CallReadOnlyAPI(args []string) ([]byte, error) {
+    if !IsReadOnlyAPICommand(args) {
+        return []byte{}, fmt.Errorf("'%s' is not a read-only API command", strings.Join(args, " "))
+    }
+    quotedArgs := make([]string, len(args))
+    for ai, arg := range args {
+        quotedArgs[ai] = shellQuote(arg)
+    }
+    return c.callAPIRaw(strings.Join(quotedArgs, " "))
+}
+
+
+// Check whether API command arguments refer to a read-only command
+// Commands which submit transactions or modify or export the wallet are excluded
+func IsReadOnlyAPICommand(args []string) bool {
+    if len(args) < 2 || args[0] == "wallet" {
+        return false
+    }
+    command := args[1]
+    if strings.HasPrefix(command, "can-") || strings.HasPrefix(command, "get-") {
+        return true
+    }
+    for _, readOnlyCommand := range ReadOnlyAPICommands {
+        if command == readOnlyCommand {
+            return true
+        }
+    }
+    return false
+}
+
@@ -2,22 +2,36 @@ package rocketpool
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/url"
 	"os"
+	"os/signal"
 	osUser "os/user"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/urfave/cli"
 	"golang.org/x/crypto/ssh"
 	kh "golang.org/x/crypto/ssh/knownhosts"
+	"gopkg.in/yaml.v2"
 
 	"github.com/blang/semver/v4"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/net"
 )
 
@@ -28,67 +42,200 @@ const (
     GlobalConfigFile = "config.yml"
     UserConfigFile = "settings.yml"
     ComposeFile = "docker-compose.yml"
+    ImageManifestFile = "image-digests.yml"
+    ImageManifestSignatureSuffix = ".sig"
+    ConfigLockSuffix = ".lock"
+
+    ConfigExportHeader = "# rocketpool config export version "
+    ConfigExportVersion = 1
 
     APIContainerSuffix = "_api"
+    ComposeNetworkSuffix = "_net"
+    APIContainerConfigPath = "/.rocketpool"
+    ComposeProjectLabel = "com.docker.compose.project"
+
+    APIModeAuto = "auto"
+    APIModeExec = "exec"
+    APIModeRun = "run"
     APIBinPath = "/go/bin/rocketpool"
 
     DebugColor = color.FgYellow
+
+    MaskedValue = "********"
+
+    LatestBlockCacheTTL = 5 * time.Second
+
+    SSHReconnectAttempts = 6
+    SSHReconnectInitialDelay = time.Second
+    SSHReconnectMaxDelay = 30 * time.Second
+    SSHKeepaliveInterval = 15 * time.Second
 )
 
 
+// The hex-encoded ed25519 public key that image manifests are signed with; set by build.sh from IMAGE_MANIFEST_PUBLIC_KEY with -ldflags -X
+var ImageManifestPublicKey string
+
+
+// Manifest of the expected digests of the service images, keyed by image tag
+type ImageManifest struct {
+    Images map[string]string            `yaml:"images"`
+}
+
+
+// JSON config export; JSON has no comments, so the export version is a field instead of a header
+type jsonConfigExport struct {
+    ExportVersion int                   `json:"exportVersion"`
+    Config json.RawMessage              `json:"config"`
+}
+
+
+// Environment variable name fragments which indicate a secret value
+var SecretEnvNames = []string{"KEY", "SECRET", "TOKEN", "PASSWORD", "PROJECT_ID", "AUTH"}
+
+
 // Rocket Pool client
+// gasPrice and gasLimit are immutable after creation; customNonce is guarded by nonceLock, and the SSH client by clientLock as it is replaced on reconnect
 type Client struct {
     configPath string
     daemonPath string
+    projectName string
+    apiMode string
+    apiModeLock sync.Mutex
     gasPrice string
     gasLimit string
     customNonce uint64
+    nonceLock sync.Mutex
+    commandTimeout time.Duration
+    jsonOutput bool
+    estimateOnly bool
+    printReceipts bool
+    confirmations uint64
+    skipCompatCheck bool
+    compatWarningOnce sync.Once
     client *ssh.Client
+    clientLock sync.RWMutex
+    sshAddress string
+    sshConfig *ssh.ClientConfig
+    latestBlock *api.LatestBlockResponse
+    latestBlockTime time.Time
+    latestBlockLock sync.Mutex
+    stdout io.Writer
+    stderr io.Writer
+    jsonWriter io.Writer
+}
+
+
+// Rocket Pool client options
+type ClientOptions struct {
+    ConfigPath string
+    DaemonPath string
+    ProjectName string                  // Overrides the project name in the config if set
+    APIMode string
+    HostAddress string                  // Commands are run on this host over SSH if set, and locally otherwise
+    User string
+    KeyPath string
+    PassphrasePath string
+    KnownHostsFile string
+    InsecureIgnoreHostKey bool
+    GasPrice string
+    GasLimit string
+    CustomNonce uint64
+    CommandTimeout time.Duration        // 0 for no timeout
+    JSONOutput bool
+    EstimateOnly bool
+    PrintReceipts bool
+    Confirmations uint64
+    SkipCompatCheck bool
 }
 
 
 // Create new Rocket Pool client from CLI context
+// In JSON mode, JSON output is printed to stdout and all other output to stderr, so stdout is pure JSON
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-    return NewClient(c.GlobalString("config-path"), 
-                     c.GlobalString("daemon-path"), 
-                     c.GlobalString("host"), 
-                     c.GlobalString("user"), 
-                     c.GlobalString("key"), 
-                     c.GlobalString("passphrase"),
-                     c.GlobalString("known-hosts"),
-                     c.GlobalString("gasPrice"),
-                     c.GlobalString("gasLimit"),
-                     c.GlobalUint64("nonce"))
+    client, err := NewClient(ClientOptions{
+        ConfigPath: c.GlobalString("config-path"),
+        DaemonPath: c.GlobalString("daemon-path"),
+        ProjectName: c.GlobalString("project"),
+        APIMode: c.GlobalString("api-mode"),
+        HostAddress: c.GlobalString("host"),
+        User: c.GlobalString("user"),
+        KeyPath: c.GlobalString("key"),
+        PassphrasePath: c.GlobalString("passphrase"),
+        KnownHostsFile: c.GlobalString("known-hosts"),
+        InsecureIgnoreHostKey: c.GlobalBool("insecure-ignore-host-key"),
+        GasPrice: c.GlobalString("gasPrice"),
+        GasLimit: c.GlobalString("gasLimit"),
+        CustomNonce: c.GlobalUint64("nonce"),
+        CommandTimeout: c.GlobalDuration("command-timeout"),
+        JSONOutput: c.GlobalBool("json"),
+        EstimateOnly: c.GlobalBool("estimate-only"),
+        PrintReceipts: c.GlobalBool("receipt"),
+        Confirmations: c.GlobalUint64("confirmations"),
+        SkipCompatCheck: c.GlobalBool("skip-compat-check"),
+    })
+    if err != nil {
+        return nil, err
+    }
+    if c.GlobalBool("json") {
+        client.SetOutput(os.Stderr, os.Stderr)
+        client.SetJSONOutput(os.Stdout)
+    }
+    return client, nil
 }
 
 
 // Create new Rocket Pool client
-func NewClient(configPath, daemonPath, hostAddress, user, keyPath, passphrasePath, knownhostsFile, gasPrice, gasLimit string, customNonce uint64) (*Client, error) {
+func NewClient(opts ClientOptions) (*Client, error) {
+
+    // Check API mode
+    switch opts.APIMode {
+        case "": opts.APIMode = APIModeAuto
+        case APIModeAuto, APIModeExec, APIModeRun:
+        default: return nil, fmt.Errorf("Invalid API mode '%s' - must be '%s', '%s' or '%s'", opts.APIMode, APIModeAuto, APIModeExec, APIModeRun)
+    }
+
+    // Check project name
+    if opts.ProjectName != "" {
+        if err := config.ValidateProjectName(opts.ProjectName); err != nil {
+            return nil, err
+        }
+    }
+
+    // Normalize gas price to wei
+    if opts.GasPrice != "" {
+        gasPriceWei, err := config.ParseGasPrice(opts.GasPrice)
+        if err != nil {
+            return nil, err
+        }
+        opts.GasPrice = fmt.Sprintf("%swei", gasPriceWei.String())
+    }
 
     // Initialize SSH client if configured for SSH
     var sshClient *ssh.Client
-    if hostAddress != "" {
+    var sshAddress string
+    var sshConfig *ssh.ClientConfig
+    if opts.HostAddress != "" {
 
         // Check parameters
-        if user == "" {
+        if opts.User == "" {
             return nil, errors.New("The SSH user (--user) must be specified.")
         }
-        if keyPath == "" {
+        if opts.KeyPath == "" {
             return nil, errors.New("The SSH private key path (--key) must be specified.")
         }
 
         // Read private key
-        keyBytes, err := ioutil.ReadFile(os.ExpandEnv(keyPath))
+        keyBytes, err := ioutil.ReadFile(os.ExpandEnv(opts.KeyPath))
         if err != nil {
-            return nil, fmt.Errorf("Could not read SSH private key at %s: %w", keyPath, err)
+            return nil, fmt.Errorf("Could not read SSH private key at %s: %w", opts.KeyPath, err)
         }
 
         // Read passphrase
         var passphrase []byte
-        if passphrasePath != "" {
-            passphrase, err = ioutil.ReadFile(os.ExpandEnv(passphrasePath))
+        if opts.PassphrasePath != "" {
+            passphrase, err = ioutil.ReadFile(os.ExpandEnv(opts.PassphrasePath))
             if err != nil {
-                return nil, fmt.Errorf("Could not read SSH passphrase at %s: %w", passphrasePath, err)
+                return nil, fmt.Errorf("Could not read SSH passphrase at %s: %w", opts.PassphrasePath, err)
             }
         }
 
@@ -100,54 +247,143 @@ func NewClient(configPath, daemonPath, hostAddress, user, keyPath, passphrasePat
             key, err = ssh.ParsePrivateKeyWithPassphrase(keyBytes, passphrase)
         }
         if err != nil {
-            return nil, fmt.Errorf("Could not parse SSH private key at %s: %w", keyPath, err)
+            return nil, fmt.Errorf("Could not parse SSH private key at %s: %w", opts.KeyPath, err)
         }
 
         // Prepare the server host key callback function
-        if knownhostsFile == "" {
-            // Default to using the current users known_hosts file if one wasn't provided
-            usr, err := osUser.Current()
+        var hostKeyCallback ssh.HostKeyCallback
+        if opts.InsecureIgnoreHostKey {
+
+            // Skip host key verification; only safe for disposable test hosts
+            fmt.Fprintf(os.Stderr, "%sWARNING: SSH host key verification is disabled. The connection to %s is vulnerable to man-in-the-middle attacks.%s\n", colorRed, opts.HostAddress, colorReset)
+            hostKeyCallback = ssh.InsecureIgnoreHostKey()
+
+        } else {
+
+            if opts.KnownHostsFile == "" {
+                // Default to using the current users known_hosts file if one wasn't provided
+                usr, err := osUser.Current()
+                if err != nil {
+                    return nil, fmt.Errorf("Could not get current user: %w", err)
+                }
+                opts.KnownHostsFile = fmt.Sprintf("%s/.ssh/known_hosts", usr.HomeDir)
+            }
+
+            hostKeyCallback, err = kh.New(opts.KnownHostsFile)
             if err != nil {
-                return nil, fmt.Errorf("Could not get current user: %w", err)
+                return nil, fmt.Errorf("Could not create hostKeyCallback function: %w", err)
             }
-            knownhostsFile = fmt.Sprintf("%s/.ssh/known_hosts", usr.HomeDir)
-        }
 
-        hostKeyCallback, err := kh.New(knownhostsFile)
-        if err != nil {
-            return nil, fmt.Errorf("Could not create hostKeyCallback function: %w", err)
         }
 
         // Initialise client
-        sshClient, err = ssh.Dial("tcp", net.DefaultPort(hostAddress, "22"), &ssh.ClientConfig{
-            User: user,
+        sshAddress = net.DefaultPort(opts.HostAddress, "22")
+        sshConfig = &ssh.ClientConfig{
+            User: opts.User,
             Auth: []ssh.AuthMethod{ssh.PublicKeys(key)},
             HostKeyCallback: hostKeyCallback,
-        })
+        }
+        sshClient, err = ssh.Dial("tcp", sshAddress, sshConfig)
         if err != nil {
-            return nil, fmt.Errorf("Could not connect to %s as %s: %w", hostAddress, user, err)
+            return nil, fmt.Errorf("Could not connect to %s as %s: %w", opts.HostAddress, opts.User, err)
         }
 
     }
 
     // Return client
     return &Client{
-        configPath: os.ExpandEnv(configPath),
-        daemonPath: os.ExpandEnv(daemonPath),
-        gasPrice: gasPrice,
-        gasLimit: gasLimit,
-        customNonce: customNonce,
+        configPath: os.ExpandEnv(opts.ConfigPath),
+        daemonPath: os.ExpandEnv(opts.DaemonPath),
+        projectName: opts.ProjectName,
+        apiMode: opts.APIMode,
+        gasPrice: opts.GasPrice,
+        gasLimit: opts.GasLimit,
+        customNonce: opts.CustomNonce,
+        commandTimeout: opts.CommandTimeout,
+        jsonOutput: opts.JSONOutput,
+        estimateOnly: opts.EstimateOnly,
+        printReceipts: opts.PrintReceipts,
+        confirmations: opts.Confirmations,
+        skipCompatCheck: opts.SkipCompatCheck,
         client: sshClient,
+        sshAddress: sshAddress,
+        sshConfig: sshConfig,
+        stdout: os.Stdout,
+        stderr: os.Stderr,
+        jsonWriter: os.Stdout,
     }, nil
 
 }
 
 
+// Set the writers that command output is printed to; nil writers default to the OS streams
+func (c *Client) SetOutput(stdout, stderr io.Writer) {
+    if stdout == nil {
+        stdout = os.Stdout
+    }
+    if stderr == nil {
+        stderr = os.Stderr
+    }
+    c.stdout = stdout
+    c.stderr = stderr
+}
+
+
+// Set the writer that JSON output (gas estimates & receipts) is printed to; a nil writer defaults to stdout
+func (c *Client) SetJSONOutput(jsonWriter io.Writer) {
+    if jsonWriter == nil {
+        jsonWriter = os.Stdout
+    }
+    c.jsonWriter = jsonWriter
+}
+
+
+// Get the writer that command output is printed to
+func (c *Client) Output() io.Writer {
+    return c.stdout
+}
+
+
 // Close client remote connection
 func (c *Client) Close() {
-    if c.client != nil {
-        c.client.Close()
+    if client := c.getSSHClient(); client != nil {
+        client.Close()
+    }
+}
+
+
+// Get the SSH client, or nil if commands are run locally
+func (c *Client) getSSHClient() *ssh.Client {
+    c.clientLock.RLock()
+    defer c.clientLock.RUnlock()
+    return c.client
+}
+
+
+// Reconnect to the SSH host after the connection was lost, retrying with exponential backoff
+func (c *Client) reconnect() error {
+    if client := c.getSSHClient(); client != nil {
+        client.Close()
+    }
+    delay := SSHReconnectInitialDelay
+    var err error
+    for attempt := 1; attempt <= SSHReconnectAttempts; attempt++ {
+        fmt.Fprintf(c.stderr, "%sLost connection to %s, reconnecting in %s (attempt %d of %d)...%s\n", colorYellow, c.sshAddress, delay, attempt, SSHReconnectAttempts, colorReset)
+        time.Sleep(delay)
+        var sshClient *ssh.Client
+        sshClient, err = ssh.Dial("tcp", c.sshAddress, c.sshConfig)
+        if err == nil {
+            c.clientLock.Lock()
+            c.client = sshClient
+            c.clientLock.Unlock()
+            return nil
+        }
+        delay *= 2
+        if delay > SSHReconnectMaxDelay {
+            delay = SSHReconnectMaxDelay
+        }
     }
+    return fmt.Errorf("Could not reconnect to %s: %w", c.sshAddress, err)
 }
 
 
@@ -166,6 +402,30 @@ func (c *Client) SaveUserConfig(cfg config.RocketPoolConfig) error {
 }
 
 
+// Update the user config, holding its lock from loading it until the update is saved so concurrent updates are not lost
+// The update function must not load the user config itself, as it is already locked
+func (c *Client) UpdateUserConfig(update func(*config.RocketPoolConfig) error) error {
+    path := fmt.Sprintf("%s/%s", c.configPath, UserConfigFile)
+    expandedPath, err := homedir.Expand(path)
+    if err != nil {
+        return err
+    }
+    unlock, err := lockConfig(expandedPath, true)
+    if err != nil {
+        return err
+    }
+    defer unlock()
+    cfg, err := readConfigFile(path, expandedPath)
+    if err != nil {
+        return err
+    }
+    if err := update(&cfg); err != nil {
+        return err
+    }
+    return writeConfigFile(cfg, path, expandedPath)
+}
+
+
 // Load the merged global & user config
 func (c *Client) LoadMergedConfig() (config.RocketPoolConfig, error) {
     globalConfig, err := c.LoadGlobalConfig()
@@ -176,12 +436,148 @@ func (c *Client) LoadMergedConfig() (config.RocketPoolConfig, error) {
     if err != nil {
         return config.RocketPoolConfig{}, err
     }
-    return config.Merge(&globalConfig, &userConfig)
+    cfg, err := config.Merge(&globalConfig, &userConfig)
+    if err != nil {
+        return config.RocketPoolConfig{}, err
+    }
+    if c.projectName != "" {
+        cfg.Smartnode.ProjectName = c.projectName
+    }
+    return cfg, nil
+}
+
+
+// Export the user config in a format with its export version
+// Inline credentials are masked unless includeSecrets is set; secret references are kept, but the secrets file is not exported
+func (c *Client) ExportConfig(w io.Writer, format config.ConfigFormat, includeSecrets bool) error {
+
+    // Load user config
+    userConfig, err := c.LoadUserConfig()
+    if err != nil {
+        return err
+    }
+
+    // Mask inline credentials
+    if !includeSecrets {
+        maskConfigSecrets(&userConfig, func(setting *string, masked string) {
+            if *setting != "" && !config.HasSecretReference(*setting) {
+                *setting = masked
+            }
+        })
+    }
+
+    // Serialize user config
+    configBytes, err := userConfig.SerializeAs(format)
+    if err != nil {
+        return err
+    }
+
+    // Write json config with its export version
+    if format == config.FormatJSON {
+        exportBytes, err := json.MarshalIndent(jsonConfigExport{
+            ExportVersion: ConfigExportVersion,
+            Config: json.RawMessage(configBytes),
+        }, "", "    ")
+        if err != nil {
+            return fmt.Errorf("Could not serialize config export: %w", err)
+        }
+        if _, err := w.Write(append(exportBytes, '\n')); err != nil {
+            return fmt.Errorf("Could not write config export: %w", err)
+        }
+        return nil
+    }
+
+    // Write header & config
+    if _, err := fmt.Fprintf(w, "%s%d\n", ConfigExportHeader, ConfigExportVersion); err != nil {
+        return fmt.Errorf("Could not write config export: %w", err)
+    }
+    if _, err := w.Write(configBytes); err != nil {
+        return fmt.Errorf("Could not write config export: %w", err)
+    }
+    return nil
+
+}
+
+
+// Import a user config exported by ExportConfig in a format
+func (c *Client) ImportConfig(r io.Reader, format config.ConfigFormat) error {
+
+    // Read config export
+    importBytes, err := ioutil.ReadAll(r)
+    if err != nil {
+        return fmt.Errorf("Could not read config export: %w", err)
+    }
+
+    // Get config & export version from json exports, or from the version header
+    var configBytes []byte
+    var version int
+    if format == config.FormatJSON {
+        var export jsonConfigExport
+        if err := json.Unmarshal(importBytes, &export); err != nil {
+            return fmt.Errorf("Could not decode config export: %w", err)
+        }
+        if export.ExportVersion == 0 || len(export.Config) == 0 {
+            return errors.New("Could not import config: missing export version or config")
+        }
+        configBytes, version = export.Config, export.ExportVersion
+    } else {
+        importString := string(importBytes)
+        headerEnd := strings.Index(importString, "\n")
+        if headerEnd == -1 || !strings.HasPrefix(importString, ConfigExportHeader) {
+            return errors.New("Could not import config: missing export version header")
+        }
+        version, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(importString[:headerEnd], ConfigExportHeader)))
+        if err != nil {
+            return fmt.Errorf("Could not import config: invalid export version header: %w", err)
+        }
+        configBytes = []byte(importString[headerEnd + 1:])
+    }
+    if version != ConfigExportVersion {
+        return fmt.Errorf("Could not import config: unsupported export version %d (expected %d)", version, ConfigExportVersion)
+    }
+
+    // Parse config
+    userConfig, err := config.ParseAs(configBytes, format)
+    if err != nil {
+        return err
+    }
+
+    // Validate selected clients against the global config
+    globalConfig, err := c.LoadGlobalConfig()
+    if err != nil {
+        return err
+    }
+    if err := validateImportedChain(&(globalConfig.Chains.Eth1), &(userConfig.Chains.Eth1), "Eth 1.0"); err != nil {
+        return err
+    }
+    if err := validateImportedChain(&(globalConfig.Chains.Eth2), &(userConfig.Chains.Eth2), "Eth 2.0"); err != nil {
+        return err
+    }
+
+    // Save user config
+    return c.SaveUserConfig(userConfig)
+
 }
 
 
 // Install the Rocket Pool service
-func (c *Client) InstallService(verbose, noDeps bool, network, version string) error {
+// The installation script is downloaded to a temporary file and verified against installerChecksum (a sha256 hash) if set before it is run
+// If events is set, progress is written to it as JSON line events instead of printing the installer output, ending with a done or error event
+func (c *Client) InstallService(verbose, noDeps bool, network, version, installerChecksum string, events io.Writer) error {
+    err := c.installService(verbose, noDeps, network, version, installerChecksum, events)
+    if events != nil {
+        if err != nil {
+            WriteInstallEvent(events, InstallEvent{Stage: InstallStageError, Message: err.Error()})
+        } else {
+            WriteInstallEvent(events, InstallEvent{Stage: InstallStageDone, Message: "The Rocket Pool service was successfully installed."})
+        }
+    }
+    return err
+}
+
+
+// Run the installation script, writing progress events to events if set
+func (c *Client) installService(verbose, noDeps bool, network, version, installerChecksum string, events io.Writer) error {
 
     // Get installation script downloader type
     downloader, err := c.getDownloader()
@@ -196,8 +592,20 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version string) e
         flags = append(flags, "-d")
     }
 
+    // Get installation script commands
+    script := []string{
+        `installer="$(mktemp)" || { echo "Could not create a temporary file for the installation script." >&2; exit 1; }`,
+        `trap 'rm -f "$installer"' EXIT`,
+        fmt.Sprintf(`%s "$installer" %q || { echo "Could not download the installation script from %s." >&2; exit 1; }`, downloader, InstallerURL, InstallerURL),
+        `[ -s "$installer" ] || { echo "The downloaded installation script is empty." >&2; exit 1; }`,
+    }
+    if installerChecksum != "" {
+        script = append(script, fmt.Sprintf(`echo "%s  $installer" | sha256sum -c - >/dev/null 2>&1 || { echo "The downloaded installation script does not match the expected checksum." >&2; exit 1; }`, strings.ToLower(installerChecksum)))
+    }
+    script = append(script, fmt.Sprintf(`sh "$installer" %s`, strings.Join(flags, " ")))
+
     // Initialize installation command
-    cmd, err := c.newCommand(fmt.Sprintf("%s %s | sh -s -- %s", downloader, InstallerURL, strings.Join(flags, " ")))
+    cmd, err := c.newCommand(strings.Join(script, "\n"), 0)
     if err != nil { return err }
     defer cmd.Close()
 
@@ -207,30 +615,53 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version string) e
     cmdErr, err := cmd.StderrPipe()
     if err != nil { return err }
 
+    // Start command
+    if events != nil {
+        WriteInstallEvent(events, InstallEvent{Stage: InstallStageDownloading, Message: "Downloading the installation script..."})
+    }
+    if err := cmd.Start(); err != nil {
+        return err
+    }
+
+    // Read command output until both pipes are closed
+    var errMessage string
+    var outputWg sync.WaitGroup
+    outputWg.Add(2)
+
     // Print progress from stdout
     go (func() {
+        defer outputWg.Done()
+        var event InstallEvent
         scanner := bufio.NewScanner(cmdOut)
         for scanner.Scan() {
-            fmt.Println(scanner.Text())
+            if events == nil {
+                fmt.Fprintln(c.stdout, scanner.Text())
+                continue
+            }
+            if strings.TrimSpace(scanner.Text()) == "" {
+                continue
+            }
+            event = parseInstallLine(scanner.Text(), event)
+            WriteInstallEvent(events, event)
         }
     })()
 
     // Read command & error output from stderr; render in verbose mode
-    var errMessage string
     go (func() {
-        c := color.New(DebugColor)
+        defer outputWg.Done()
+        debug := color.New(DebugColor)
         scanner := bufio.NewScanner(cmdErr)
         for scanner.Scan() {
             errMessage = scanner.Text()
             if verbose {
-                _, _ = c.Println(scanner.Text())
+                _, _ = debug.Fprintln(c.stderr, scanner.Text())
             }
         }
     })()
 
-    // Run command and return error output
-    err = cmd.Run()
-    if err != nil {
+    // Wait for the command once its output has been read & return error output
+    outputWg.Wait()
+    if err := cmd.Wait(); err != nil {
         return fmt.Errorf("Could not install Rocket Pool service: %s", errMessage)
     }
     return nil
@@ -239,26 +670,98 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version string) e
 
 
 // Start the Rocket Pool service
-func (c *Client) StartService(composeFiles []string) error {
+// Output is only printed on failure if quiet is set
+func (c *Client) StartService(composeFiles []string, quiet bool) error {
+    if err := c.checkImageDigests(); err != nil { return err }
     cmd, err := c.compose(composeFiles, "up -d")
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Start a subset of the Rocket Pool services
+// Output is only printed on failure if quiet is set
+func (c *Client) StartServices(composeFiles []string, services []string, quiet bool) error {
+    if err := c.checkImageDigests(); err != nil { return err }
+    cmd, err := c.compose(composeFiles, fmt.Sprintf("up -d %s", strings.Join(services, " ")))
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Pull the Rocket Pool service images
+// Output is only printed on failure if quiet is set
+func (c *Client) PullServiceImages(composeFiles []string, quiet bool) error {
+    cmd, err := c.compose(composeFiles, "pull")
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Recreate the Rocket Pool service containers, even if their config & images are unchanged
+// Output is only printed on failure if quiet is set
+func (c *Client) RecreateService(composeFiles []string, quiet bool) error {
+    if err := c.checkImageDigests(); err != nil { return err }
+    cmd, err := c.compose(composeFiles, "up -d --force-recreate")
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
 }
 
 
 // Pause the Rocket Pool service
-func (c *Client) PauseService(composeFiles []string) error {
+// Output is only printed on failure if quiet is set
+func (c *Client) PauseService(composeFiles []string, quiet bool) error {
     cmd, err := c.compose(composeFiles, "stop")
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Freeze the Rocket Pool service containers in place, suspending their processes without stopping them
+// Output is only printed on failure if quiet is set
+func (c *Client) FreezeService(composeFiles []string, quiet bool) error {
+    cmd, err := c.compose(composeFiles, "pause")
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Resume the frozen Rocket Pool service containers
+// Output is only printed on failure if quiet is set
+func (c *Client) UnfreezeService(composeFiles []string, quiet bool) error {
+    cmd, err := c.compose(composeFiles, "unpause")
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Lock the node wallet in the running node & watchtower daemons, which zero their cached keys on SIGUSR1
+// Output is only printed on failure if quiet is set
+func (c *Client) LockServiceWallet(composeFiles []string, quiet bool) error {
+    cmd, err := c.compose(composeFiles, "kill -s SIGUSR1 node watchtower")
+    if err != nil { return err }
+    return c.printOrCaptureOutput(cmd, quiet)
 }
 
 
 // Stop the Rocket Pool service
-func (c *Client) StopService(composeFiles []string) error {
-    cmd, err := c.compose(composeFiles, "down -v")
+// Volumes are only removed if removeVolumes is set, as this deletes all chain data
+// Output is only printed on failure if quiet is set
+func (c *Client) StopService(composeFiles []string, removeVolumes, quiet bool) error {
+    cmd, err := c.compose(composeFiles, getStopServiceArgs(removeVolumes))
     if err != nil { return err }
-    return c.printOutput(cmd)
+    return c.printOrCaptureOutput(cmd, quiet)
+}
+
+
+// Get the compose arguments to stop the Rocket Pool service
+// Volumes are only removed if explicitly requested
+func getStopServiceArgs(removeVolumes bool) string {
+    args := "down"
+    if removeVolumes {
+        args += " -v"
+    }
+    return args
 }
 
 
@@ -270,15 +773,153 @@ func (c *Client) PrintServiceStatus(composeFiles []string) error {
 }
 
 
+// Get the Rocket Pool service status output
+func (c *Client) GetServiceStatus(composeFiles []string) ([]byte, error) {
+    cmd, err := c.compose(composeFiles, "ps")
+    if err != nil { return nil, err }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get Rocket Pool service status: %w", err)
+    }
+    return output, nil
+}
+
+
+// Get the compose projects other than this one which are running a validator client container on the host
+func (c *Client) GetOtherValidatorProjects() ([]string, error) {
+
+    // Get this project name
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return nil, err
+    }
+
+    // Get the projects of running validator containers
+    output, err := c.readOutput(fmt.Sprintf("docker ps --filter %q --format %q", "label=com.docker.compose.service=validator", fmt.Sprintf("{{.Label \"%s\"}}", ComposeProjectLabel)))
+    if err != nil {
+        return nil, fmt.Errorf("Could not get running validator containers: %w", err)
+    }
+    projects := []string{}
+    for _, project := range strings.Fields(string(output)) {
+        if project != cfg.Smartnode.ProjectName {
+            projects = append(projects, project)
+        }
+    }
+    return projects, nil
+
+}
+
+
+// Get the Rocket Pool services which are defined in the compose files but are not running
+func (c *Client) GetStoppedServices(composeFiles []string) ([]string, error) {
+
+    // Get defined services
+    cmd, err := c.compose(composeFiles, "config --services")
+    if err != nil { return nil, err }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get Rocket Pool services: %w", err)
+    }
+    services := strings.Fields(string(output))
+
+    // Get running services
+    cmd, err = c.compose(composeFiles, "ps --services --filter status=running")
+    if err != nil { return nil, err }
+    output, err = c.readOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get running Rocket Pool services: %w", err)
+    }
+    running := map[string]bool{}
+    for _, service := range strings.Fields(string(output)) {
+        running[service] = true
+    }
+
+    // Return stopped services
+    stopped := []string{}
+    for _, service := range services {
+        if !running[service] {
+            stopped = append(stopped, service)
+        }
+    }
+    return stopped, nil
+
+}
+
+
+// Get the time a stopped Rocket Pool service's container finished
+func (c *Client) GetServiceFinishedAt(composeFiles []string, serviceName string) (time.Time, error) {
+
+    // Get service container
+    cmd, err := c.compose(composeFiles, fmt.Sprintf("ps -q %s", shellQuote(serviceName)))
+    if err != nil { return time.Time{}, err }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("Could not get the %s service container: %w", serviceName, err)
+    }
+    containerIds := strings.Fields(string(output))
+    if len(containerIds) == 0 {
+        return time.Time{}, fmt.Errorf("The %s service has no container.", serviceName)
+    }
+
+    // Get container finish time
+    output, err = c.readOutput(fmt.Sprintf("docker inspect --format '{{.State.FinishedAt}}' %s", shellQuote(containerIds[0])))
+    if err != nil {
+        return time.Time{}, fmt.Errorf("Could not inspect the %s service container: %w", serviceName, err)
+    }
+    finishedAt, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(output)))
+    if err != nil {
+        return time.Time{}, fmt.Errorf("Could not parse the %s service container's finish time: %w", serviceName, err)
+    }
+    return finishedAt, nil
+
+}
+
+
 // Print the Rocket Pool service logs
 func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNames ...string) error {
+    if err := validateLogTail(tail); err != nil {
+        return err
+    }
     sanitizedStrings := make([]string, len(serviceNames))
     for i, serviceName := range serviceNames {
-        sanitizedStrings[i] = fmt.Sprintf("%q", serviceName)
+        sanitizedStrings[i] = shellQuote(serviceName)
     }
-    cmd, err := c.compose(composeFiles, fmt.Sprintf("logs -f --tail %q %s", tail, strings.Join(sanitizedStrings, " ")))
-    if err != nil { return err }
-    return c.printOutput(cmd)
+    services := strings.Join(sanitizedStrings, " ")
+
+    // Resume following logs from the last output seen if the connection is lost
+    return c.streamOutput(func(since time.Time) (string, error) {
+        if since.IsZero() {
+            return c.compose(composeFiles, fmt.Sprintf("logs -f --tail %s %s", tail, services))
+        }
+        return c.compose(composeFiles, fmt.Sprintf("logs -f --since %s %s", since.UTC().Format(time.RFC3339Nano), services))
+    })
+}
+
+
+// Get the most recent Rocket Pool service logs, without following them
+func (c *Client) GetServiceLogs(composeFiles []string, tail string) ([]byte, error) {
+    if err := validateLogTail(tail); err != nil {
+        return nil, err
+    }
+    cmd, err := c.compose(composeFiles, fmt.Sprintf("logs --no-color --tail %s", tail))
+    if err != nil { return nil, err }
+    output, err := c.readOutput(cmd)
+    if err != nil {
+        return nil, fmt.Errorf("Could not get Rocket Pool service logs: %w", err)
+    }
+    return output, nil
+}
+
+
+// Check that a log tail is a number of lines or 'all'
+func validateLogTail(tail string) error {
+    if tail == "all" {
+        return nil
+    }
+    if _, err := strconv.ParseUint(tail, 10, 64); err != nil {
+        return fmt.Errorf("Invalid log tail '%s' - must be a number of lines or 'all'", tail)
+    }
+    return nil
 }
 
 
@@ -293,7 +934,10 @@ func (c *Client) PrintServiceStats(composeFiles []string) error {
     containerIds := strings.Split(strings.TrimSpace(string(containers)), "\n")
 
     // Print stats
-    return c.printOutput(fmt.Sprintf("docker stats %s", strings.Join(containerIds, " ")))
+    statsCmd := fmt.Sprintf("docker stats %s", strings.Join(containerIds, " "))
+    return c.streamOutput(func(time.Time) (string, error) {
+        return statsCmd, nil
+    })
 
 }
 
@@ -337,9 +981,42 @@ func (c *Client) GetServiceVersion() (string, error) {
 }
 
 
-// Increments the custom nonce parameter.
-// This is used for calls that involve multiple transactions, so they don't all have the same nonce.
-func (c *Client) IncrementCustomNonce() {
+// Check whether the Rocket Pool service is already installed at a version
+// Returns false if the target version is "latest" or the installed version can't be determined
+func (c *Client) IsServiceVersion(version string) (bool, error) {
+
+    // The latest version can't be compared without resolving it
+    if version == "" || version == "latest" {
+        return false, nil
+    }
+
+    // Parse target version
+    targetVersion, err := semver.ParseTolerant(version)
+    if err != nil {
+        return false, fmt.Errorf("Could not parse Rocket Pool service version number '%s': %w", version, err)
+    }
+
+    // Get installed version; the service may not be installed
+    serviceVersionString, err := c.GetServiceVersion()
+    if err != nil {
+        return false, nil
+    }
+    serviceVersion, err := semver.Make(serviceVersionString)
+    if err != nil {
+        return false, nil
+    }
+
+    // Compare versions
+    return serviceVersion.Equals(targetVersion), nil
+
+}
+
+
+// Increments the custom nonce parameter.
+// This is used for calls that involve multiple transactions, so they don't all have the same nonce.
+func (c *Client) IncrementCustomNonce() {
+    c.nonceLock.Lock()
+    defer c.nonceLock.Unlock()
     c.customNonce += 1
 }
 
@@ -350,53 +1027,324 @@ func (c *Client) loadConfig(path string) (config.RocketPoolConfig, error) {
     if err != nil {
         return config.RocketPoolConfig{}, err
     }
+    unlock, err := lockConfig(expandedPath, false)
+    if err != nil {
+        return config.RocketPoolConfig{}, err
+    }
+    defer unlock()
+    return readConfigFile(path, expandedPath)
+}
+
+
+// Read & parse a config file without locking it
+func readConfigFile(path, expandedPath string) (config.RocketPoolConfig, error) {
     configBytes, err := ioutil.ReadFile(expandedPath)
     if err != nil {
         return config.RocketPoolConfig{}, fmt.Errorf("Could not read Rocket Pool config at %q: %w", path, err)
     }
-    return config.Parse(configBytes)
+    return config.ParseAs(configBytes, config.DetectFormat(path))
 }
 
 
-// Save a config file
+// Save a config file in the format of its extension
+// The file is locked against concurrent writers and replaced atomically, so readers never see a partially written config
 func (c *Client) saveConfig(cfg config.RocketPoolConfig, path string) error {
-    configBytes, err := cfg.Serialize()
+
+    // Lock config
+    expandedPath, err := homedir.Expand(path)
     if err != nil {
         return err
     }
-    expandedPath, err := homedir.Expand(path)
+    unlock, err := lockConfig(expandedPath, true)
+    if err != nil {
+        return err
+    }
+    defer unlock()
+
+    // Write config
+    return writeConfigFile(cfg, path, expandedPath)
+
+}
+
+
+// Write a config file without locking it
+func writeConfigFile(cfg config.RocketPoolConfig, path, expandedPath string) error {
+
+    // Serialize config, keeping the existing file's permissions
+    var mode os.FileMode = 0644
+    if info, err := os.Stat(expandedPath); err == nil {
+        mode = info.Mode().Perm()
+    }
+    configBytes, err := cfg.SerializeAs(config.DetectFormat(path))
     if err != nil {
         return err
     }
-    if err := ioutil.WriteFile(expandedPath, configBytes, 0); err != nil {
+
+    // Write to a temporary file and move it into place
+    tempFile, err := ioutil.TempFile(filepath.Dir(expandedPath), filepath.Base(expandedPath) + ".tmp")
+    if err != nil {
+        return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
+    }
+    defer os.Remove(tempFile.Name())
+    if _, err := tempFile.Write(configBytes); err != nil {
+        tempFile.Close()
+        return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
+    }
+    if err := tempFile.Sync(); err != nil {
+        tempFile.Close()
+        return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
+    }
+    if err := tempFile.Close(); err != nil {
+        return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
+    }
+    if err := os.Chmod(tempFile.Name(), mode); err != nil {
+        return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
+    }
+    if err := os.Rename(tempFile.Name(), expandedPath); err != nil {
         return fmt.Errorf("Could not write Rocket Pool config to %q: %w", expandedPath, err)
     }
     return nil
+
 }
 
 
-// Build a docker-compose command
-func (c *Client) compose(composeFiles []string, args string) (string, error) {
+// Lock a config file for reading (shared) or writing (exclusive), using a lock file next to it
+// Returns a function which releases the lock
+// Reads go ahead unlocked if the lock file can't be created (e.g. in a read-only config directory), as writes are atomic
+func lockConfig(expandedPath string, exclusive bool) (func(), error) {
+    lockPath := expandedPath + ConfigLockSuffix
+    file, err := os.OpenFile(lockPath, os.O_RDWR | os.O_CREATE, 0600)
+    if err != nil {
+        if !exclusive {
+            return func() {}, nil
+        }
+        return nil, fmt.Errorf("Could not open Rocket Pool config lock file %q: %w", lockPath, err)
+    }
+    if err := lockFile(file, exclusive); err != nil {
+        file.Close()
+        return nil, fmt.Errorf("Could not lock Rocket Pool config %q: %w", expandedPath, err)
+    }
+    return func() {
+        unlockFile(file)
+        file.Close()
+    }, nil
+}
+
+
+// Check that an imported chain config selects a client available in the global config
+func validateImportedChain(globalChain, userChain *config.Chain, chainName string) error {
+    if userChain.Client.Selected == "" {
+        return nil
+    }
+    for _, option := range globalChain.Client.Options {
+        if option.ID == userChain.Client.Selected {
+            return nil
+        }
+    }
+    return fmt.Errorf("Could not import config: unknown %s client '%s'", chainName, userChain.Client.Selected)
+}
+
+
+// Warn if any locally pulled image tags have drifted from their pinned digests
+func (c *Client) checkImageDigests() error {
 
     // Cancel if running in non-docker mode
     if c.daemonPath != "" {
-        return "", errors.New("Command unavailable with '--daemon-path' option specified.")
+        return nil
     }
 
     // Load config
     cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+
+    if err := cfg.ValidateImageDigests(); err != nil {
+        return err
+    }
+
+    // Compare the digests of the locally pulled image tags; skip images which have not been pulled or are not pinned
+    for image, digest := range getServiceImages(cfg) {
+        if digest == "" {
+            continue
+        }
+        repoDigests, err := c.getImageRepoDigests(image)
+        if err != nil {
+            continue
+        }
+        if !strings.Contains(repoDigests, "@" + digest) {
+            fmt.Fprintf(c.stdout, "%sWARNING: The image %s has drifted from its pinned digest %s; the pinned digest will be used.%s\n", colorYellow, image, digest, colorReset)
+        }
+    }
+    return nil
+
+}
+
+
+// Verify the service images against a manifest of expected digests
+// Fails if an image is missing from the manifest, is pinned to another digest, or its tag resolves to another digest
+func (c *Client) VerifyServiceImages(manifestPath string) error {
+
+    // Cancel if running in non-docker mode
+    if c.daemonPath != "" {
+        return errors.New("Command unavailable with '--daemon-path' option specified.")
+    }
+
+    // Load config
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return err
+    }
+    if err := cfg.ValidateImageDigests(); err != nil {
+        return err
+    }
+
+    // Load manifest
+    if manifestPath == "" {
+        manifestPath = fmt.Sprintf("%s/%s", c.configPath, ImageManifestFile)
+    }
+    expandedPath, err := homedir.Expand(manifestPath)
+    if err != nil {
+        return err
+    }
+    manifestBytes, err := ioutil.ReadFile(expandedPath)
+    if err != nil {
+        return fmt.Errorf("Could not read image manifest at %q: %w", manifestPath, err)
+    }
+
+    // Verify manifest signature
+    signatureBytes, err := ioutil.ReadFile(expandedPath + ImageManifestSignatureSuffix)
+    if err != nil {
+        return fmt.Errorf("Could not read image manifest signature at %q: %w", manifestPath + ImageManifestSignatureSuffix, err)
+    }
+    if err := verifyImageManifestSignature(manifestBytes, signatureBytes); err != nil {
+        return err
+    }
+    var manifest ImageManifest
+    if err := yaml.Unmarshal(manifestBytes, &manifest); err != nil {
+        return fmt.Errorf("Could not parse image manifest at %q: %w", manifestPath, err)
+    }
+
+    // Check images
+    mismatches := []string{}
+    for image, pinnedDigest := range getServiceImages(cfg) {
+
+        // Get expected digest
+        expectedDigest, ok := manifest.Images[image]
+        if !ok {
+            mismatches = append(mismatches, fmt.Sprintf("%s is not listed in the image manifest", image))
+            continue
+        }
+        if err := config.ValidateImageDigest(expectedDigest); err != nil {
+            return fmt.Errorf("Invalid digest for image %s in the image manifest: %w", image, err)
+        }
+
+        // Check pinned digest
+        if pinnedDigest != "" {
+            if pinnedDigest != expectedDigest {
+                mismatches = append(mismatches, fmt.Sprintf("%s is pinned to %s but the manifest expects %s", image, pinnedDigest, expectedDigest))
+            }
+            continue
+        }
+
+        // Check the digest the tag resolves to, pulling the image if required
+        repoDigests, err := c.getImageRepoDigests(image)
+        if err != nil {
+            if _, err := c.readOutput(fmt.Sprintf("docker pull -q %q", image)); err != nil {
+                return fmt.Errorf("Could not pull image %s: %w", image, err)
+            }
+            if repoDigests, err = c.getImageRepoDigests(image); err != nil {
+                return fmt.Errorf("Could not inspect image %s: %w", image, err)
+            }
+        }
+        if !strings.Contains(repoDigests, "@" + expectedDigest) {
+            mismatches = append(mismatches, fmt.Sprintf("%s resolves to %s but the manifest expects %s", image, strings.TrimSpace(repoDigests), expectedDigest))
+        }
+
+    }
+
+    // Return
+    if len(mismatches) > 0 {
+        return fmt.Errorf("Image verification failed:\n  %s", strings.Join(mismatches, "\n  "))
+    }
+    return nil
+
+}
+
+
+// Verify an image manifest's hex-encoded signature against the release public key
+func verifyImageManifestSignature(manifestBytes, signatureBytes []byte) error {
+    if ImageManifestPublicKey == "" {
+        return errors.New("This build has no image manifest signing key, so image manifests can't be verified.")
+    }
+    publicKey, err := hex.DecodeString(ImageManifestPublicKey)
+    if err != nil || len(publicKey) != ed25519.PublicKeySize {
+        return errors.New("This build has an invalid image manifest signing key.")
+    }
+    signature, err := hex.DecodeString(strings.TrimSpace(string(signatureBytes)))
+    if err != nil || len(signature) != ed25519.SignatureSize {
+        return errors.New("The image manifest signature is invalid.")
+    }
+    if !ed25519.Verify(ed25519.PublicKey(publicKey), manifestBytes, signature) {
+        return errors.New("The image manifest signature does not match; the manifest was not signed for a Rocket Pool release.")
+    }
+    return nil
+}
+
+
+// Get the images used by the selected clients, mapped to their pinned digests (empty if unpinned)
+func getServiceImages(cfg config.RocketPoolConfig) map[string]string {
+    images := []string{cfg.Smartnode.Image}
+    if eth1Client := cfg.GetSelectedEth1Client(); eth1Client != nil {
+        images = append(images, eth1Client.Image)
+    }
+    if eth2Client := cfg.GetSelectedEth2Client(); eth2Client != nil {
+        images = append(images, eth2Client.GetBeaconImage())
+    }
+    if validatorClient := cfg.GetSelectedValidatorClient(); validatorClient != nil {
+        images = append(images, validatorClient.GetValidatorImage())
+    }
+    digests := map[string]string{}
+    for _, image := range images {
+        if image != "" {
+            digests[image] = cfg.Smartnode.ImageDigests[image]
+        }
+    }
+    return digests
+}
+
+
+// Get the repo digests of a locally pulled image
+func (c *Client) getImageRepoDigests(image string) (string, error) {
+    repoDigests, err := c.readOutput(fmt.Sprintf("docker image inspect --format '{{join .RepoDigests \" \"}}' %q", image))
     if err != nil {
         return "", err
     }
+    return string(repoDigests), nil
+}
+
+
+// Get the environment variables to run docker-compose with
+func (c *Client) getComposeEnv() ([]string, error) {
+
+    // Load config
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return []string{}, err
+    }
 
     // Check config
     eth1Client := cfg.GetSelectedEth1Client()
     eth2Client := cfg.GetSelectedEth2Client()
     if eth1Client == nil {
-        return "", errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
+        return []string{}, errors.New("No Eth 1.0 client selected. Please run 'rocketpool service config' and try again.")
     }
     if eth2Client == nil {
-        return "", errors.New("No Eth 2.0 client selected. Please run 'rocketpool service config' and try again.")
+        return []string{}, errors.New("No Eth 2.0 client selected. Please run 'rocketpool service config' and try again.")
+    }
+    validatorClient := cfg.GetSelectedValidatorClient()
+    if validatorClient == nil {
+        return []string{}, fmt.Errorf("Unknown validator client [%s] selected. Please run 'rocketpool service config' and try again.", cfg.Chains.Eth2.Client.SelectedValidator)
     }
 
     // Make sure the selected eth2 is compatible with the selected eth1
@@ -412,21 +1360,54 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
             }
         }
     }
-    if !isCompatible {
-        return "", fmt.Errorf("Eth 2.0 client [%s] is incompatible with Eth 1.0 client [%s]. Please run 'rocketpool service config' and select compatible clients.", eth2Client.Name, eth1Client.Name)
+    if !isCompatible && c.skipCompatCheck {
+        c.compatWarningOnce.Do(func() {
+            fmt.Fprintf(c.stderr, "%sWARNING: Eth 2.0 client [%s] is not listed as compatible with Eth 1.0 client [%s]; continuing because the compatibility check is disabled.%s\n", colorYellow, eth2Client.Name, eth1Client.Name, colorReset)
+        })
+    } else if !isCompatible {
+        return []string{}, fmt.Errorf("Eth 2.0 client [%s] is incompatible with Eth 1.0 client [%s]. Please run 'rocketpool service config' and select compatible clients.", eth2Client.Name, eth1Client.Name)
+    }
+
+    // Make sure the selected validator client can run against the selected beacon client
+    if !eth2Client.SupportsValidatorClient(validatorClient.ID) {
+        return []string{}, fmt.Errorf("Validator client [%s] is not supported with beacon client [%s]. Please run 'rocketpool service config' and select compatible clients.", validatorClient.Name, eth2Client.Name)
+    }
+
+    // Resolve provider secrets; they are only passed to docker-compose, never saved
+    expandedConfigPath, err := homedir.Expand(c.configPath)
+    if err != nil {
+        return []string{}, err
+    }
+    if err := cfg.ResolveSecrets(expandedConfigPath); err != nil {
+        return []string{}, err
+    }
+
+    // Check project name & pinned image digests
+    if err := config.ValidateProjectName(cfg.Smartnode.ProjectName); err != nil {
+        return []string{}, fmt.Errorf("%s Please correct your user settings and try again.", err.Error())
+    }
+    if err := cfg.ValidateImageDigests(); err != nil {
+        return []string{}, fmt.Errorf("%s Please correct your user settings and try again.", err.Error())
+    }
+
+    // Get validator graffiti
+    graffiti, err := cfg.GetGraffiti()
+    if err != nil {
+        return []string{}, fmt.Errorf("%s Please run 'rocketpool service config' and try again.", err.Error())
     }
 
     // Set environment variables from config
     env := []string{
         fmt.Sprintf("COMPOSE_PROJECT_NAME=%q",    cfg.Smartnode.ProjectName),
         fmt.Sprintf("ROCKET_POOL_VERSION=%q",     cfg.Smartnode.GraffitiVersion),
-        fmt.Sprintf("SMARTNODE_IMAGE=%q",         cfg.Smartnode.Image),
+        fmt.Sprintf("GRAFFITI=%q",                graffiti),
+        fmt.Sprintf("SMARTNODE_IMAGE=%q",         cfg.GetPinnedImage(cfg.Smartnode.Image)),
         fmt.Sprintf("ETH1_CLIENT=%q",             cfg.GetSelectedEth1Client().ID),
-        fmt.Sprintf("ETH1_IMAGE=%q",              cfg.GetSelectedEth1Client().Image),
-        fmt.Sprintf("ETH2_CLIENT=%q",             cfg.GetSelectedEth2Client().ID),
-        fmt.Sprintf("ETH2_IMAGE=%q",              cfg.GetSelectedEth2Client().GetBeaconImage()),
-        fmt.Sprintf("VALIDATOR_CLIENT=%q",        cfg.GetSelectedEth2Client().ID),
-        fmt.Sprintf("VALIDATOR_IMAGE=%q",         cfg.GetSelectedEth2Client().GetValidatorImage()),
+        fmt.Sprintf("ETH1_IMAGE=%q",              cfg.GetPinnedImage(cfg.GetSelectedEth1Client().Image)),
+        fmt.Sprintf("ETH2_CLIENT=%q",             eth2Client.ID),
+        fmt.Sprintf("ETH2_IMAGE=%q",              cfg.GetPinnedImage(eth2Client.GetBeaconImage())),
+        fmt.Sprintf("VALIDATOR_CLIENT=%q",        validatorClient.ID),
+        fmt.Sprintf("VALIDATOR_IMAGE=%q",         cfg.GetPinnedImage(validatorClient.GetValidatorImage())),
         fmt.Sprintf("ETH1_PROVIDER=%q",           cfg.Chains.Eth1.Provider),
         fmt.Sprintf("ETH1_WS_PROVIDER=%q",        cfg.Chains.Eth1.WsProvider),
         fmt.Sprintf("ETH2_PROVIDER=%q",           cfg.Chains.Eth2.Provider),
@@ -451,6 +1432,41 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
         if _, ok := paramsSet[param.Env]; ok { continue }
         if param.Default == "" { continue }
         env = append(env, fmt.Sprintf("%s=%q", param.Env, param.Default))
+        paramsSet[param.Env] = true
+    }
+    if validatorClient.ID != eth2Client.ID {
+        for _, param := range validatorClient.Params {
+            if _, ok := paramsSet[param.Env]; ok { continue }
+            if param.Default == "" { continue }
+            env = append(env, fmt.Sprintf("%s=%q", param.Env, param.Default))
+        }
+    }
+
+    // Return
+    return env, nil
+
+}
+
+
+// Build a docker-compose command
+func (c *Client) compose(composeFiles []string, args string) (string, error) {
+
+    // Cancel if running in non-docker mode
+    if c.daemonPath != "" {
+        return "", errors.New("Command unavailable with '--daemon-path' option specified.")
+    }
+
+    // Check the overridden project exists, unless it is being brought up
+    if c.projectName != "" && !strings.HasPrefix(args, "up") {
+        if err := c.checkProjectStack(); err != nil {
+            return "", err
+        }
+    }
+
+    // Get environment variables
+    env, err := c.getComposeEnv()
+    if err != nil {
+        return "", err
     }
 
     // Set compose file flags
@@ -459,7 +1475,15 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
     if err != nil {
         return "", err
     }
-    composeFileFlags[0] = fmt.Sprintf("-f \"%s/%s\"", expandedConfigPath, ComposeFile)
+    baseComposeFile := fmt.Sprintf("%s/%s", expandedConfigPath, ComposeFile)
+    exists, err := c.hostFileExists(baseComposeFile)
+    if err != nil {
+        return "", err
+    }
+    if !exists {
+        return "", fmt.Errorf("Rocket Pool compose file not found at %s; run 'rocketpool service install'.", baseComposeFile)
+    }
+    composeFileFlags[0] = fmt.Sprintf("-f %q", baseComposeFile)
     for fi, composeFile := range composeFiles {
         expandedFile, err := homedir.Expand(composeFile)
         if err != nil {
@@ -468,21 +1492,324 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
         composeFileFlags[fi + 1] = fmt.Sprintf("-f %q", expandedFile)
     }
 
+    // Add extra compose files from config
+    extraComposeFiles, err := c.getExtraComposeFiles(expandedConfigPath)
+    if err != nil {
+        return "", err
+    }
+    for _, extraComposeFile := range extraComposeFiles {
+        composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %q", extraComposeFile))
+    }
+
     // Return command
     return fmt.Sprintf("%s docker-compose --project-directory %q %s %s", strings.Join(env, " "), expandedConfigPath, strings.Join(composeFileFlags, " "), args), nil
 
 }
 
 
+// Get the extra compose files from config
+// Relative paths are resolved against the config path; all files must exist on the Rocket Pool host
+func (c *Client) getExtraComposeFiles(expandedConfigPath string) ([]string, error) {
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return []string{}, err
+    }
+    extraComposeFiles := []string{}
+    for _, extraComposeFile := range cfg.Smartnode.ExtraComposeFiles {
+        expandedFile, err := homedir.Expand(extraComposeFile)
+        if err != nil {
+            return []string{}, err
+        }
+        if !filepath.IsAbs(expandedFile) {
+            expandedFile = filepath.Join(expandedConfigPath, expandedFile)
+        }
+        exists, err := c.hostFileExists(expandedFile)
+        if err != nil {
+            return []string{}, err
+        }
+        if !exists {
+            return []string{}, fmt.Errorf("Extra compose file '%s' does not exist. Please create it or remove it from the extraComposeFiles setting.", expandedFile)
+        }
+        extraComposeFiles = append(extraComposeFiles, expandedFile)
+    }
+    return extraComposeFiles, nil
+}
+
+
+// Get the available disk space in bytes for the Rocket Pool config directory on the Rocket Pool host
+// If the config directory does not exist yet (e.g. before installation), its closest existing parent directory is checked
+func (c *Client) GetDiskSpace() (uint64, error) {
+    expandedConfigPath, err := homedir.Expand(c.configPath)
+    if err != nil {
+        return 0, err
+    }
+    output, err := c.readOutput(fmt.Sprintf(`p=%q; while [ ! -e "$p" ]; do p="$(dirname "$p")"; done; df -Pk "$p"`, expandedConfigPath))
+    if err != nil {
+        return 0, fmt.Errorf("Could not get available disk space: %w", err)
+    }
+
+    // Parse the available space (in kilobytes) from the last line of df output
+    lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+    fields := strings.Fields(lines[len(lines) - 1])
+    if len(fields) < 4 {
+        return 0, fmt.Errorf("Could not parse available disk space from '%s'", strings.TrimSpace(string(output)))
+    }
+    availableKb, err := strconv.ParseUint(fields[3], 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse available disk space '%s': %w", fields[3], err)
+    }
+    return availableKb * 1024, nil
+}
+
+
+// Delete the Rocket Pool config & data directory on the Rocket Pool host
+func (c *Client) DeleteConfigDirectory() error {
+    expandedConfigPath, err := homedir.Expand(c.configPath)
+    if err != nil {
+        return err
+    }
+    cleanPath := filepath.Clean(expandedConfigPath)
+    if cleanPath == "/" || cleanPath == "." || cleanPath == filepath.Dir(cleanPath) {
+        return fmt.Errorf("Refusing to delete config directory '%s'.", expandedConfigPath)
+    }
+
+    // Refuse to delete the user's home directory on the Rocket Pool host
+    homeOutput, err := c.readOutput(`echo "$HOME"`)
+    if err != nil {
+        return fmt.Errorf("Could not get the home directory: %w", err)
+    }
+    if home := strings.TrimSpace(string(homeOutput)); home == "" || filepath.Clean(home) == cleanPath {
+        return fmt.Errorf("Refusing to delete config directory '%s' as it is the home directory.", expandedConfigPath)
+    }
+
+    // Only delete the directory if it holds the files installed with the Rocket Pool service
+    for _, markerFile := range []string{GlobalConfigFile, ComposeFile} {
+        exists, err := c.hostFileExists(filepath.Join(cleanPath, markerFile))
+        if err != nil {
+            return fmt.Errorf("Could not check config directory '%s': %w", cleanPath, err)
+        }
+        if !exists {
+            return fmt.Errorf("Refusing to delete config directory '%s' as it does not contain %s and may not be a Rocket Pool config directory.", cleanPath, markerFile)
+        }
+    }
+
+    // Delete config directory
+    if _, err := c.readOutput(fmt.Sprintf("rm -rf %s", shellQuote(cleanPath))); err != nil {
+        return fmt.Errorf("Could not delete config directory '%s': %w", cleanPath, err)
+    }
+    return nil
+}
+
+
+// Check whether a file exists on the Rocket Pool host
+func (c *Client) hostFileExists(path string) (bool, error) {
+    output, err := c.readOutput(fmt.Sprintf("if [ -f %q ]; then echo true; else echo false; fi", path))
+    if err != nil {
+        return false, err
+    }
+    return strings.TrimSpace(string(output)) == "true", nil
+}
+
+
+// Get the docker-compose environment variables for the Rocket Pool service, with secrets masked
+func (c *Client) GetServiceEnv() ([]string, error) {
+
+    // Cancel if running in non-docker mode
+    if c.daemonPath != "" {
+        return []string{}, errors.New("Command unavailable with '--daemon-path' option specified.")
+    }
+
+    // Get environment variables
+    env, err := c.getComposeEnv()
+    if err != nil {
+        return []string{}, err
+    }
+
+    // Mask secrets
+    for ei, variable := range env {
+        env[ei] = maskEnvVariable(variable)
+    }
+    return env, nil
+
+}
+
+
+// Load the merged config with secrets masked
+// Also returns a replacer which masks the same secret values in other output, such as the service logs
+func (c *Client) LoadRedactedConfig() (config.RocketPoolConfig, *strings.Replacer, error) {
+
+    // Load config
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return config.RocketPoolConfig{}, nil, err
+    }
+    expandedConfigPath, err := homedir.Expand(c.configPath)
+    if err != nil {
+        return config.RocketPoolConfig{}, nil, err
+    }
+
+    // Resolve secrets on a copy; the resolved providers are passed to the containers, so they may appear in their logs
+    resolvedCfg := cfg
+    secretValues, err := resolvedCfg.ResolveSecretValues(expandedConfigPath)
+    if err != nil {
+        return config.RocketPoolConfig{}, nil, err
+    }
+
+    // Mask resolved providers & secret values in other output
+    // Whole providers are replaced before the secret values within them, so they keep their scheme & host
+    replacements := []string{}
+    addReplacement := func(value, masked string) {
+        if value != "" && value != masked {
+            replacements = append(replacements, value, masked)
+        }
+    }
+    for _, chain := range []*config.Chain{&(resolvedCfg.Chains.Eth1), &(resolvedCfg.Chains.Eth2)} {
+        for _, provider := range []string{chain.Provider, chain.FallbackProvider, chain.WsProvider, chain.MainnetProvider} {
+            addReplacement(provider, maskUrl(provider))
+        }
+        addReplacement(chain.ProviderToken, MaskedValue)
+    }
+    for _, value := range secretValues {
+        addReplacement(value, MaskedValue)
+    }
+
+    // Mask secrets
+    maskConfigSecrets(&cfg, func(setting *string, masked string) {
+        if *setting == "" || *setting == masked {
+            return
+        }
+        addReplacement(*setting, masked)
+        *setting = masked
+    })
+
+    // Return
+    return cfg, strings.NewReplacer(replacements...), nil
+
+}
+
+
+// Mask the credentials in a config's provider, client & notification settings
+// The mask function is passed each setting with its masked value
+func maskConfigSecrets(cfg *config.RocketPoolConfig, mask func(setting *string, masked string)) {
+    for _, chain := range []*config.Chain{&(cfg.Chains.Eth1), &(cfg.Chains.Eth2)} {
+        for _, provider := range []*string{&chain.Provider, &chain.FallbackProvider, &chain.WsProvider, &chain.MainnetProvider} {
+            mask(provider, maskUrl(*provider))
+        }
+        mask(&chain.ProviderToken, MaskedValue)
+        for name, value := range chain.ProviderHeaders {
+            mask(&value, MaskedValue)
+            chain.ProviderHeaders[name] = value
+        }
+        for pi := range chain.Client.Params {
+            param := &(chain.Client.Params[pi])
+            mask(&param.Value, maskEnvValue(param.Env, param.Value))
+        }
+    }
+    mask(&cfg.Smartnode.WebhookUrl, maskUrl(cfg.Smartnode.WebhookUrl))
+    mask(&cfg.Smartnode.WebhookSecret, MaskedValue)
+    mask(&cfg.Smartnode.DiscordWebhookUrl, maskUrl(cfg.Smartnode.DiscordWebhookUrl))
+    mask(&cfg.Smartnode.TelegramBotToken, MaskedValue)
+}
+
+
+// Mask secret values in a quoted environment variable
+func maskEnvVariable(variable string) string {
+    parts := strings.SplitN(variable, "=", 2)
+    if len(parts) != 2 {
+        return variable
+    }
+    value, err := strconv.Unquote(parts[1])
+    if err != nil || value == "" {
+        return variable
+    }
+    masked := maskEnvValue(parts[0], value)
+    if masked == value {
+        return variable
+    }
+    return fmt.Sprintf("%s=%q", parts[0], masked)
+}
+
+
+// Mask an environment variable value if its name indicates a secret
+// Provider URLs keep their scheme & host so they can still be diagnosed
+func maskEnvValue(name, value string) string {
+    if value == "" {
+        return value
+    }
+    upperName := strings.ToUpper(name)
+    if strings.HasSuffix(upperName, "PROVIDER") {
+        providerUrl, err := url.Parse(value)
+        if err == nil && providerUrl.Host != "" {
+            return maskUrl(value)
+        }
+    }
+    for _, secretName := range SecretEnvNames {
+        if strings.Contains(upperName, secretName) {
+            return MaskedValue
+        }
+    }
+    return value
+}
+
+
+// Mask the credentials, path & query of a URL, keeping its scheme & host
+// Values which can't be parsed as a URL are masked entirely
+func maskUrl(value string) string {
+    if value == "" {
+        return value
+    }
+    parsedUrl, err := url.Parse(value)
+    if err != nil || parsedUrl.Host == "" {
+        return MaskedValue
+    }
+    if parsedUrl.User != nil || strings.Trim(parsedUrl.Path, "/") != "" || parsedUrl.RawQuery != "" {
+        return fmt.Sprintf("%s://%s/%s", parsedUrl.Scheme, parsedUrl.Host, MaskedValue)
+    }
+    return value
+}
+
+
 // Call the Rocket Pool API
+// The response is checked against the standard API response envelope, and error responses are returned as errors
 func (c *Client) callAPI(args string) ([]byte, error) {
+    responseBytes, err := c.callAPIRaw(args)
+    if err != nil {
+        return []byte{}, err
+    }
+    var response api.APIResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return []byte{}, fmt.Errorf("Invalid API response: %s", strings.TrimSpace(string(responseBytes)))
+    }
+    if response.Status == "error" {
+        if response.ErrorCode != "" {
+            return []byte{}, api.NewCodedError(response.ErrorCode, response.Error)
+        }
+        return []byte{}, errors.New(response.Error)
+    }
+    return responseBytes, nil
+}
+
+
+// Call the Rocket Pool API and return its raw response
+func (c *Client) callAPIRaw(args string) ([]byte, error) {
     var cmd string
     if c.daemonPath == "" {
         containerName, err := c.getAPIContainerName()
         if err != nil {
             return []byte{}, err
         }
-        cmd = fmt.Sprintf("docker exec %q %q %s %s api %s", containerName, APIBinPath, c.getGasOpts(), c.getCustomNonce(), args)
+        apiMode, err := c.getAPIMode(containerName)
+        if err != nil {
+            return []byte{}, err
+        }
+        if apiMode == APIModeRun {
+            cmd, err = c.getAPIRunCommand(args)
+            if err != nil {
+                return []byte{}, err
+            }
+        } else {
+            cmd = fmt.Sprintf("docker exec %q %q %s %s api %s", containerName, APIBinPath, c.getGasOpts(), c.getCustomNonce(), args)
+        }
     } else {
         cmd = fmt.Sprintf("%s --config %q --settings %q %s %s api %s", c.daemonPath, fmt.Sprintf("%s/%s", c.configPath, GlobalConfigFile), fmt.Sprintf("%s/%s", c.configPath, UserConfigFile), c.getGasOpts(), c.getCustomNonce(), args)
     }
@@ -499,10 +1826,78 @@ func (c *Client) getAPIContainerName() (string, error) {
     if cfg.Smartnode.ProjectName == "" {
       return "", errors.New("Rocket Pool docker project name not set")
     }
+    if err := config.ValidateProjectName(cfg.Smartnode.ProjectName); err != nil {
+        return "", err
+    }
+    if c.projectName != "" {
+        if err := c.checkProjectStack(); err != nil {
+            return "", err
+        }
+    }
     return cfg.Smartnode.ProjectName + APIContainerSuffix, nil
 }
 
 
+// Get the mode to call the API in
+// In auto mode, the API is run in a one-shot container if the API container is not running
+func (c *Client) getAPIMode(containerName string) (string, error) {
+    c.apiModeLock.Lock()
+    defer c.apiModeLock.Unlock()
+    if c.apiMode != APIModeAuto {
+        return c.apiMode, nil
+    }
+    output, err := c.readOutput(fmt.Sprintf("docker ps -q --filter %q --filter status=running", fmt.Sprintf("name=^%s$", containerName)))
+    if err != nil {
+        return "", fmt.Errorf("Could not check whether the API container is running: %w", err)
+    }
+    if strings.TrimSpace(string(output)) == "" {
+        c.apiMode = APIModeRun
+    } else {
+        c.apiMode = APIModeExec
+    }
+    return c.apiMode, nil
+}
+
+
+// Get the command to run the API in a one-shot container, with the config directory mounted
+func (c *Client) getAPIRunCommand(args string) (string, error) {
+    cfg, err := c.LoadMergedConfig()
+    if err != nil {
+        return "", err
+    }
+    if err := config.ValidateProjectName(cfg.Smartnode.ProjectName); err != nil {
+        return "", err
+    }
+    if err := cfg.ValidateImageDigests(); err != nil {
+        return "", err
+    }
+    expandedConfigPath, err := homedir.Expand(c.configPath)
+    if err != nil {
+        return "", err
+    }
+    return fmt.Sprintf("docker run --rm --network %q -v %q %q %s %s api %s",
+        cfg.Smartnode.ProjectName + ComposeNetworkSuffix,
+        fmt.Sprintf("%s:%s", expandedConfigPath, APIContainerConfigPath),
+        cfg.GetPinnedImage(cfg.Smartnode.Image),
+        c.getGasOpts(),
+        c.getCustomNonce(),
+        args), nil
+}
+
+
+// Check that a docker compose stack exists for the overridden project name
+func (c *Client) checkProjectStack() error {
+    output, err := c.readOutput(fmt.Sprintf("docker ps -a -q --filter %q", fmt.Sprintf("label=%s=%s", ComposeProjectLabel, c.projectName)))
+    if err != nil {
+        return fmt.Errorf("Could not check for Rocket Pool project '%s': %w", c.projectName, err)
+    }
+    if strings.TrimSpace(string(output)) == "" {
+        return fmt.Errorf("No Rocket Pool stack was found for project '%s'. Please check the '--project' option.", c.projectName)
+    }
+    return nil
+}
+
+
 // Get gas price & limit flags
 func (c *Client) getGasOpts() string {
     var opts string
@@ -517,28 +1912,34 @@ func (c *Client) getGasOpts() string {
 
 
 func (c *Client) getCustomNonce() string {
+    // Take a snapshot of the custom nonce
+    c.nonceLock.Lock()
+    customNonce := c.customNonce
+    c.nonceLock.Unlock()
+
     // Set the custom nonce
     nonce := ""
-    if c.customNonce != 0 {
-        nonce = fmt.Sprintf("--nonce %d", c.customNonce)
+    if customNonce != 0 {
+        nonce = fmt.Sprintf("--nonce %d", customNonce)
     }
     return nonce
 }
 
 
 // Get the first downloader available to the system
+// Downloads are retried on failure; the output file path and URL must be appended
 func (c *Client) getDownloader() (string, error) {
 
     // Check for cURL
     hasCurl, err := c.readOutput("command -v curl")
     if err == nil && len(hasCurl) > 0 {
-        return "curl -sL", nil
+        return "curl -sSfL --retry 5 --retry-delay 2 -o", nil
     }
 
     // Check for wget
     hasWget, err := c.readOutput("command -v wget")
     if err == nil && len(hasWget) > 0 {
-        return "wget -qO-", nil
+        return "wget -q --tries=5 --waitretry=2 -O", nil
     }
 
     // Return error
@@ -549,19 +1950,176 @@ func (c *Client) getDownloader() (string, error) {
 
 // Run a command and print its output
 func (c *Client) printOutput(cmdText string) error {
+    return c.printOutputWithTimeout(cmdText, c.commandTimeout)
+}
+
+
+// Run a long-running command (e.g. following logs) and stream its output in real time, without a timeout
+// The command is stopped cleanly on interrupt, including when run remotely
+// If the SSH connection is lost, it is re-established and the command resumed; getCmdText is passed the time of the last output seen
+func (c *Client) streamOutput(getCmdText func(since time.Time) (string, error)) error {
+    output := &timestampedWriter{writer: c.stdout}
+    var since time.Time
+    for {
+
+        // Get command
+        cmdText, err := getCmdText(since)
+        if err != nil { return err }
+
+        // Run command
+        err = c.streamCommand(cmdText, output)
+        if err == errStreamInterrupted {
+            return nil
+        }
+        if err == nil || c.getSSHClient() == nil {
+            return err
+        }
+
+        // Stop if the command itself failed rather than the connection
+        if _, ok := err.(*ssh.ExitError); ok {
+            return err
+        }
+
+        // Reconnect & resume from the last output seen, in the SSH host's time
+        // The host's clock offset is only precise to a second, so up to a second of output may be repeated
+        if err := c.reconnect(); err != nil {
+            return err
+        }
+        since = output.lastWrite()
+        if !since.IsZero() {
+            offset, err := c.getRemoteClockOffset()
+            if err != nil {
+                return err
+            }
+            since = since.Add(offset)
+        }
+
+    }
+}
+
+
+// Get the offset of the SSH host's clock from the local clock
+// The host's time is read in whole seconds, so the offset may be up to a second early
+func (c *Client) getRemoteClockOffset() (time.Duration, error) {
+    output, err := c.readOutput("date +%s")
+    if err != nil {
+        return 0, fmt.Errorf("Could not get the time on %s: %w", c.sshAddress, err)
+    }
+    remoteTime, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+    if err != nil {
+        return 0, fmt.Errorf("Could not parse the time on %s: %w", c.sshAddress, err)
+    }
+    return time.Unix(remoteTime, 0).Sub(time.Now()), nil
+}
+
+
+// Error returned from streamCommand when the command was interrupted by the user
+var errStreamInterrupted = errors.New("Interrupted")
+
+
+// Run a long-running command and stream its output
+func (c *Client) streamCommand(cmdText string, output io.Writer) error {
 
     // Initialize command
-    cmd, err := c.newCommand(cmdText)
+    cmd, err := c.newCommand(cmdText, 0)
     if err != nil { return err }
     defer cmd.Close()
 
-    // Copy command output to stdout & stderr
-    cmdOut, err := cmd.StdoutPipe()
-    if err != nil { return err }
-    cmdErr, err := cmd.StderrPipe()
+    // Write command output directly to the client's stdout & stderr
+    if err := cmd.RequestPty(); err != nil { return err }
+    cmd.SetOutput(output, c.stderr)
+
+    // Interrupt command on Ctrl-C
+    interrupts := make(chan os.Signal, 1)
+    done := make(chan struct{})
+    var interrupted int32
+    signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+    defer signal.Stop(interrupts)
+    defer close(done)
+    go func() {
+        select {
+            case <-interrupts:
+                atomic.StoreInt32(&interrupted, 1)
+                cmd.Interrupt()
+            case <-done:
+        }
+    }()
+
+    // Detect a dead SSH connection so the command does not hang
+    if client := c.getSSHClient(); client != nil {
+        go keepAlive(client, done)
+    }
+
+    // Run command
+    err = cmd.Run()
+    if atomic.LoadInt32(&interrupted) == 1 {
+        return errStreamInterrupted
+    }
+    return err
+
+}
+
+
+// Send keepalive requests over an SSH connection until done, closing it if the host stops responding
+func keepAlive(client *ssh.Client, done <-chan struct{}) {
+    ticker := time.NewTicker(SSHKeepaliveInterval)
+    defer ticker.Stop()
+    for {
+        select {
+            case <-ticker.C:
+                result := make(chan error, 1)
+                go func() {
+                    _, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+                    result <- err
+                }()
+                select {
+                    case err := <-result:
+                        if err != nil {
+                            client.Close()
+                            return
+                        }
+                    case <-time.After(SSHKeepaliveInterval):
+                        client.Close()
+                        return
+                    case <-done:
+                        return
+                }
+            case <-done:
+                return
+        }
+    }
+}
+
+
+// A writer which records the time of the last write
+type timestampedWriter struct {
+    writer io.Writer
+    lastWriteNano int64
+}
+func (w *timestampedWriter) Write(p []byte) (int, error) {
+    atomic.StoreInt64(&w.lastWriteNano, time.Now().UnixNano())
+    return w.writer.Write(p)
+}
+func (w *timestampedWriter) lastWrite() time.Time {
+    nano := atomic.LoadInt64(&w.lastWriteNano)
+    if nano == 0 {
+        return time.Time{}
+    }
+    return time.Unix(0, nano)
+}
+
+
+// Run a command with a timeout and print its output
+func (c *Client) printOutputWithTimeout(cmdText string, timeout time.Duration) error {
+
+    // Initialize command
+    cmd, err := c.newCommand(cmdText, timeout)
     if err != nil { return err }
-    go io.Copy(os.Stdout, cmdOut)
-    go io.Copy(os.Stderr, cmdErr)
+    defer cmd.Close()
+
+    // Write command output to the client's stdout & stderr
+    // All output is written before the command returns; timed out commands are killed with their child processes, closing the output
+    cmd.SetOutput(c.stdout, c.stderr)
 
     // Run command
     return cmd.Run()
@@ -569,11 +2127,52 @@ func (c *Client) printOutput(cmdText string) error {
 }
 
 
+// Run a command and print its output, or capture its output and only include it in the returned error if quiet is set
+func (c *Client) printOrCaptureOutput(cmdText string, quiet bool) error {
+    if !quiet {
+        return c.printOutput(cmdText)
+    }
+
+    // Initialize command
+    cmd, err := c.newCommand(cmdText, c.commandTimeout)
+    if err != nil { return err }
+    defer cmd.Close()
+
+    // Capture command output
+    output := new(lockedBuffer)
+    cmd.SetOutput(output, output)
+
+    // Run command
+    if err := cmd.Run(); err != nil {
+        return fmt.Errorf("%w\n%s", err, strings.TrimSpace(output.String()))
+    }
+    return nil
+
+}
+
+
+// A buffer which may be written to concurrently
+type lockedBuffer struct {
+    buffer bytes.Buffer
+    lock sync.Mutex
+}
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+    b.lock.Lock()
+    defer b.lock.Unlock()
+    return b.buffer.Write(p)
+}
+func (b *lockedBuffer) String() string {
+    b.lock.Lock()
+    defer b.lock.Unlock()
+    return b.buffer.String()
+}
+
+
 // Run a command and return its output
 func (c *Client) readOutput(cmdText string) ([]byte, error) {
 
     // Initialize command
-    cmd, err := c.newCommand(cmdText)
+    cmd, err := c.newCommand(cmdText, c.commandTimeout)
     if err != nil {
         return []byte{}, err
     }
@@ -0,0 +1,341 @@
+package rocketpool
+
+import (
+    "bytes"
+    "crypto/ed25519"
+    "crypto/rand"
+    "fmt"
+    "io/ioutil"
+    "net"
+    "os"
+    "path/filepath"
+    "strings"
+    "sync"
+    "testing"
+    "time"
+
+    "golang.org/x/crypto/ssh"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// Volumes must only be removed when explicitly requested
+func TestStopServiceArgs(t *testing.T) {
+    if args := getStopServiceArgs(false); strings.Contains(args, "-v") {
+        t.Errorf("Stop service args %q remove volumes by default", args)
+    }
+    if args := getStopServiceArgs(true); args != "down -v" {
+        t.Errorf("Stop service args %q do not remove volumes when requested", args)
+    }
+}
+
+
+// Run with -race to check the custom nonce is synchronized
+func TestCustomNonceConcurrency(t *testing.T) {
+    c := &Client{customNonce: 10}
+
+    // Increment & read the nonce concurrently
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(2)
+        go func() {
+            defer wg.Done()
+            c.IncrementCustomNonce()
+        }()
+        go func() {
+            defer wg.Done()
+            if nonce := c.getCustomNonce(); !strings.HasPrefix(nonce, "--nonce ") {
+                t.Errorf("Unexpected custom nonce flag %q", nonce)
+            }
+        }()
+    }
+    wg.Wait()
+
+    // Check every increment was applied
+    if nonce := c.getCustomNonce(); nonce != fmt.Sprintf("--nonce %d", 60) {
+        t.Errorf("Expected custom nonce flag '--nonce 60', got %q", nonce)
+    }
+}
+
+
+// Run with -race to check the API mode is synchronized
+func TestAPIModeConcurrency(t *testing.T) {
+    c := &Client{apiMode: APIModeExec}
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            mode, err := c.getAPIMode("rocketpool_api")
+            if err != nil {
+                t.Error(err)
+            } else if mode != APIModeExec {
+                t.Errorf("Expected API mode %q, got %q", APIModeExec, mode)
+            }
+        }()
+    }
+    wg.Wait()
+}
+
+
+// Run with -race to check command output buffers may be written to concurrently
+func TestLockedBufferConcurrency(t *testing.T) {
+    var buffer lockedBuffer
+    var wg sync.WaitGroup
+    for i := 0; i < 50; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            fmt.Fprint(&buffer, "x")
+            _ = buffer.String()
+        }()
+    }
+    wg.Wait()
+    if length := len(buffer.String()); length != 50 {
+        t.Errorf("Expected 50 bytes written, got %d", length)
+    }
+}
+
+
+// All command output must be written to the client's writers before the command returns
+func TestPrintOutputWithTimeout(t *testing.T) {
+    for _, timeout := range []time.Duration{0, 10 * time.Second} {
+        var stdout, stderr bytes.Buffer
+        c := &Client{}
+        c.SetOutput(&stdout, &stderr)
+        if err := c.printOutputWithTimeout("for i in 1 2 3; do echo out$i; done; echo err >&2", timeout); err != nil {
+            t.Fatalf("Could not run command with timeout %s: %s", timeout, err)
+        }
+        if output := stdout.String(); output != "out1\nout2\nout3\n" {
+            t.Errorf("Unexpected stdout with timeout %s: %q", timeout, output)
+        }
+        if output := stderr.String(); output != "err\n" {
+            t.Errorf("Unexpected stderr with timeout %s: %q", timeout, output)
+        }
+    }
+}
+
+
+// Secrets resolved into the providers passed to the containers must be masked in their logs
+func TestLoadRedactedConfigMasksResolvedSecrets(t *testing.T) {
+
+    // Write config with a provider secret reference
+    configPath, err := ioutil.TempDir("", "rocketpool-config")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(configPath)
+    globalConfig := "chains:\n  eth1:\n    provider: https://mainnet.infura.io/v3/${INFURA_KEY}\n"
+    userConfig := "smartnode:\n  secretsFile: secrets.yml\n"
+    secrets := "INFURA_KEY: 0123456789abcdef\n"
+    for name, contents := range map[string]string{GlobalConfigFile: globalConfig, UserConfigFile: userConfig, "secrets.yml": secrets} {
+        if err := ioutil.WriteFile(filepath.Join(configPath, name), []byte(contents), 0600); err != nil { t.Fatal(err) }
+    }
+
+    // Mask service logs
+    c := &Client{configPath: configPath}
+    _, replacer, err := c.LoadRedactedConfig()
+    if err != nil { t.Fatal(err) }
+    logs := replacer.Replace("eth1 | Connecting to https://mainnet.infura.io/v3/0123456789abcdef\neth1 | Invalid project id 0123456789abcdef\n")
+
+    // Check the resolved secret was masked
+    if strings.Contains(logs, "0123456789abcdef") {
+        t.Errorf("Resolved secret was not masked in logs:\n%s", logs)
+    }
+    if !strings.Contains(logs, "https://mainnet.infura.io/") {
+        t.Errorf("Provider host was masked in logs:\n%s", logs)
+    }
+
+}
+
+
+// Config exports mask inline credentials unless secrets are included, and keep secret references
+func TestExportConfigMasksSecrets(t *testing.T) {
+
+    // Write user config with inline credentials & a secret reference
+    configPath, err := ioutil.TempDir("", "rocketpool-config")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(configPath)
+    userConfig := `smartnode:
+  secretsFile: secrets.yml
+  telegramBotToken: "123456:telegram-token"
+chains:
+  eth1:
+    provider: https://mainnet.infura.io/v3/0123456789abcdef
+    wsProvider: wss://mainnet.infura.io/ws/v3/${INFURA_KEY}
+    providerHeaders:
+      X-Api-Key: header-secret
+  eth2:
+    provider: http://eth2:5052
+    providerToken: eth2-token
+`
+    if err := ioutil.WriteFile(filepath.Join(configPath, UserConfigFile), []byte(userConfig), 0600); err != nil { t.Fatal(err) }
+    c := &Client{configPath: configPath}
+    secrets := []string{"0123456789abcdef", "header-secret", "eth2-token", "telegram-token"}
+
+    // Export with masked credentials
+    var export bytes.Buffer
+    if err := c.ExportConfig(&export, config.FormatYAML, false); err != nil { t.Fatal(err) }
+    for _, secret := range secrets {
+        if strings.Contains(export.String(), secret) {
+            t.Errorf("Credential %q was not masked in export:\n%s", secret, export.String())
+        }
+    }
+    for _, setting := range []string{"https://mainnet.infura.io/" + MaskedValue, "wss://mainnet.infura.io/ws/v3/${INFURA_KEY}", "http://eth2:5052", "secrets.yml"} {
+        if !strings.Contains(export.String(), setting) {
+            t.Errorf("Expected %q in export:\n%s", setting, export.String())
+        }
+    }
+
+    // Export with credentials
+    export.Reset()
+    if err := c.ExportConfig(&export, config.FormatYAML, true); err != nil { t.Fatal(err) }
+    for _, secret := range secrets {
+        if !strings.Contains(export.String(), secret) {
+            t.Errorf("Expected credential %q in export with secrets:\n%s", secret, export.String())
+        }
+    }
+
+}
+
+
+// Run with -race to check concurrent user config updates are not lost and readers never see a partial config
+func TestUserConfigConcurrency(t *testing.T) {
+
+    // Write user config
+    configPath, err := ioutil.TempDir("", "rocketpool-config")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(configPath)
+    if err := ioutil.WriteFile(filepath.Join(configPath, UserConfigFile), []byte("smartnode:\n  projectName: rocketpool\n"), 0644); err != nil { t.Fatal(err) }
+
+    // Update & load the user config concurrently from separate clients
+    var wg sync.WaitGroup
+    for i := 0; i < 20; i++ {
+        i := i
+        wg.Add(2)
+        go func() {
+            defer wg.Done()
+            c := &Client{configPath: configPath}
+            err := c.UpdateUserConfig(func(cfg *config.RocketPoolConfig) error {
+                if cfg.Smartnode.AddressBook == nil {
+                    cfg.Smartnode.AddressBook = map[string]string{}
+                }
+                cfg.Smartnode.AddressBook[fmt.Sprintf("0x%040x", i)] = fmt.Sprintf("Wallet %d", i)
+                return nil
+            })
+            if err != nil { t.Error(err) }
+        }()
+        go func() {
+            defer wg.Done()
+            c := &Client{configPath: configPath}
+            cfg, err := c.LoadUserConfig()
+            if err != nil {
+                t.Error(err)
+            } else if cfg.Smartnode.ProjectName != "rocketpool" {
+                t.Errorf("Loaded a partial user config: %+v", cfg.Smartnode)
+            }
+        }()
+    }
+    wg.Wait()
+
+    // Check every update was saved
+    c := &Client{configPath: configPath}
+    cfg, err := c.LoadUserConfig()
+    if err != nil { t.Fatal(err) }
+    if len(cfg.Smartnode.AddressBook) != 20 {
+        t.Errorf("Expected 20 address book entries, got %d", len(cfg.Smartnode.AddressBook))
+    }
+
+    // Check the config kept its permissions
+    info, err := os.Stat(filepath.Join(configPath, UserConfigFile))
+    if err != nil { t.Fatal(err) }
+    if info.Mode().Perm() != 0644 {
+        t.Errorf("Expected user config permissions 0644, got %04o", info.Mode().Perm())
+    }
+
+}
+
+
+// Start an SSH server on a local port which accepts any client and session, but runs no commands
+func startTestSSHServer(t *testing.T) net.Listener {
+    _, hostKey, err := ed25519.GenerateKey(rand.Reader)
+    if err != nil { t.Fatal(err) }
+    signer, err := ssh.NewSignerFromKey(hostKey)
+    if err != nil { t.Fatal(err) }
+    serverConfig := &ssh.ServerConfig{NoClientAuth: true}
+    serverConfig.AddHostKey(signer)
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil { t.Fatal(err) }
+    go func() {
+        for {
+            conn, err := listener.Accept()
+            if err != nil { return }
+            go func() {
+                _, channels, requests, err := ssh.NewServerConn(conn, serverConfig)
+                if err != nil { return }
+                go ssh.DiscardRequests(requests)
+                for newChannel := range channels {
+                    channel, channelRequests, err := newChannel.Accept()
+                    if err != nil { continue }
+                    go func() {
+                        ssh.DiscardRequests(channelRequests)
+                        channel.Close()
+                    }()
+                }
+            }()
+        }
+    }()
+    return listener
+}
+
+
+// Run with -race to check the SSH client may be replaced on reconnect while other goroutines create commands
+func TestReconnectConcurrency(t *testing.T) {
+    listener := startTestSSHServer(t)
+    defer listener.Close()
+
+    // Connect
+    c := &Client{
+        sshAddress: listener.Addr().String(),
+        sshConfig: &ssh.ClientConfig{HostKeyCallback: ssh.InsecureIgnoreHostKey()},
+        stderr: ioutil.Discard,
+    }
+    client, err := ssh.Dial("tcp", c.sshAddress, c.sshConfig)
+    if err != nil { t.Fatal(err) }
+    c.client = client
+    defer c.Close()
+
+    // Create commands while reconnecting; commands on the closed connection may fail
+    done := make(chan struct{})
+    var wg sync.WaitGroup
+    for i := 0; i < 10; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for {
+                select {
+                    case <-done:
+                        return
+                    case <-time.After(time.Millisecond):
+                }
+                if cmd, err := c.newCommand("true", 0); err == nil {
+                    cmd.Close()
+                }
+            }
+        }()
+    }
+    if err := c.reconnect(); err != nil {
+        t.Error(err)
+    }
+    close(done)
+    wg.Wait()
+
+    // Check commands are created on the new connection
+    if c.getSSHClient() == client {
+        t.Fatal("Expected the SSH client to be replaced")
+    }
+    cmd, err := c.newCommand("true", 0)
+    if err != nil {
+        t.Fatalf("Could not create a command after reconnecting: %s", err)
+    }
+    cmd.Close()
+}
@@ -1,8 +1,17 @@
 package rocketpool
 
 import (
+    "bytes"
+    "context"
+    "fmt"
     "io"
+    "os"
     "os/exec"
+    "os/signal"
+    "strings"
+    "sync/atomic"
+    "syscall"
+    "time"
 
     "golang.org/x/crypto/ssh"
 )
@@ -13,24 +22,47 @@ type command struct {
     cmd *exec.Cmd
     session *ssh.Session
     cmdText string
+    timeout time.Duration
+    ctx context.Context
+    cancel context.CancelFunc
+    stopWatching func() bool    // Stops watching a started command's timeout, returning whether it elapsed
+}
+
+
+// Quote a value as a single shell word; single quotes prevent any expansion within it
+func shellQuote(value string) string {
+    return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
 }
 
 
 // Create a command to be run by the Rocket Pool client
-func (c *Client) newCommand(cmdText string) (*command, error) {
-    if c.client == nil {
+// A timeout of 0 indicates no timeout
+func (c *Client) newCommand(cmdText string, timeout time.Duration) (*command, error) {
+    client := c.getSSHClient()
+    if client == nil {
+        cmd := exec.Command("sh", "-c", cmdText)
+        ctx := context.Background()
+        cancel := func() {}
+        if timeout > 0 {
+            ctx, cancel = context.WithTimeout(ctx, timeout)
+            setProcessGroup(cmd)
+        }
         return &command{
-            cmd: exec.Command("sh", "-c", cmdText),
+            cmd: cmd,
             cmdText: cmdText,
+            timeout: timeout,
+            ctx: ctx,
+            cancel: cancel,
         }, nil
     } else {
-        session, err := c.client.NewSession()
+        session, err := client.NewSession()
         if err != nil {
             return nil, err
         }
         return &command{
             session: session,
             cmdText: cmdText,
+            timeout: timeout,
         }, nil
     }
 }
@@ -38,6 +70,9 @@ func (c *Client) newCommand(cmdText string) (*command, error) {
 
 // Close the command session
 func (c *command) Close() error {
+    if c.cancel != nil {
+        c.cancel()
+    }
     if c.session != nil {
         return c.session.Close()
     }
@@ -48,9 +83,16 @@ func (c *command) Close() error {
 // Run the command
 func (c *command) Run() error {
     if c.cmd != nil {
-        return c.cmd.Run()
+        return c.runLocal()
     } else {
-        return c.session.Run(c.cmdText)
+        var err error
+        timedOut := c.withSessionTimeout(func() {
+            err = c.session.Run(c.cmdText)
+        })
+        if timedOut {
+            return c.timeoutError()
+        }
+        return err
     }
 }
 
@@ -58,10 +100,113 @@ func (c *command) Run() error {
 // Run the command and return its output
 func (c *command) Output() ([]byte, error) {
     if c.cmd != nil {
-        return c.cmd.Output()
+        var output bytes.Buffer
+        c.cmd.Stdout = &output
+        err := c.runLocal()
+        return output.Bytes(), err
     } else {
-        return c.session.Output(c.cmdText)
+        var output []byte
+        var err error
+        timedOut := c.withSessionTimeout(func() {
+            output, err = c.session.Output(c.cmdText)
+        })
+        if timedOut {
+            return output, c.timeoutError()
+        }
+        return output, err
+    }
+}
+
+
+// Start the command without waiting for it to complete
+// The timeout applies from when the command is started
+func (c *command) Start() error {
+    if c.cmd != nil {
+        return c.startLocal()
+    }
+    if err := c.session.Start(c.cmdText); err != nil {
+        return err
+    }
+    if c.timeout > 0 {
+        var timedOut int32
+        timer := time.AfterFunc(c.timeout, func() {
+            atomic.StoreInt32(&timedOut, 1)
+            _ = c.session.Signal(ssh.SIGKILL)
+            _ = c.session.Close()
+        })
+        c.stopWatching = func() bool {
+            timer.Stop()
+            return atomic.LoadInt32(&timedOut) == 1
+        }
+    }
+    return nil
+}
+
+
+// Wait for a started command to complete
+// Output pipes must be read to the end before waiting, as they are closed when the command exits
+func (c *command) Wait() error {
+    if c.cmd != nil {
+        return c.waitLocal()
+    }
+    err := c.session.Wait()
+    if c.stopWatching != nil && c.stopWatching() {
+        return c.timeoutError()
+    }
+    return err
+}
+
+
+// Run a local command, killing its whole process group if its deadline is exceeded
+func (c *command) runLocal() error {
+    if err := c.startLocal(); err != nil {
+        return err
+    }
+    return c.waitLocal()
+}
+
+
+// Start a local command, watching for its deadline if it has one
+// Commands with a deadline are not in the terminal's foreground process group, so interrupts are forwarded to them
+func (c *command) startLocal() error {
+    if err := c.cmd.Start(); err != nil {
+        return err
+    }
+    if c.timeout <= 0 {
+        return nil
+    }
+    interrupts := make(chan os.Signal, 1)
+    signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+    done := make(chan struct{})
+    go func() {
+        for {
+            select {
+                case sig := <-interrupts:
+                    signalProcessGroup(c.cmd.Process, sig)
+                case <-c.ctx.Done():
+                    signalProcessGroup(c.cmd.Process, syscall.SIGKILL)
+                    return
+                case <-done:
+                    return
+            }
+        }
+    }()
+    c.stopWatching = func() bool {
+        signal.Stop(interrupts)
+        close(done)
+        return c.ctx.Err() == context.DeadlineExceeded
     }
+    return nil
+}
+
+
+// Wait for a local command to complete
+func (c *command) waitLocal() error {
+    err := c.cmd.Wait()
+    if c.stopWatching != nil {
+        c.stopWatching()
+    }
+    return c.checkTimeout(err)
 }
 
 
@@ -84,3 +229,76 @@ func (c *command) StderrPipe() (io.Reader, error) {
     }
 }
 
+
+// Set the writers for the command's stdout & stderr
+func (c *command) SetOutput(stdout, stderr io.Writer) {
+    if c.cmd != nil {
+        c.cmd.Stdout = stdout
+        c.cmd.Stderr = stderr
+    } else {
+        c.session.Stdout = stdout
+        c.session.Stderr = stderr
+    }
+}
+
+
+// Request a pseudo-terminal for a remote command, so that its output is line-buffered and it is stopped when the session is closed
+// Local commands already share the terminal, so this has no effect on them
+func (c *command) RequestPty() error {
+    if c.session == nil {
+        return nil
+    }
+    modes := ssh.TerminalModes{
+        ssh.ECHO: 0,
+        ssh.TTY_OP_ISPEED: 14400,
+        ssh.TTY_OP_OSPEED: 14400,
+    }
+    return c.session.RequestPty("xterm", 40, 80, modes)
+}
+
+
+// Interrupt the command
+func (c *command) Interrupt() {
+    if c.cmd != nil {
+        if c.cmd.Process != nil {
+            _ = c.cmd.Process.Signal(os.Interrupt)
+        }
+    } else {
+        _ = c.session.Signal(ssh.SIGINT)
+        _ = c.session.Close()
+    }
+}
+
+
+// Replace a local command's error with a timeout error if its deadline was exceeded
+func (c *command) checkTimeout(err error) error {
+    if err != nil && c.ctx != nil && c.ctx.Err() == context.DeadlineExceeded {
+        return c.timeoutError()
+    }
+    return err
+}
+
+
+// Run a function using the SSH session, killing the session if the timeout elapses
+// Returns whether the timeout elapsed
+func (c *command) withSessionTimeout(run func()) bool {
+    if c.timeout <= 0 {
+        run()
+        return false
+    }
+    var timedOut int32
+    timer := time.AfterFunc(c.timeout, func() {
+        atomic.StoreInt32(&timedOut, 1)
+        _ = c.session.Signal(ssh.SIGKILL)
+        _ = c.session.Close()
+    })
+    run()
+    timer.Stop()
+    return atomic.LoadInt32(&timedOut) == 1
+}
+
+
+// Get a command timeout error
+func (c *command) timeoutError() error {
+    return fmt.Errorf("Command timed out after %s. Docker or the Rocket Pool service may be unresponsive; use '--command-timeout' to increase the timeout.", c.timeout)
+}
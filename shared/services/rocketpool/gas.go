@@ -1,6 +1,8 @@
 package rocketpool
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -10,13 +12,82 @@ import (
 )
 
 const colorReset string = "\033[0m"
+const colorRed string = "\033[31m"
 const colorYellow string = "\033[33m"
 
+// Error returned after printing a gas estimate if only the estimate was requested, so the command stops before sending any transaction
+var ErrEstimateOnly = errors.New("Only the gas estimate was requested")
+
+
+// Gas info for a transaction, printed as JSON when the client is in JSON mode
+// Amounts are in wei; requested values are omitted unless set by the user
+type GasEstimate struct {
+    EstGasPrice *big.Int                `json:"estGasPrice"`
+    EstGasLimit uint64                  `json:"estGasLimit"`
+    EstGasCost *big.Int                 `json:"estGasCost"`
+    ReqGasPrice *big.Int                `json:"reqGasPrice,omitempty"`
+    ReqGasLimit uint64                  `json:"reqGasLimit,omitempty"`
+    MaxGasCost *big.Int                 `json:"maxGasCost,omitempty"`
+    Transactions int                    `json:"transactions"`
+}
+
+
+// Get the structured gas estimate for gas info
+func GetGasEstimate(gasInfo rocketpool.GasInfo) GasEstimate {
+    gasPrice := gasInfo.EstGasPrice
+    if gasPrice == nil {
+        gasPrice = big.NewInt(0)
+    }
+    estimate := GasEstimate{
+        EstGasPrice: gasPrice,
+        EstGasLimit: gasInfo.EstGasLimit,
+        EstGasCost: new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasInfo.EstGasLimit)),
+        ReqGasPrice: gasInfo.ReqGasPrice,
+        ReqGasLimit: gasInfo.ReqGasLimit,
+        Transactions: 1,
+    }
+    if gasInfo.ReqGasPrice != nil || gasInfo.ReqGasLimit != 0 {
+        maxGasPrice := gasPrice
+        if gasInfo.ReqGasPrice != nil {
+            maxGasPrice = gasInfo.ReqGasPrice
+        }
+        maxGasLimit := gasInfo.EstGasLimit
+        if gasInfo.ReqGasLimit != 0 {
+            maxGasLimit = gasInfo.ReqGasLimit
+        }
+        estimate.MaxGasCost = new(big.Int).Mul(maxGasPrice, new(big.Int).SetUint64(maxGasLimit))
+    }
+    return estimate
+}
+
+
+// Print a gas estimate to the JSON output as a single line of JSON
+func (rp *Client) printGasEstimateJson(estimate GasEstimate) error {
+    estimateBytes, err := json.Marshal(estimate)
+    if err != nil {
+        return fmt.Errorf("Could not encode gas estimate: %w", err)
+    }
+    fmt.Fprintln(rp.jsonWriter, string(estimateBytes))
+    return nil
+}
+
+
+// Stop after printing a gas estimate if only the estimate was requested, before any transaction is sent
+func (rp *Client) checkEstimateOnly() error {
+    if rp.estimateOnly {
+        return ErrEstimateOnly
+    }
+    return nil
+}
+
 
 // Print a warning about the gas estimate for operations that have multiple transactions
 func (rp *Client) PrintMultiTxWarning() {
 
-    fmt.Printf("%sNOTE: This operation requires multiple transactions.\n" +
+    if rp.jsonOutput {
+        return
+    }
+    fmt.Fprintf(rp.stdout, "%sNOTE: This operation requires multiple transactions.\n" +
         "The actual gas cost may be higher than what is estimated here.\n%s",
         colorYellow,
         colorReset);
@@ -24,8 +95,52 @@ func (rp *Client) PrintMultiTxWarning() {
 }
 
 
+// Sum the gas info of the transactions in a multi-transaction flow
+// Gas limits are added together; the highest gas price is used for the total cost
+func SumGasInfo(gasInfos ...rocketpool.GasInfo) rocketpool.GasInfo {
+    total := rocketpool.GasInfo{}
+    for _, gasInfo := range gasInfos {
+        total.EstGasLimit += gasInfo.EstGasLimit
+        total.ReqGasLimit += gasInfo.ReqGasLimit
+        if gasInfo.EstGasPrice != nil && (total.EstGasPrice == nil || gasInfo.EstGasPrice.Cmp(total.EstGasPrice) > 0) {
+            total.EstGasPrice = gasInfo.EstGasPrice
+        }
+        if gasInfo.ReqGasPrice != nil && (total.ReqGasPrice == nil || gasInfo.ReqGasPrice.Cmp(total.ReqGasPrice) > 0) {
+            total.ReqGasPrice = gasInfo.ReqGasPrice
+        }
+    }
+    return total
+}
+
+
+// Print the total estimated gas cost of all transactions in a multi-transaction flow
+// Returns ErrEstimateOnly if only the estimate was requested
+func (rp *Client) PrintMultiTxGasInfo(gasInfos ...rocketpool.GasInfo) error {
+    if rp.jsonOutput {
+        estimate := GetGasEstimate(SumGasInfo(gasInfos...))
+        estimate.Transactions = len(gasInfos)
+        if err := rp.printGasEstimateJson(estimate); err != nil {
+            return err
+        }
+        return rp.checkEstimateOnly()
+    }
+    fmt.Fprintf(rp.stdout, "%sThis operation requires %d transactions. The estimated total for all of them is:\n%s", colorYellow, len(gasInfos), colorReset)
+    return rp.PrintGasInfo(SumGasInfo(gasInfos...))
+}
+
+
 // Print estimated gas cost and any requested gas parameters
-func (rp *Client) PrintGasInfo(gasInfo rocketpool.GasInfo) {
+// Prints a single line of JSON to the JSON output instead if the client is in JSON mode
+// Returns ErrEstimateOnly if only the estimate was requested
+func (rp *Client) PrintGasInfo(gasInfo rocketpool.GasInfo) error {
+
+    // Print structured gas info
+    if rp.jsonOutput {
+        if err := rp.printGasEstimateJson(GetGasEstimate(gasInfo)); err != nil {
+            return err
+        }
+        return rp.checkEstimateOnly()
+    }
 
     // Print gas price, gas limit and total eth cost as estimated by the network
     gas := new(big.Int).SetUint64(gasInfo.EstGasLimit)
@@ -36,7 +151,7 @@ func (rp *Client) PrintGasInfo(gasInfo rocketpool.GasInfo) {
         gasPrice = big.NewInt(0)
     }
     totalGasWei := new(big.Int).Mul(gasPrice, gas)
-    fmt.Printf("%sSuggested gas price: %.6f Gwei\nEstimated gas used: %d gas\nEstimated gas cost: %.6f ETH\n%s",
+    fmt.Fprintf(rp.stdout, "%sSuggested gas price: %.6f Gwei\nEstimated gas used: %d gas\nEstimated gas cost: %.6f ETH\n%s",
                colorYellow, 
                eth.WeiToGwei(gasPrice), 
                gasInfo.EstGasLimit, 
@@ -75,6 +190,7 @@ func (rp *Client) PrintGasInfo(gasInfo rocketpool.GasInfo) {
                                       math.RoundDown(eth.WeiToEth(totalGasWei), 6),
                                       colorReset)
     }
-    fmt.Println(userGasMessage)
+    fmt.Fprintln(rp.stdout, userGasMessage)
+    return rp.checkEstimateOnly()
 }
 
@@ -0,0 +1,76 @@
+package rocketpool
+
+import (
+    "encoding/json"
+    "io"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// Installation stages
+const (
+    InstallStageDownloading = "downloading"
+    InstallStageDependencies = "installing-dependencies"
+    InstallStagePullingImages = "pulling-images"
+    InstallStageInstalling = "installing"
+    InstallStageDone = "done"
+    InstallStageError = "error"
+)
+
+
+// Installer progress line format, e.g. "Step 2 of 7: Installing OS dependencies..."
+var installStepRegex = regexp.MustCompile(`^Step (\d+) of (\d+): (.*)$`)
+
+
+// A structured installation progress event
+type InstallEvent struct {
+    Stage string        `json:"stage"`
+    Step int            `json:"step,omitempty"`
+    TotalSteps int      `json:"totalSteps,omitempty"`
+    Message string      `json:"message"`
+}
+
+
+// Parse an installer output line into a progress event
+// Lines which are not installer steps keep the stage of the previous event
+func parseInstallLine(line string, previous InstallEvent) InstallEvent {
+    event := InstallEvent{
+        Stage: previous.Stage,
+        Step: previous.Step,
+        TotalSteps: previous.TotalSteps,
+        Message: strings.TrimSpace(line),
+    }
+    if event.Stage == "" {
+        event.Stage = InstallStageInstalling
+    }
+    match := installStepRegex.FindStringSubmatch(event.Message)
+    if match == nil {
+        return event
+    }
+    event.Step, _ = strconv.Atoi(match[1])
+    event.TotalSteps, _ = strconv.Atoi(match[2])
+    event.Message = match[3]
+    message := strings.ToLower(event.Message)
+    switch {
+        case strings.Contains(message, "dependenc"):
+            event.Stage = InstallStageDependencies
+        case strings.Contains(message, "pull") || strings.Contains(message, "image"):
+            event.Stage = InstallStagePullingImages
+        case strings.Contains(message, "download"):
+            event.Stage = InstallStageDownloading
+        default:
+            event.Stage = InstallStageInstalling
+    }
+    return event
+}
+
+
+// Write an installation progress event as a JSON line
+func WriteInstallEvent(w io.Writer, event InstallEvent) {
+    eventBytes, err := json.Marshal(event)
+    if err != nil {
+        return
+    }
+    w.Write(append(eventBytes, '\n'))
+}
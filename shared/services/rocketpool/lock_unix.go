@@ -0,0 +1,24 @@
+// +build !windows
+
+package rocketpool
+
+import (
+    "os"
+    "syscall"
+)
+
+
+// Acquire an advisory lock on an open file, blocking until it is available
+func lockFile(file *os.File, exclusive bool) error {
+    how := syscall.LOCK_SH
+    if exclusive {
+        how = syscall.LOCK_EX
+    }
+    return syscall.Flock(int(file.Fd()), how)
+}
+
+
+// Release an advisory lock on an open file
+func unlockFile(file *os.File) error {
+    return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
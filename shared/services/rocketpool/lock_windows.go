@@ -0,0 +1,26 @@
+// +build windows
+
+package rocketpool
+
+import (
+    "math"
+    "os"
+
+    "golang.org/x/sys/windows"
+)
+
+
+// Acquire a lock on an open file, blocking until it is available
+func lockFile(file *os.File, exclusive bool) error {
+    var flags uint32
+    if exclusive {
+        flags = windows.LOCKFILE_EXCLUSIVE_LOCK
+    }
+    return windows.LockFileEx(windows.Handle(file.Fd()), flags, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
+
+
+// Release a lock on an open file
+func unlockFile(file *os.File) error {
+    return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
@@ -6,14 +6,78 @@ import (
     "math/big"
 
     "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/types"
 
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
+// Get the minipool for a validator pubkey
+func (c *Client) GetMinipoolByPubkey(pubkey types.ValidatorPubkey) (api.MinipoolLookupResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-minipool-by-pubkey %s", pubkey.Hex()))
+    if err != nil {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not get minipool by pubkey: %w", err)
+    }
+    var response api.MinipoolLookupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not decode minipool lookup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not get minipool by pubkey: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the validator pubkey for a minipool
+func (c *Client) GetMinipoolPubkey(address common.Address) (api.MinipoolLookupResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-pubkey %s", address.Hex()))
+    if err != nil {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not get minipool pubkey: %w", err)
+    }
+    var response api.MinipoolLookupResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not decode minipool lookup response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolLookupResponse{}, fmt.Errorf("Could not get minipool pubkey: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Compare the node's minipools against the validator keys stored for the validator client
+func (c *Client) MinipoolCheckKeys() (api.MinipoolCheckKeysResponse, error) {
+    responseBytes, err := c.callAPI("minipool check-keys")
+    if err != nil {
+        return api.MinipoolCheckKeysResponse{}, fmt.Errorf("Could not check minipool validator keys: %w", err)
+    }
+    var response api.MinipoolCheckKeysResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolCheckKeysResponse{}, fmt.Errorf("Could not decode minipool check keys response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolCheckKeysResponse{}, fmt.Errorf("Could not check minipool validator keys: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Get minipool status
 func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
-    responseBytes, err := c.callAPI("minipool status")
+    return c.minipoolStatus("minipool status")
+}
+
+
+// Get the minipool status of any node by address, without requiring the node wallet
+func (c *Client) ObservedMinipoolStatus(nodeAddress common.Address) (api.MinipoolStatusResponse, error) {
+    return c.minipoolStatus(fmt.Sprintf("minipool status --node-address %s", nodeAddress.Hex()))
+}
+
+
+// Get minipool status using the given API arguments
+func (c *Client) minipoolStatus(args string) (api.MinipoolStatusResponse, error) {
+    responseBytes, err := c.callAPI(args)
     if err != nil {
         return api.MinipoolStatusResponse{}, fmt.Errorf("Could not get minipool status: %w", err)
     }
@@ -42,6 +106,34 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 }
 
 
+// Get the balance events of the node's minipools within a block range
+// A toBlock of 0 uses the latest block
+func (c *Client) MinipoolHistory(nodeAddress *common.Address, fromBlock, toBlock uint64) (api.MinipoolHistoryResponse, error) {
+    args := fmt.Sprintf("minipool history %d %d", fromBlock, toBlock)
+    if nodeAddress != nil {
+        args += fmt.Sprintf(" --node-address %s", nodeAddress.Hex())
+    }
+    responseBytes, err := c.callAPI(args)
+    if err != nil {
+        return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not get minipool history: %w", err)
+    }
+    var response api.MinipoolHistoryResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not decode minipool history response: %w", err)
+    }
+    if response.Error != "" {
+        return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not get minipool history: %s", response.Error)
+    }
+    for i := 0; i < len(response.Events); i++ {
+        event := &response.Events[i]
+        if event.Amount == nil { event.Amount = big.NewInt(0) }
+        if event.NodeAmount == nil { event.NodeAmount = big.NewInt(0) }
+        if event.UserAmount == nil { event.UserAmount = big.NewInt(0) }
+    }
+    return response, nil
+}
+
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
     responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -4,6 +4,7 @@ import (
     "encoding/json"
     "fmt"
     "math/big"
+    "time"
 
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -45,3 +46,65 @@ func (c *Client) RplPrice() (api.RplPriceResponse, error) {
     return response, nil
 }
 
+
+// Get a summary of network-wide statistics
+func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
+    responseBytes, err := c.callAPI("network stats")
+    if err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %w", err)
+    }
+    var response api.NetworkStatsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not decode network stats response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %s", response.Error)
+    }
+    if response.TotalRplStake == nil { response.TotalRplStake = big.NewInt(0) }
+    if response.TotalEffectiveRplStake == nil { response.TotalEffectiveRplStake = big.NewInt(0) }
+    if response.RplPrice == nil { response.RplPrice = big.NewInt(0) }
+    if response.DepositPoolBalance == nil { response.DepositPoolBalance = big.NewInt(0) }
+    return response, nil
+}
+
+
+// Get the latest eth1 block
+// The block is cached for a short time so commands making several reads share one block
+func (c *Client) GetLatestBlock() (api.LatestBlockResponse, error) {
+    c.latestBlockLock.Lock()
+    defer c.latestBlockLock.Unlock()
+    if c.latestBlock != nil && time.Since(c.latestBlockTime) < LatestBlockCacheTTL {
+        return *c.latestBlock, nil
+    }
+    responseBytes, err := c.callAPI("network latest-block")
+    if err != nil {
+        return api.LatestBlockResponse{}, fmt.Errorf("Could not get latest block: %w", err)
+    }
+    var response api.LatestBlockResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.LatestBlockResponse{}, fmt.Errorf("Could not decode latest block response: %w", err)
+    }
+    if response.Error != "" {
+        return api.LatestBlockResponse{}, fmt.Errorf("Could not get latest block: %s", response.Error)
+    }
+    c.latestBlock = &response
+    c.latestBlockTime = time.Now()
+    return response, nil
+}
+
+
+// Measure the round-trip latency of the eth1 client
+func (c *Client) Eth1Latency(count uint64) (api.Eth1LatencyResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("network eth1-latency %d", count))
+    if err != nil {
+        return api.Eth1LatencyResponse{}, fmt.Errorf("Could not measure eth1 latency: %w", err)
+    }
+    var response api.Eth1LatencyResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.Eth1LatencyResponse{}, fmt.Errorf("Could not decode eth1 latency response: %w", err)
+    }
+    if response.Error != "" {
+        return api.Eth1LatencyResponse{}, fmt.Errorf("Could not measure eth1 latency: %s", response.Error)
+    }
+    return response, nil
+}
@@ -1,6 +1,7 @@
 package rocketpool
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -12,7 +13,19 @@ import (
 
 // Get node status
 func (c *Client) NodeStatus() (api.NodeStatusResponse, error) {
-    responseBytes, err := c.callAPI("node status")
+    return c.nodeStatus("node status")
+}
+
+
+// Get the status of any node by address, without requiring the node wallet
+func (c *Client) ObservedNodeStatus(nodeAddress common.Address) (api.NodeStatusResponse, error) {
+    return c.nodeStatus(fmt.Sprintf("node status --node-address %s", nodeAddress.Hex()))
+}
+
+
+// Get node status using the given API arguments
+func (c *Client) nodeStatus(args string) (api.NodeStatusResponse, error) {
+    responseBytes, err := c.callAPI(args)
     if err != nil {
         return api.NodeStatusResponse{}, fmt.Errorf("Could not get node status: %w", err)
     }
@@ -74,7 +87,7 @@ func (c *Client) RegisterNode(timezoneLocation string) (api.RegisterNodeResponse
 
 // Checks if the node's withdrawal address can be set
 func (c *Client) CanSetNodeWithdrawalAddress(withdrawalAddress common.Address, confirm bool) (api.CanSetNodeWithdrawalAddressResponse, error) {
-    responseBytes, err := c.callAPI(fmt.Sprintf("node can-set-withdrawal-address %s %s", withdrawalAddress.Hex(), confirm))
+    responseBytes, err := c.callAPI(fmt.Sprintf("node can-set-withdrawal-address %s %t", withdrawalAddress.Hex(), confirm))
     if err != nil {
         return api.CanSetNodeWithdrawalAddressResponse{}, fmt.Errorf("Could not get can set node withdrawal address: %w", err)
     }
@@ -91,7 +104,7 @@ func (c *Client) CanSetNodeWithdrawalAddress(withdrawalAddress common.Address, c
 
 // Set the node's withdrawal address
 func (c *Client) SetNodeWithdrawalAddress(withdrawalAddress common.Address, confirm bool) (api.SetNodeWithdrawalAddressResponse, error) {
-    responseBytes, err := c.callAPI(fmt.Sprintf("node set-withdrawal-address %s %s", withdrawalAddress.Hex(), confirm))
+    responseBytes, err := c.callAPI(fmt.Sprintf("node set-withdrawal-address %s %t", withdrawalAddress.Hex(), confirm))
     if err != nil {
         return api.SetNodeWithdrawalAddressResponse{}, fmt.Errorf("Could not set node withdrawal address: %w", err)
     }
@@ -344,6 +357,24 @@ func (c *Client) CanNodeSend(amountWei *big.Int, token string) (api.CanNodeSendR
 }
 
 
+// Get the symbol, decimals & node balance of an ERC-20 token
+func (c *Client) NodeTokenInfo(tokenAddress common.Address) (api.NodeTokenInfoResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node token-info %s", tokenAddress.Hex()))
+    if err != nil {
+        return api.NodeTokenInfoResponse{}, fmt.Errorf("Could not get token info: %w", err)
+    }
+    var response api.NodeTokenInfoResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeTokenInfoResponse{}, fmt.Errorf("Could not decode token info response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeTokenInfoResponse{}, fmt.Errorf("Could not get token info: %s", response.Error)
+    }
+    if response.Balance == nil { response.Balance = big.NewInt(0) }
+    return response, nil
+}
+
+
 // Send tokens from the node to an address
 func (c *Client) NodeSend(amountWei *big.Int, token string, toAddress common.Address) (api.NodeSendResponse, error) {
     responseBytes, err := c.callAPI(fmt.Sprintf("node send %s %s %s", amountWei.String(), token, toAddress.Hex()))
@@ -361,6 +392,91 @@ func (c *Client) NodeSend(amountWei *big.Int, token string, toAddress common.Add
 }
 
 
+// Sign a transaction with the node account and broadcast it
+func (c *Client) SignAndSendTransaction(toAddress common.Address, value *big.Int, data []byte) (api.SendRawTransactionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node sign-and-send %s %s 0x%s", toAddress.Hex(), value.String(), hex.EncodeToString(data)))
+    if err != nil {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not send transaction: %w", err)
+    }
+    var response api.SendRawTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not decode send transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not send transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Broadcast an already-signed raw transaction
+func (c *Client) SendRawTransaction(rawTx []byte) (api.SendRawTransactionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node send-raw-transaction 0x%s", hex.EncodeToString(rawTx)))
+    if err != nil {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not send raw transaction: %w", err)
+    }
+    var response api.SendRawTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not decode send raw transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.SendRawTransactionResponse{}, fmt.Errorf("Could not send raw transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Get the nonces of the node's pending transactions
+func (c *Client) NodePendingTransactions() (api.NodePendingTransactionsResponse, error) {
+    responseBytes, err := c.callAPI("node get-pending-transactions")
+    if err != nil {
+        return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+    }
+    var response api.NodePendingTransactionsResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Check whether the pending transaction with a nonce can be cancelled
+func (c *Client) CanCancelTransaction(nonce uint64) (api.CanCancelTransactionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node can-cancel-transaction %d", nonce))
+    if err != nil {
+        return api.CanCancelTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %w", err)
+    }
+    var response api.CanCancelTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CanCancelTransactionResponse{}, fmt.Errorf("Could not decode can cancel transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CanCancelTransactionResponse{}, fmt.Errorf("Could not get can cancel transaction status: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Cancel the pending transaction with a nonce by replacing it with a zero-value transaction to the node
+func (c *Client) CancelTransaction(nonce uint64) (api.CancelTransactionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-transaction %d", nonce))
+    if err != nil {
+        return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %w", err)
+    }
+    var response api.CancelTransactionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.CancelTransactionResponse{}, fmt.Errorf("Could not decode cancel transaction response: %w", err)
+    }
+    if response.Error != "" {
+        return api.CancelTransactionResponse{}, fmt.Errorf("Could not cancel transaction: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Check whether the node can burn tokens
 func (c *Client) CanNodeBurn(amountWei *big.Int, token string) (api.CanNodeBurnResponse, error) {
     responseBytes, err := c.callAPI(fmt.Sprintf("node can-burn %s %s", amountWei.String(), token))
@@ -397,7 +513,16 @@ func (c *Client) NodeBurn(amountWei *big.Int, token string) (api.NodeBurnRespons
 
 // Get node sync progress
 func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
-    responseBytes, err := c.callAPI("node sync")
+    return c.nodeSync("node sync")
+}
+
+
+// Get the sync progress of the eth1 and eth2 clients, retrying failed checks a number of times
+func (c *Client) NodeSyncWithRetries(retries uint64) (api.NodeSyncProgressResponse, error) {
+    return c.nodeSync(fmt.Sprintf("node sync --sync-retries %d", retries))
+}
+func (c *Client) nodeSync(command string) (api.NodeSyncProgressResponse, error) {
+    responseBytes, err := c.callAPI(command)
     if err != nil {
         return api.NodeSyncProgressResponse{}, fmt.Errorf("Could not get node sync: %w", err)
     }
@@ -412,6 +537,101 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 }
 
 
+// Get the node's RPL collateral ratio sampled over a number of days
+func (c *Client) NodeCollateralHistory(days, samples uint64) (api.NodeCollateralHistoryResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node get-collateral-history %d %d", days, samples))
+    if err != nil {
+        return api.NodeCollateralHistoryResponse{}, fmt.Errorf("Could not get node collateral history: %w", err)
+    }
+    var response api.NodeCollateralHistoryResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeCollateralHistoryResponse{}, fmt.Errorf("Could not decode node collateral history response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeCollateralHistoryResponse{}, fmt.Errorf("Could not get node collateral history: %s", response.Error)
+    }
+    for i := 0; i < len(response.Samples); i++ {
+        sample := &response.Samples[i]
+        if sample.RplPrice == nil { sample.RplPrice = big.NewInt(0) }
+        if sample.RplStake == nil { sample.RplStake = big.NewInt(0) }
+    }
+    return response, nil
+}
+
+
+// Get the RPL stake required to run a number of additional minipools
+func (c *Client) NodeRplRequired(additionalMinipools uint64) (api.NodeRplRequiredResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node get-rpl-required %d", additionalMinipools))
+    if err != nil {
+        return api.NodeRplRequiredResponse{}, fmt.Errorf("Could not get required RPL stake: %w", err)
+    }
+    var response api.NodeRplRequiredResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeRplRequiredResponse{}, fmt.Errorf("Could not decode required RPL stake response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeRplRequiredResponse{}, fmt.Errorf("Could not get required RPL stake: %s", response.Error)
+    }
+    if response.RplPrice == nil { response.RplPrice = big.NewInt(0) }
+    if response.RplStake == nil { response.RplStake = big.NewInt(0) }
+    if response.RequiredRplStake == nil { response.RequiredRplStake = big.NewInt(0) }
+    if response.AdditionalRplStake == nil { response.AdditionalRplStake = big.NewInt(0) }
+    return response, nil
+}
+
+
+// Get the eth2 beacon client version
+func (c *Client) NodeBeaconClientVersion() (api.NodeBeaconClientVersionResponse, error) {
+    responseBytes, err := c.callAPI("node get-beacon-client-version")
+    if err != nil {
+        return api.NodeBeaconClientVersionResponse{}, fmt.Errorf("Could not get beacon client version: %w", err)
+    }
+    var response api.NodeBeaconClientVersionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeBeaconClientVersionResponse{}, fmt.Errorf("Could not decode beacon client version response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeBeaconClientVersionResponse{}, fmt.Errorf("Could not get beacon client version: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Test connectivity to the eth1 & eth2 providers
+func (c *Client) NodeTestConnectivity() (api.NodeTestConnectivityResponse, error) {
+    responseBytes, err := c.callAPI("node test-connectivity")
+    if err != nil {
+        return api.NodeTestConnectivityResponse{}, fmt.Errorf("Could not test provider connectivity: %w", err)
+    }
+    var response api.NodeTestConnectivityResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeTestConnectivityResponse{}, fmt.Errorf("Could not decode test connectivity response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeTestConnectivityResponse{}, fmt.Errorf("Could not test provider connectivity: %s", response.Error)
+    }
+    return response, nil
+}
+
+
+// Check whether the node's validator keys are attesting
+// If the local validator client is stopped, stoppedAt is the unix time it stopped at, and only later epochs are checked
+func (c *Client) NodeCheckDuplicateKeys(stoppedAt uint64) (api.NodeCheckDuplicateKeysResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("node check-duplicate-keys %d", stoppedAt))
+    if err != nil {
+        return api.NodeCheckDuplicateKeysResponse{}, fmt.Errorf("Could not check for duplicate validator keys: %w", err)
+    }
+    var response api.NodeCheckDuplicateKeysResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeCheckDuplicateKeysResponse{}, fmt.Errorf("Could not decode check duplicate keys response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeCheckDuplicateKeysResponse{}, fmt.Errorf("Could not check for duplicate validator keys: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
     responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -445,3 +665,32 @@ func (c *Client) NodeClaimRpl() (api.NodeClaimRplResponse, error) {
     return response, nil
 }
 
+
+
+// Get the block & transaction in which the node was registered
+func (c *Client) NodeRegistration() (api.NodeRegistrationResponse, error) {
+    return c.nodeRegistration("node registration")
+}
+
+
+// Get the block & transaction in which any node was registered by address, without requiring the node wallet
+func (c *Client) ObservedNodeRegistration(nodeAddress common.Address) (api.NodeRegistrationResponse, error) {
+    return c.nodeRegistration(fmt.Sprintf("node registration --node-address %s", nodeAddress.Hex()))
+}
+
+
+// Get the node registration using the given API arguments
+func (c *Client) nodeRegistration(args string) (api.NodeRegistrationResponse, error) {
+    responseBytes, err := c.callAPI(args)
+    if err != nil {
+        return api.NodeRegistrationResponse{}, fmt.Errorf("Could not get node registration: %w", err)
+    }
+    var response api.NodeRegistrationResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.NodeRegistrationResponse{}, fmt.Errorf("Could not decode node registration response: %w", err)
+    }
+    if response.Error != "" {
+        return api.NodeRegistrationResponse{}, fmt.Errorf("Could not get node registration: %s", response.Error)
+    }
+    return response, nil
+}
@@ -0,0 +1,26 @@
+// +build !windows
+
+package rocketpool
+
+import (
+    "os"
+    "os/exec"
+    "syscall"
+)
+
+
+// Run a local command in its own process group, so that it can be signalled along with its child processes
+func setProcessGroup(cmd *exec.Cmd) {
+    cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+
+// Send a signal to a local command's process group
+func signalProcessGroup(process *os.Process, sig os.Signal) {
+    if process == nil {
+        return
+    }
+    if s, ok := sig.(syscall.Signal); ok {
+        _ = syscall.Kill(-process.Pid, s)
+    }
+}
@@ -0,0 +1,21 @@
+// +build windows
+
+package rocketpool
+
+import (
+    "os"
+    "os/exec"
+    "syscall"
+)
+
+
+// Process groups are not used on Windows
+func setProcessGroup(cmd *exec.Cmd) {}
+
+
+// Kill a local command; interrupts are already delivered to every process attached to the console
+func signalProcessGroup(process *os.Process, sig os.Signal) {
+    if process != nil && sig == syscall.SIGKILL {
+        _ = process.Kill()
+    }
+}
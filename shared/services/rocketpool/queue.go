@@ -28,6 +28,27 @@ func (c *Client) QueueStatus() (api.QueueStatusResponse, error) {
 }
 
 
+// Get the queue position & estimated wait for ETH assignment of a new minipool
+func (c *Client) QueuePosition(amountWei *big.Int) (api.QueuePositionResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("queue position %s", amountWei.String()))
+    if err != nil {
+        return api.QueuePositionResponse{}, fmt.Errorf("Could not get queue position: %w", err)
+    }
+    var response api.QueuePositionResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.QueuePositionResponse{}, fmt.Errorf("Could not decode queue position response: %w", err)
+    }
+    if response.Error != "" {
+        return api.QueuePositionResponse{}, fmt.Errorf("Could not get queue position: %s", response.Error)
+    }
+    if response.DepositPoolBalance == nil { response.DepositPoolBalance = big.NewInt(0) }
+    if response.RequiredBalance == nil { response.RequiredBalance = big.NewInt(0) }
+    if response.Shortfall == nil { response.Shortfall = big.NewInt(0) }
+    if response.DepositRate == nil { response.DepositRate = big.NewInt(0) }
+    return response, nil
+}
+
+
 // Check whether the queue can be processed
 func (c *Client) CanProcessQueue() (api.CanProcessQueueResponse, error) {
     responseBytes, err := c.callAPI("queue can-process")
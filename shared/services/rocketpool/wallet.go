@@ -1,8 +1,10 @@
 package rocketpool
 
 import (
+    "encoding/hex"
     "encoding/json"
     "fmt"
+    "math/big"
 
     "github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -76,6 +78,23 @@ func (c *Client) RecoverWallet(mnemonic string) (api.RecoverWalletResponse, erro
 }
 
 
+// Initialize the wallet from a V3 keystore
+func (c *Client) RebuildFromKeystore(keystoreJson []byte, keystorePassword string) (api.RebuildFromKeystoreResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("wallet rebuild-from-keystore %s %s", hex.EncodeToString(keystoreJson), hex.EncodeToString([]byte(keystorePassword))))
+    if err != nil {
+        return api.RebuildFromKeystoreResponse{}, fmt.Errorf("Could not rebuild wallet from keystore: %w", err)
+    }
+    var response api.RebuildFromKeystoreResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.RebuildFromKeystoreResponse{}, fmt.Errorf("Could not decode rebuild wallet from keystore response: %w", err)
+    }
+    if response.Error != "" {
+        return api.RebuildFromKeystoreResponse{}, fmt.Errorf("Could not rebuild wallet from keystore: %s", response.Error)
+    }
+    return response, nil
+}
+
+
 // Rebuild wallet
 func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
     responseBytes, err := c.callAPI("wallet rebuild")
@@ -93,6 +112,28 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 }
 
 
+// Get the derived node account addresses & their balances
+func (c *Client) WalletDerivedAddresses(count uint64) (api.DerivedAddressesResponse, error) {
+    responseBytes, err := c.callAPI(fmt.Sprintf("wallet derived-addresses %d", count))
+    if err != nil {
+        return api.DerivedAddressesResponse{}, fmt.Errorf("Could not get derived addresses: %w", err)
+    }
+    var response api.DerivedAddressesResponse
+    if err := json.Unmarshal(responseBytes, &response); err != nil {
+        return api.DerivedAddressesResponse{}, fmt.Errorf("Could not decode derived addresses response: %w", err)
+    }
+    if response.Error != "" {
+        return api.DerivedAddressesResponse{}, fmt.Errorf("Could not get derived addresses: %s", response.Error)
+    }
+    for i := 0; i < len(response.Addresses); i++ {
+        address := &response.Addresses[i]
+        if address.EthBalance == nil { address.EthBalance = big.NewInt(0) }
+        if address.RplBalance == nil { address.RplBalance = big.NewInt(0) }
+    }
+    return response, nil
+}
+
+
 // Export wallet
 func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
     responseBytes, err := c.callAPI("wallet export")
@@ -1,14 +1,19 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
 
@@ -29,6 +34,7 @@ import (
 
 // Config
 const DockerAPIVersion = "1.40"
+const LatestBlockCacheTTL = 5 * time.Second
 
 
 // Service instances & initializers
@@ -36,7 +42,9 @@ var (
     cfg config.RocketPoolConfig
     passwordManager *passwords.PasswordManager
     nodeWallet *wallet.Wallet
+    ethRpcClient *rpc.Client
     ethClient *ethclient.Client
+    eth1ClientVersion string
     mainnetEthClient *ethclient.Client
     rocketPool *rocketpool.RocketPool
     oneInchOracle *contracts.OneInchOracle
@@ -47,11 +55,16 @@ var (
     initPasswordManager sync.Once
     initNodeWallet sync.Once
     initEthClient sync.Once
+    initEth1ClientVersion sync.Once
     initMainnetEthClient sync.Once
     initRocketPool sync.Once
     initOneInchOracle sync.Once
     initBeaconClient sync.Once
     initDocker sync.Once
+
+    latestBlock *types.Header
+    latestBlockTime time.Time
+    latestBlockLock sync.Mutex
 )
 
 
@@ -84,6 +97,27 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 }
 
 
+// Get the address of the node to report on
+// Read-only commands may observe any node via the node-address flag, in which case the node wallet is not required
+func GetNodeAddress(c *cli.Context) (common.Address, error) {
+    if c.String("node-address") != "" {
+        return common.HexToAddress(c.String("node-address")), nil
+    }
+    if err := RequireNodeWallet(c); err != nil {
+        return common.Address{}, err
+    }
+    w, err := GetWallet(c)
+    if err != nil {
+        return common.Address{}, err
+    }
+    nodeAccount, err := w.GetNodeAccount()
+    if err != nil {
+        return common.Address{}, err
+    }
+    return nodeAccount.Address, nil
+}
+
+
 func GetEthClient(c *cli.Context) (*ethclient.Client, error) {
     cfg, err := getConfig(c)
     if err != nil {
@@ -93,6 +127,125 @@ func GetEthClient(c *cli.Context) (*ethclient.Client, error) {
 }
 
 
+// Get the eth1 client's version string, as reported by web3_clientVersion
+func GetEth1ClientVersion(c *cli.Context) (string, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return "", err
+    }
+    if _, err := getEthClient(cfg); err != nil {
+        return "", err
+    }
+    return getEth1ClientVersion()
+}
+
+
+// Get the gas price of a pending transaction from an account by nonce, as listed in the eth1 client's transaction pool
+// Requires the txpool API, which hosted providers may not support
+func GetPendingTransactionGasPrice(c *cli.Context, address common.Address, nonce uint64) (*big.Int, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := getEthClient(cfg); err != nil {
+        return nil, err
+    }
+    var content map[string]map[string]map[string]struct {
+        GasPrice *hexutil.Big   `json:"gasPrice"`
+    }
+    if err := ethRpcClient.CallContext(context.Background(), &content, "txpool_content"); err != nil {
+        return nil, fmt.Errorf("Could not get the eth1 client's transaction pool: %w", err)
+    }
+    for account, txs := range content["pending"] {
+        if common.HexToAddress(account) != address {
+            continue
+        }
+        if tx, ok := txs[fmt.Sprintf("%d", nonce)]; ok && tx.GasPrice != nil {
+            return tx.GasPrice.ToInt(), nil
+        }
+    }
+    return nil, fmt.Errorf("The transaction with nonce %d was not found in the eth1 client's transaction pool", nonce)
+}
+
+
+// Get the effective gas price paid by a mined transaction
+// Uses the price in its receipt if the eth1 client reports it; otherwise EIP-1559 transactions pay the base fee of their block plus their priority fee, capped at their max fee
+func GetTransactionEffectiveGasPrice(c *cli.Context, txHash common.Hash, blockNumber *big.Int) (*big.Int, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := getEthClient(cfg); err != nil {
+        return nil, err
+    }
+
+    // Get the price from the receipt
+    var receipt struct {
+        EffectiveGasPrice *hexutil.Big     `json:"effectiveGasPrice"`
+    }
+    if err := ethRpcClient.CallContext(context.Background(), &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+        return nil, fmt.Errorf("Could not get the receipt of transaction %s: %w", txHash.Hex(), err)
+    }
+    if receipt.EffectiveGasPrice != nil {
+        return receipt.EffectiveGasPrice.ToInt(), nil
+    }
+
+    // Get the transaction's gas price, or its fees if it is an EIP-1559 transaction
+    var tx struct {
+        GasPrice *hexutil.Big              `json:"gasPrice"`
+        MaxFeePerGas *hexutil.Big          `json:"maxFeePerGas"`
+        MaxPriorityFeePerGas *hexutil.Big  `json:"maxPriorityFeePerGas"`
+    }
+    if err := ethRpcClient.CallContext(context.Background(), &tx, "eth_getTransactionByHash", txHash); err != nil {
+        return nil, fmt.Errorf("Could not get transaction %s: %w", txHash.Hex(), err)
+    }
+    if tx.MaxFeePerGas == nil || tx.MaxPriorityFeePerGas == nil {
+        if tx.GasPrice == nil {
+            return nil, fmt.Errorf("Could not get the gas price of transaction %s", txHash.Hex())
+        }
+        return tx.GasPrice.ToInt(), nil
+    }
+
+    // Get the block's base fee
+    var block struct {
+        BaseFeePerGas *hexutil.Big         `json:"baseFeePerGas"`
+    }
+    if err := ethRpcClient.CallContext(context.Background(), &block, "eth_getBlockByNumber", hexutil.EncodeBig(blockNumber), false); err != nil {
+        return nil, fmt.Errorf("Could not get block %s: %w", blockNumber.String(), err)
+    }
+    if block.BaseFeePerGas == nil {
+        return nil, fmt.Errorf("Could not get the base fee of block %s", blockNumber.String())
+    }
+    return GetEffectiveGasPrice(block.BaseFeePerGas.ToInt(), tx.MaxFeePerGas.ToInt(), tx.MaxPriorityFeePerGas.ToInt()), nil
+
+}
+
+
+// Get the gas price paid by an EIP-1559 transaction in a block with a base fee
+func GetEffectiveGasPrice(baseFee, maxFee, maxPriorityFee *big.Int) *big.Int {
+    price := new(big.Int).Add(baseFee, maxPriorityFee)
+    if price.Cmp(maxFee) > 0 {
+        price.Set(maxFee)
+    }
+    return price
+}
+
+
+// Get the latest eth1 block header
+// The header is cached for a short time so repeated calls within a command share one block read
+func GetLatestBlock(c *cli.Context) (*types.Header, error) {
+    cfg, err := getConfig(c)
+    if err != nil {
+        return nil, err
+    }
+    ec, err := getEthClient(cfg)
+    if err != nil {
+        return nil, err
+    }
+    return getLatestBlock(ec)
+}
+
+
 func GetMainnetEthClient(c *cli.Context) (*ethclient.Client, error) {
     cfg, err := getConfig(c)
     if err != nil {
@@ -111,6 +264,9 @@ func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
     if err != nil {
         return nil, err
     }
+    if err := checkEthClientChainID(c); err != nil {
+        return nil, err
+    }
     return getRocketPool(cfg, ec)
 }
 
@@ -169,12 +325,16 @@ func getWallet(cfg config.RocketPoolConfig, pm *passwords.PasswordManager) (*wal
     initNodeWallet.Do(func() {
         var gasPrice *big.Int
         var gasLimit uint64
+        var autoLockTimeout time.Duration
         gasPrice, err = cfg.GetGasPrice()
         if err != nil { return }
         gasLimit, err = cfg.GetGasLimit()
         if err != nil { return }
+        autoLockTimeout, err = cfg.GetWalletAutoLockTimeout()
+        if err != nil { return }
         nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.WalletPath), cfg.Chains.Eth1.ChainID, gasPrice, gasLimit, pm)
         if err != nil { return }
+        nodeWallet.SetAutoLockTimeout(autoLockTimeout)
         lighthouseKeystore := lhkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), pm)
         nimbusKeystore := nmkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), pm)
         prysmKeystore := prkeystore.NewKeystore(os.ExpandEnv(cfg.Smartnode.ValidatorKeychainPath), pm)
@@ -191,12 +351,39 @@ func getWallet(cfg config.RocketPoolConfig, pm *passwords.PasswordManager) (*wal
 func getEthClient(cfg config.RocketPoolConfig) (*ethclient.Client, error) {
     var err error
     initEthClient.Do(func() {
-        ethClient, err = ethclient.Dial(cfg.Chains.Eth1.Provider)
+        ethRpcClient, err = rpc.Dial(cfg.Chains.Eth1.Provider)
+        if err != nil { return }
+        ethClient = ethclient.NewClient(ethRpcClient)
     })
     return ethClient, err
 }
 
 
+func getEth1ClientVersion() (string, error) {
+    var err error
+    initEth1ClientVersion.Do(func() {
+        err = ethRpcClient.CallContext(context.Background(), &eth1ClientVersion, "web3_clientVersion")
+    })
+    return eth1ClientVersion, err
+}
+
+
+func getLatestBlock(client *ethclient.Client) (*types.Header, error) {
+    latestBlockLock.Lock()
+    defer latestBlockLock.Unlock()
+    if latestBlock != nil && time.Since(latestBlockTime) < LatestBlockCacheTTL {
+        return latestBlock, nil
+    }
+    header, err := client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return nil, err
+    }
+    latestBlock = header
+    latestBlockTime = time.Now()
+    return latestBlock, nil
+}
+
+
 func getMainnetEthClient(cfg config.RocketPoolConfig) (*ethclient.Client, error) {
     var err error
     initMainnetEthClient.Do(func() {
@@ -227,23 +414,33 @@ func getOneInchOracle(cfg config.RocketPoolConfig, client *ethclient.Client) (*c
 func getBeaconClient(cfg config.RocketPoolConfig) (beacon.Client, error) {
     var err error
     initBeaconClient.Do(func() {
-        switch cfg.Chains.Eth2.Client.Selected {
-            case "lighthouse":
-                beaconClient = lighthouse.NewClient(cfg.Chains.Eth2.Provider)
-            case "nimbus":
-                beaconClient, err = nimbus.NewClient(cfg.Chains.Eth2.Provider)
-            case "prysm":
-                beaconClient, err = prysm.NewClient(cfg.Chains.Eth2.Provider)
-            case "teku":
-                beaconClient = teku.NewClient(cfg.Chains.Eth2.Provider)
-            default:
-                err = fmt.Errorf("Unknown Eth 2.0 client '%s' selected", cfg.Chains.Eth2.Client.Selected)
-        }
+        beaconClient, err = newBeaconClient(cfg.Chains.Eth2.Client.Selected, cfg.Chains.Eth2.Provider, cfg.Chains.Eth2.GetProviderHeaders())
+        if err != nil || cfg.Chains.Eth2.FallbackProvider == "" { return }
+        var fallbackClient beacon.Client
+        fallbackClient, err = newBeaconClient(cfg.Chains.Eth2.Client.Selected, cfg.Chains.Eth2.FallbackProvider, map[string]string{})
+        if err != nil { return }
+        beaconClient = beacon.NewFallbackClient(beaconClient, fallbackClient)
     })
     return beaconClient, err
 }
 
 
+func newBeaconClient(selected string, provider string, headers map[string]string) (beacon.Client, error) {
+    switch selected {
+        case "lighthouse":
+            return lighthouse.NewClient(provider, headers), nil
+        case "nimbus":
+            return nimbus.NewClient(provider, headers)
+        case "prysm":
+            return prysm.NewClient(provider, headers)
+        case "teku":
+            return teku.NewClient(provider, headers), nil
+        default:
+            return nil, fmt.Errorf("Unknown Eth 2.0 client '%s' selected", selected)
+    }
+}
+
+
 func getDocker() (*client.Client, error) {
     var err error
     initDocker.Do(func() {
@@ -0,0 +1,28 @@
+// +build !windows
+
+package services
+
+import (
+    "os"
+    "os/signal"
+    "syscall"
+
+    "github.com/urfave/cli"
+)
+
+
+// Lock the node wallet whenever the process receives SIGUSR1, e.g. from 'rocketpool service lock-wallet'
+func LockWalletOnSignal(c *cli.Context) error {
+    w, err := GetWallet(c)
+    if err != nil {
+        return err
+    }
+    signals := make(chan os.Signal, 1)
+    signal.Notify(signals, syscall.SIGUSR1)
+    go func() {
+        for range signals {
+            w.LockWallet()
+        }
+    }()
+    return nil
+}
@@ -0,0 +1,13 @@
+// +build windows
+
+package services
+
+import (
+    "github.com/urfave/cli"
+)
+
+
+// SIGUSR1 is not supported on Windows, so the node wallet can only be locked by its idle timeout
+func LockWalletOnSignal(c *cli.Context) error {
+    return nil
+}
@@ -1,8 +1,16 @@
 package keystore
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
 // Generates a random password
@@ -21,5 +29,38 @@ func GenerateRandomPassword() (string, error) {
 // Validator keystore interface
 type Keystore interface {
     StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
+    GetValidatorPubkeys() ([]rptypes.ValidatorPubkey, error)
 }
 
+
+// Get the pubkeys of the validator keys stored in a dir, in subdirs named by pubkey which contain a key file
+func GetValidatorDirPubkeys(validatorsPath, keyFileName string) ([]rptypes.ValidatorPubkey, error) {
+
+    // Read validators dir; the keystore is empty if it doesn't exist
+    entries, err := ioutil.ReadDir(validatorsPath)
+    if os.IsNotExist(err) {
+        return []rptypes.ValidatorPubkey{}, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Could not read validator keys folder: %w", err)
+    }
+
+    // Get pubkeys of validator key dirs containing a key file
+    pubkeys := []rptypes.ValidatorPubkey{}
+    for _, entry := range entries {
+        if !entry.IsDir() {
+            continue
+        }
+        pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(entry.Name()))
+        if err != nil {
+            continue
+        }
+        if _, err := os.Stat(filepath.Join(validatorsPath, entry.Name(), keyFileName)); err != nil {
+            continue
+        }
+        pubkeys = append(pubkeys, pubkey)
+    }
+
+    // Return
+    return pubkeys, nil
+
+}
@@ -120,3 +120,8 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 
 }
 
+
+// Get the pubkeys of the validator keys stored in the keystore
+func (ks *Keystore) GetValidatorPubkeys() ([]rptypes.ValidatorPubkey, error) {
+    return keystore.GetValidatorDirPubkeys(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir), KeyFileName)
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 
 	"github.com/google/uuid"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	rpkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -162,6 +163,33 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 }
 
 
+// Get the pubkeys of the validator keys stored in the keystore
+func (ks *Keystore) GetValidatorPubkeys() ([]rptypes.ValidatorPubkey, error) {
+
+    // The keystore is empty if the account store doesn't exist; don't initialize it here, as that would create a password file
+    if ks.as == nil {
+        if _, err := os.Stat(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName)); os.IsNotExist(err) {
+            return []rptypes.ValidatorPubkey{}, nil
+        }
+    }
+
+    // Initialize the account store
+    if err := ks.initialize(); err != nil {
+        return nil, err
+    }
+
+    // Get pubkeys
+    pubkeys := []rptypes.ValidatorPubkey{}
+    for _, pubkey := range ks.as.PublicKeys {
+        pubkeys = append(pubkeys, rptypes.BytesToValidatorPubkey(pubkey))
+    }
+
+    // Return
+    return pubkeys, nil
+
+}
+
+
 // Initialize the account store
 func (ks *Keystore) initialize() error {
 
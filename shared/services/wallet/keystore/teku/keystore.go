@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
@@ -114,3 +115,33 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
     return nil
 
 }
+
+
+// Get the pubkeys of the validator keys stored in the keystore
+func (ks *Keystore) GetValidatorPubkeys() ([]rptypes.ValidatorPubkey, error) {
+
+    // Read keys dir; the keystore is empty if it doesn't exist
+    entries, err := ioutil.ReadDir(filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir))
+    if os.IsNotExist(err) {
+        return []rptypes.ValidatorPubkey{}, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("Could not read validator keys folder: %w", err)
+    }
+
+    // Get pubkeys of validator key files
+    pubkeys := []rptypes.ValidatorPubkey{}
+    for _, entry := range entries {
+        if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+            continue
+        }
+        pubkey, err := rptypes.HexToValidatorPubkey(hexutil.RemovePrefix(strings.TrimSuffix(entry.Name(), ".json")))
+        if err != nil {
+            continue
+        }
+        pubkeys = append(pubkeys, pubkey)
+    }
+
+    // Return
+    return pubkeys, nil
+
+}
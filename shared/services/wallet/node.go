@@ -8,11 +8,14 @@ import (
 	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Config
 const NodeKeyPath = "m/44'/60'/0'/0/%d"
+const MaxDerivedNodeAddresses = 1000
 
 
 // Get the node account
@@ -20,7 +23,7 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return accounts.Account{}, errors.New("Wallet is not initialized")
+        return accounts.Account{}, ErrWalletNotInitialized
     }
 
     // Get private key
@@ -53,7 +56,7 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return nil, errors.New("Wallet is not initialized")
+        return nil, ErrWalletNotInitialized
     }
 
     // Get private key
@@ -71,12 +74,120 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 }
 
 
+// Initialize the wallet from an encrypted V3 keystore containing the node key
+// The wallet has no seed, so validator keys cannot be derived from it
+func (w *Wallet) ImportNodeKeystore(keystoreJson []byte, keystorePassword string) error {
+
+    // Check wallet is not initialized
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if w.isInitialized() {
+        return errors.New("Wallet is already initialized")
+    }
+
+    // Decrypt keystore
+    key, err := ethkeystore.DecryptKey(keystoreJson, keystorePassword)
+    if err != nil {
+        return fmt.Errorf("Could not decrypt node keystore: %w", err)
+    }
+
+    // Initialize wallet store
+    return w.initializeStoreFromNodeKey(key.PrivateKey)
+
+}
+
+
+// Get the derivation path & index used for the node key
+// The index is greater than 0 if the lower indices derived invalid child keys
+func (w *Wallet) GetNodeKeyPath() (string, uint, error) {
+
+    // Check wallet is initialized
+    if !w.IsInitialized() {
+        return "", 0, ErrWalletNotInitialized
+    }
+
+    // Check node key was derived
+    if w.IsNodeKeyImported() {
+        return "", 0, ErrImportedNodeKey
+    }
+
+    // Get derivation path
+    _, path, err := w.getNodePrivateKey()
+    if err != nil {
+        return "", 0, err
+    }
+
+    // Get derivation index
+    var index uint
+    if _, err := fmt.Sscanf(path, NodeKeyPath, &index); err != nil {
+        return "", 0, fmt.Errorf("Could not parse node key derivation path '%s': %w", path, err)
+    }
+
+    // Return
+    return path, index, nil
+
+}
+
+
+// Get the node account addresses derived at the first count indices, with their derivation paths
+// Indices which derive invalid child keys are skipped, as they are when deriving the node key
+func (w *Wallet) GetDerivedNodeAddresses(count uint) ([]common.Address, []string, error) {
+
+    // Check wallet is initialized
+    if !w.IsInitialized() {
+        return nil, nil, ErrWalletNotInitialized
+    }
+
+    // Check node keys can be derived
+    if w.IsNodeKeyImported() {
+        return nil, nil, ErrImportedNodeKey
+    }
+
+    // Check address count
+    if count > MaxDerivedNodeAddresses {
+        return nil, nil, fmt.Errorf("Cannot derive more than %d node addresses.", MaxDerivedNodeAddresses)
+    }
+
+    // Unlock key material
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if err := w.unlockKeys(); err != nil {
+        return nil, nil, err
+    }
+
+    // Derive addresses
+    addresses := []common.Address{}
+    paths := []string{}
+    seen := map[string]bool{}
+    for index := uint(0); index < count; index++ {
+        derivedKey, path, err := w.getNodeDerivedKey(index)
+        if err != nil {
+            return nil, nil, err
+        }
+        if seen[path] {
+            continue
+        }
+        seen[path] = true
+        privateKey, err := derivedKey.ECPrivKey()
+        if err != nil {
+            return nil, nil, fmt.Errorf("Could not get private key at derivation path '%s': %w", path, err)
+        }
+        addresses = append(addresses, crypto.PubkeyToAddress(privateKey.ToECDSA().PublicKey))
+        paths = append(paths, path)
+    }
+
+    // Return
+    return addresses, paths, nil
+
+}
+
+
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return nil, errors.New("Wallet is not initialized")
+        return nil, ErrWalletNotInitialized
     }
 
     // Get private key
@@ -91,12 +202,24 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 }
 
 
-// Get the node private key
+// Get a copy of the node private key
+// The cached key is zeroed when the wallet is locked, so it is never handed out
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
+    // Unlock key material
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if err := w.unlockKeys(); err != nil {
+        return nil, "", err
+    }
+
     // Check for cached node key
     if w.nodeKey != nil {
-        return w.nodeKey, w.nodeKeyPath, nil
+        privateKey, err := copyPrivateKey(w.nodeKey)
+        if err != nil {
+            return nil, "", err
+        }
+        return privateKey, w.nodeKeyPath, nil
     }
 
     // Get derived key
@@ -116,9 +239,29 @@ func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
     w.nodeKey = privateKeyECDSA
     w.nodeKeyPath = path
 
-    // Return
-    return privateKeyECDSA, path, nil
+    // Return a copy
+    privateKeyCopy, err := copyPrivateKey(privateKeyECDSA)
+    if err != nil {
+        return nil, "", err
+    }
+    return privateKeyCopy, path, nil
+
+}
+
 
+// Copy a private key
+func copyPrivateKey(privateKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, error) {
+    keyBytes := crypto.FromECDSA(privateKey)
+    defer func() {
+        for i := range keyBytes {
+            keyBytes[i] = 0
+        }
+    }()
+    privateKeyCopy, err := crypto.ToECDSA(keyBytes)
+    if err != nil {
+        return nil, fmt.Errorf("Could not copy node private key: %w", err)
+    }
+    return privateKeyCopy, nil
 }
 
 
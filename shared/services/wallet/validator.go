@@ -2,7 +2,6 @@ package wallet
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"sync"
 
@@ -23,7 +22,7 @@ func (w *Wallet) GetValidatorKeyCount() (uint, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return 0, errors.New("Wallet is not initialized")
+        return 0, ErrWalletNotInitialized
     }
 
     // Return validator key count
@@ -37,7 +36,7 @@ func (w *Wallet) GetValidatorKeyAt(index uint) (*eth2types.BLSPrivateKey, error)
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return nil, errors.New("Wallet is not initialized")
+        return nil, ErrWalletNotInitialized
     }
 
     // Return validator key
@@ -52,7 +51,7 @@ func (w *Wallet) GetValidatorKeyByPubkey(pubkey rptypes.ValidatorPubkey) (*eth2t
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return nil, errors.New("Wallet is not initialized")
+        return nil, ErrWalletNotInitialized
     }
 
     // Get pubkey hex string
@@ -98,7 +97,12 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return nil, errors.New("Wallet is not initialized")
+        return nil, ErrWalletNotInitialized
+    }
+
+    // Check validator keys can be derived
+    if w.IsNodeKeyImported() {
+        return nil, ErrImportedNodeKey
     }
 
     // Get & increment account index
@@ -130,7 +134,7 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return errors.New("Wallet is not initialized")
+        return ErrWalletNotInitialized
     }
 
     // Find matching validator key
@@ -175,6 +179,18 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey) error {
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
+    // Unlock key material
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if err := w.unlockKeys(); err != nil {
+        return nil, "", err
+    }
+
+    // Check validator keys can be derived
+    if w.ws.ImportedNodeKey {
+        return nil, "", ErrImportedNodeKey
+    }
+
     // Get derivation path
     derivationPath := fmt.Sprintf(ValidatorKeyPath, index)
 
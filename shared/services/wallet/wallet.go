@@ -7,16 +7,22 @@ import (
     "fmt"
     "io/ioutil"
     "math/big"
+    "sync"
+    "time"
 
     "github.com/btcsuite/btcd/chaincfg"
     "github.com/btcsuite/btcutil/hdkeychain"
+    "github.com/ethereum/go-ethereum/crypto"
     "github.com/google/uuid"
+    rptypes "github.com/rocket-pool/rocketpool-go/types"
     "github.com/tyler-smith/go-bip39"
     eth2types "github.com/wealdtech/go-eth2-types/v2"
     eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
+    "github.com/rocket-pool/smartnode/shared/services/config"
     "github.com/rocket-pool/smartnode/shared/services/passwords"
     "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+    "github.com/rocket-pool/smartnode/shared/types/api"
 )
 
 
@@ -24,9 +30,15 @@ import (
 const (
     EntropyBits = 256
     FileMode = 0600
+    ImportedNodeKeyPath = "imported"
 )
 
 
+// Errors
+var ErrImportedNodeKey = errors.New("The node wallet was imported from a keystore file and has no seed to derive keys from")
+var ErrWalletNotInitialized = api.ErrWalletNotInitialized
+
+
 // Wallet
 type Wallet struct {
 
@@ -54,6 +66,13 @@ type Wallet struct {
     // Keystores
     keystores map[string]keystore.Keystore
 
+    // Key material lock state & idle auto-lock
+    keyLock sync.Mutex
+    locked bool
+    autoLockTimeout time.Duration
+    autoLockTimer *time.Timer
+    lastKeyUse time.Time
+
     // Desired gas price & limit from config
     gasPrice *big.Int
     gasLimit uint64
@@ -68,6 +87,7 @@ type walletStore struct {
     Version uint                    `json:"version"`
     UUID uuid.UUID                  `json:"uuid"`
     NextAccount uint                `json:"next_account"`
+    ImportedNodeKey bool            `json:"imported_node_key,omitempty"`
 }
 
 
@@ -75,9 +95,9 @@ type walletStore struct {
 func NewWallet(walletPath, chainIDStr string, gasPrice *big.Int, gasLimit uint64, passwordManager *passwords.PasswordManager) (*Wallet, error) {
 
     // Parse chain ID
-    chainID := new(big.Int)
-    if _, ok := chainID.SetString(chainIDStr, 10); !ok {
-        return nil, fmt.Errorf("Invalid Chain ID '%s'", chainIDStr)
+    chainID, err := config.ParseChainID(chainIDStr)
+    if err != nil {
+        return nil, err
     }
 
     // Initialize wallet
@@ -110,15 +130,43 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 }
 
 
+// Get the pubkeys of the validator keys stored in a keystore
+func (w *Wallet) GetKeystoreValidatorPubkeys(name string) ([]rptypes.ValidatorPubkey, error) {
+    ks, ok := w.keystores[name]
+    if !ok {
+        return nil, fmt.Errorf("Unknown validator keystore '%s'", name)
+    }
+    return ks.GetValidatorPubkeys()
+}
+
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
-    return (w.ws != nil && w.seed != nil && w.mk != nil)
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    return w.isInitialized()
+}
+
+
+// Check if the wallet has been initialized; must be called with the key lock held
+func (w *Wallet) isInitialized() bool {
+    return (w.ws != nil && (w.locked || (w.seed != nil && w.mk != nil) || (w.ws.ImportedNodeKey && w.nodeKey != nil)))
+}
+
+
+// Check if the wallet's node key was imported from a keystore rather than derived from a seed
+func (w *Wallet) IsNodeKeyImported() bool {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    return (w.ws != nil && w.ws.ImportedNodeKey)
 }
 
 
 // Attempt to initialize the wallet if not initialized and return status
 func (w *Wallet) GetInitialized() (bool, error) {
-    if w.IsInitialized() {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if w.isInitialized() {
         return true, nil
     }
     return w.loadStore()
@@ -130,7 +178,7 @@ func (w *Wallet) String() (string, error) {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return "", errors.New("Wallet is not initialized")
+        return "", ErrWalletNotInitialized
     }
 
     // Encode wallet store
@@ -149,7 +197,9 @@ func (w *Wallet) String() (string, error) {
 func (w *Wallet) Initialize() (string, error) {
 
     // Check wallet is not initialized
-    if w.IsInitialized() {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if w.isInitialized() {
         return "", errors.New("Wallet is already initialized")
     }
 
@@ -180,7 +230,9 @@ func (w *Wallet) Initialize() (string, error) {
 func (w *Wallet) Recover(mnemonic string) error {
 
     // Check wallet is not initialized
-    if w.IsInitialized() {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if w.isInitialized() {
         return errors.New("Wallet is already initialized")
     }
 
@@ -200,12 +252,114 @@ func (w *Wallet) Recover(mnemonic string) error {
 }
 
 
+// Lock the wallet, zeroing the decrypted seed and all cached key material
+// The wallet store is decrypted again when a key is next required
+func (w *Wallet) LockWallet() {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    w.lockKeys()
+}
+
+
+// Lock the wallet if its keys have not been used within the auto-lock timeout
+func (w *Wallet) autoLock() {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    if w.autoLockTimeout == 0 {
+        return
+    }
+    if idle := time.Since(w.lastKeyUse); idle < w.autoLockTimeout {
+        w.autoLockTimer.Reset(w.autoLockTimeout - idle)
+        return
+    }
+    w.lockKeys()
+}
+
+
+// Zero the decrypted seed and all cached key material
+// Must be called with the key lock held
+func (w *Wallet) lockKeys() {
+
+    // Stop auto-lock timer
+    if w.autoLockTimer != nil {
+        w.autoLockTimer.Stop()
+    }
+
+    // Zero node key; callers are only given copies of it, so it is not in use elsewhere
+    if w.nodeKey != nil {
+        words := w.nodeKey.D.Bits()
+        for i := range words {
+            words[i] = 0
+        }
+        w.nodeKey.D.SetInt64(0)
+        w.nodeKey = nil
+    }
+    w.nodeKeyPath = ""
+
+    // Zero seed & master key
+    for i := range w.seed {
+        w.seed[i] = 0
+    }
+    w.seed = nil
+    if w.mk != nil {
+        w.mk.Zero()
+        w.mk = nil
+    }
+
+    // Drop validator keys
+    w.validatorKeys = map[uint]*eth2types.BLSPrivateKey{}
+
+    // Mark wallet as locked if it has a store to decrypt again
+    w.locked = (w.ws != nil)
+
+}
+
+
+// Set a period of key inactivity after which the wallet is automatically locked
+// A timeout of 0 disables auto-locking
+func (w *Wallet) SetAutoLockTimeout(timeout time.Duration) {
+    w.keyLock.Lock()
+    defer w.keyLock.Unlock()
+    w.autoLockTimeout = timeout
+    if timeout == 0 && w.autoLockTimer != nil {
+        w.autoLockTimer.Stop()
+    }
+}
+
+
+// Decrypt the wallet store if it is locked and reset the auto-lock timer
+// Must be called with the key lock held
+func (w *Wallet) unlockKeys() error {
+
+    // Decrypt wallet store
+    if w.locked {
+        if err := w.decryptStore(); err != nil {
+            return err
+        }
+    }
+
+    // Reset auto-lock timer
+    w.lastKeyUse = time.Now()
+    if w.autoLockTimeout > 0 {
+        if w.autoLockTimer == nil {
+            w.autoLockTimer = time.AfterFunc(w.autoLockTimeout, w.autoLock)
+        } else {
+            w.autoLockTimer.Reset(w.autoLockTimeout)
+        }
+    }
+
+    // Return
+    return nil
+
+}
+
+
 // Save the wallet store to disk
 func (w *Wallet) Save() error {
 
     // Check wallet is initialized
     if !w.IsInitialized() {
-        return errors.New("Wallet is not initialized")
+        return ErrWalletNotInitialized
     }
 
     // Encode wallet store
@@ -240,26 +394,59 @@ func (w *Wallet) loadStore() (bool, error) {
         return false, fmt.Errorf("Could not decode wallet: %w", err)
     }
 
+    // Decrypt seed & create master key
+    if err := w.decryptStore(); err != nil {
+        return false, err
+    }
+
+    // Return
+    return true, nil
+
+}
+
+
+// Decrypt the wallet seed from the wallet store and create the master key
+func (w *Wallet) decryptStore() error {
+
     // Get wallet password
     password, err := w.pm.GetPassword()
     if err != nil {
-        return false, fmt.Errorf("Could not get wallet password: %w", err)
+        return fmt.Errorf("Could not get wallet password: %w", err)
+    }
+
+    // Decrypt imported node key
+    if w.ws.ImportedNodeKey {
+        keyBytes, err := w.encryptor.Decrypt(w.ws.Crypto, password)
+        if err != nil {
+            return fmt.Errorf("Could not decrypt wallet node key: %w", err)
+        }
+        w.nodeKey, err = crypto.ToECDSA(keyBytes)
+        if err != nil {
+            return fmt.Errorf("Could not decode wallet node key: %w", err)
+        }
+        for i := range keyBytes {
+            keyBytes[i] = 0
+        }
+        w.nodeKeyPath = ImportedNodeKeyPath
+        w.locked = false
+        return nil
     }
 
     // Decrypt seed
     w.seed, err = w.encryptor.Decrypt(w.ws.Crypto, password)
     if err != nil {
-        return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
+        return fmt.Errorf("Could not decrypt wallet seed: %w", err)
     }
 
     // Create master key
     w.mk, err = hdkeychain.NewMaster(w.seed, &chaincfg.MainNetParams)
     if err != nil {
-        return false, fmt.Errorf("Could not create wallet master key: %w", err)
+        return fmt.Errorf("Could not create wallet master key: %w", err)
     }
 
     // Return
-    return true, nil
+    w.locked = false
+    return nil
 
 }
 
@@ -269,6 +456,7 @@ func (w *Wallet) initializeStore(mnemonic string) error {
 
     // Generate seed
     w.seed = bip39.NewSeed(mnemonic, "")
+    w.locked = false
 
     // Create master key
     var err error
@@ -303,3 +491,42 @@ func (w *Wallet) initializeStore(mnemonic string) error {
 
 }
 
+
+// Initialize the encrypted wallet store from an imported node private key
+func (w *Wallet) initializeStoreFromNodeKey(privateKey *ecdsa.PrivateKey) error {
+
+    // Get wallet password
+    password, err := w.pm.GetPassword()
+    if err != nil {
+        return fmt.Errorf("Could not get wallet password: %w", err)
+    }
+
+    // Encrypt node key
+    keyBytes := crypto.FromECDSA(privateKey)
+    encryptedKey, err := w.encryptor.Encrypt(keyBytes, password)
+    for i := range keyBytes {
+        keyBytes[i] = 0
+    }
+    if err != nil {
+        return fmt.Errorf("Could not encrypt wallet node key: %w", err)
+    }
+
+    // Create wallet store
+    w.ws = &walletStore{
+        Crypto: encryptedKey,
+        Name: w.encryptor.Name(),
+        Version: w.encryptor.Version(),
+        UUID: uuid.New(),
+        NextAccount: 0,
+        ImportedNodeKey: true,
+    }
+
+    // Cache node key
+    w.nodeKey = privateKey
+    w.nodeKeyPath = ImportedNodeKeyPath
+    w.locked = false
+
+    // Return
+    return nil
+
+}
@@ -1,8 +1,37 @@
 package api
 
+import (
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
 
 type APIResponse struct {
     Status string   `json:"status"`
     Error string    `json:"error"`
+    ErrorCode string `json:"errorCode,omitempty"`
 }
 
+
+
+type WaitForTransactionResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Reverted bool                   `json:"reverted"`
+    Receipt *TransactionReceipt     `json:"receipt,omitempty"`
+    ReceiptError string             `json:"receiptError,omitempty"`
+}
+type TransactionReceipt struct {
+    Status uint64                   `json:"status"`
+    TxHash common.Hash              `json:"transactionHash"`
+    BlockHash common.Hash           `json:"blockHash"`
+    BlockNumber uint64              `json:"blockNumber"`
+    TransactionIndex uint           `json:"transactionIndex"`
+    GasUsed uint64                  `json:"gasUsed"`
+    CumulativeGasUsed uint64        `json:"cumulativeGasUsed"`
+    EffectiveGasPrice *big.Int      `json:"effectiveGasPrice"`
+    ContractAddress common.Address  `json:"contractAddress"`
+    Logs []*types.Log               `json:"logs"`
+}
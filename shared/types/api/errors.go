@@ -0,0 +1,44 @@
+package api
+
+
+// Stable codes identifying API errors which the CLI handles specially
+const (
+    ErrorCodeWalletNotInitialized = "wallet-not-initialized"
+    ErrorCodeNotSynced = "not-synced"
+)
+
+
+// API errors with stable codes
+var (
+    ErrWalletNotInitialized = NewCodedError(ErrorCodeWalletNotInitialized, "Wallet is not initialized")
+    ErrNotSynced = NewCodedError(ErrorCodeNotSynced, "Node is currently syncing")
+)
+
+
+// An API error with a stable code, which is passed back to the CLI in the response's errorCode field
+type CodedError struct {
+    Code string
+    Message string
+}
+
+
+// Create a new coded API error
+func NewCodedError(code, message string) *CodedError {
+    return &CodedError{
+        Code: code,
+        Message: message,
+    }
+}
+
+
+// Get the error message
+func (e *CodedError) Error() string {
+    return e.Message
+}
+
+
+// Errors with the same code match, so errors rebuilt from API responses match the originals
+func (e *CodedError) Is(target error) bool {
+    t, ok := target.(*CodedError)
+    return ok && t.Code == e.Code
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -17,6 +18,14 @@ type MinipoolStatusResponse struct {
     Error string                    `json:"error"`
     Minipools []MinipoolDetails     `json:"minipools"`
 }
+type MinipoolLookupResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Exists bool                     `json:"exists"`
+    Address common.Address          `json:"address"`
+    ValidatorPubkey types.ValidatorPubkey `json:"validatorPubkey"`
+    MinipoolStatus types.MinipoolStatus   `json:"minipoolStatus"`
+}
 type MinipoolDetails struct {
     Address common.Address                  `json:"address"`
     ValidatorPubkey types.ValidatorPubkey   `json:"validatorPubkey"`
@@ -29,6 +38,9 @@ type MinipoolDetails struct {
     Validator ValidatorDetails              `json:"validator"`
     RefundAvailable bool                    `json:"refundAvailable"`
     WithdrawalAvailable bool                `json:"withdrawalAvailable"`
+    WithdrawalDelayRemaining uint64         `json:"withdrawalDelayRemaining"`
+    WithdrawalAvailableBlock uint64         `json:"withdrawalAvailableBlock"`
+    WithdrawalAvailableTime time.Time       `json:"withdrawalAvailableTime"`
     CloseAvailable bool                     `json:"closeAvailable"`
 }
 type ValidatorDetails struct {
@@ -40,6 +52,43 @@ type ValidatorDetails struct {
 }
 
 
+type MinipoolCheckKeysResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    ValidatorClient string          `json:"validatorClient"`
+    MinipoolCount uint64            `json:"minipoolCount"`
+    StoredKeyCount uint64           `json:"storedKeyCount"`
+    MissingKeys []MinipoolKeyStatus `json:"missingKeys"`
+    UnmatchedKeys []types.ValidatorPubkey `json:"unmatchedKeys"`
+}
+type MinipoolKeyStatus struct {
+    Address common.Address          `json:"address"`
+    ValidatorPubkey types.ValidatorPubkey `json:"validatorPubkey"`
+    MinipoolStatus types.MinipoolStatus   `json:"minipoolStatus"`
+}
+
+
+type MinipoolHistoryResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    FromBlock uint64                `json:"fromBlock"`
+    ToBlock uint64                  `json:"toBlock"`
+    Events []MinipoolHistoryEvent   `json:"events"`
+}
+type MinipoolHistoryEvent struct {
+    Address common.Address          `json:"address"`
+    Block uint64                    `json:"block"`
+    Time uint64                     `json:"time"`
+    TxHash common.Hash              `json:"txHash"`
+    Event string                    `json:"event"`
+    Amount *big.Int                 `json:"amount"`
+    NodeAmount *big.Int             `json:"nodeAmount"`
+    UserAmount *big.Int             `json:"userAmount"`
+    Balance *big.Int                `json:"balance"`
+    BalanceError string             `json:"balanceError,omitempty"`
+}
+
+
 type CanRefundMinipoolResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
@@ -2,6 +2,9 @@ package api
 
 import (
     "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
 )
 
 
@@ -23,3 +26,37 @@ type RplPriceResponse struct {
     MinPerMinipoolRplStake *big.Int `json:"minPerMinipoolRplStake"`
     MaxPerMinipoolRplStake *big.Int `json:"maxPerMinipoolRplStake"`
 }
+
+
+type NetworkStatsResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    NodeCount uint64                `json:"nodeCount"`
+    MinipoolCount uint64            `json:"minipoolCount"`
+    MinipoolQueueLength uint64      `json:"minipoolQueueLength"`
+    TotalRplStake *big.Int          `json:"totalRplStake"`
+    TotalEffectiveRplStake *big.Int `json:"totalEffectiveRplStake"`
+    RplPrice *big.Int               `json:"rplPrice"`
+    DepositPoolBalance *big.Int     `json:"depositPoolBalance"`
+}
+
+
+type LatestBlockResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    BlockNumber uint64              `json:"blockNumber"`
+    BlockHash common.Hash           `json:"blockHash"`
+    BlockTime uint64                `json:"blockTime"`
+}
+
+
+type Eth1LatencyResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    Requests int                    `json:"requests"`
+    Errors []string                 `json:"errors"`
+    MinLatency time.Duration        `json:"minLatency"`
+    AvgLatency time.Duration        `json:"avgLatency"`
+    P95Latency time.Duration        `json:"p95Latency"`
+    Throughput float64              `json:"throughput"`
+}
@@ -2,11 +2,13 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/types"
 )
 
 
@@ -36,6 +38,14 @@ type NodeStatusResponse struct {
         WithdrawalAvailable int             `json:"withdrawalAvailable"`
         CloseAvailable int                  `json:"closeAvailable"`
     }                                   `json:"minipoolCounts"`
+    MinipoolNodeFees struct {
+        Min float64                         `json:"min"`
+        Max float64                         `json:"max"`
+    }                                   `json:"minipoolNodeFees"`
+    NodeFee float64                     `json:"nodeFee"`
+    MinNodeFee float64                  `json:"minNodeFee"`
+    TargetNodeFee float64               `json:"targetNodeFee"`
+    MaxNodeFee float64                  `json:"maxNodeFee"`
 }
 
 
@@ -57,7 +67,14 @@ type RegisterNodeResponse struct {
 type CanSetNodeWithdrawalAddressResponse struct {
     Status string                       `json:"status"`
     Error string                        `json:"error"`
-    CanSet bool                        ` json:"canSet"`
+    CanSet bool                         `json:"canSet"`
+    ZeroAddress bool                    `json:"zeroAddress"`
+    IsContract bool                     `json:"isContract"`
+    WithdrawalAddressSet bool           `json:"withdrawalAddressSet"`
+    CurrentWithdrawalAddress common.Address `json:"currentWithdrawalAddress"`
+    WouldRevert bool                    `json:"wouldRevert"`
+    RevertReason string                 `json:"revertReason"`
+    Immediate bool                      `json:"immediate"`
     GasInfo rocketpool.GasInfo          `json:"gasInfo"`
 }
 type SetNodeWithdrawalAddressResponse struct {
@@ -105,6 +122,7 @@ type CanNodeStakeRplResponse struct {
     InsufficientBalance bool            `json:"insufficientBalance"`
     InConsensus bool                    `json:"inConsensus"`
     GasInfo rocketpool.GasInfo          `json:"gasInfo"`
+    StakeGasInfo rocketpool.GasInfo     `json:"stakeGasInfo"`
 }
 type NodeStakeRplApproveResponse struct {
     Status string                       `json:"status"`
@@ -166,6 +184,13 @@ type CanNodeSendResponse struct {
     InsufficientBalance bool            `json:"insufficientBalance"`
     GasInfo rocketpool.GasInfo          `json:"gasInfo"`
 }
+type NodeTokenInfoResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Symbol string                       `json:"symbol"`
+    Decimals uint8                      `json:"decimals"`
+    Balance *big.Int                    `json:"balance"`
+}
 type NodeSendResponse struct {
     Status string                       `json:"status"`
     Error string                        `json:"error"`
@@ -194,12 +219,99 @@ type NodeSyncProgressResponse struct {
     Eth2Progress float64                `json:"eth2Progress"`
     Eth1Synced bool                     `json:"eth1Synced"`
     Eth2Synced bool                     `json:"eth2Synced"`
+    Eth2UsedFallback bool               `json:"eth2UsedFallback"`
+    Eth2Backfilling bool                `json:"eth2Backfilling"`
+    Eth2BackfillProgress float64        `json:"eth2BackfillProgress"`
+}
+
+
+type NodeCollateralHistoryResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    MinimumCollateralRatio float64      `json:"minimumCollateralRatio"`
+    Samples []CollateralSample          `json:"samples"`
+}
+type CollateralSample struct {
+    Block uint64                        `json:"block"`
+    Time int64                          `json:"time"`
+    RplPrice *big.Int                   `json:"rplPrice"`
+    RplStake *big.Int                   `json:"rplStake"`
+    MinipoolCount uint64                `json:"minipoolCount"`
+    CollateralRatio float64             `json:"collateralRatio"`
+}
+
+
+type NodeRplRequiredResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    MinipoolCount uint64                `json:"minipoolCount"`
+    AdditionalMinipools uint64          `json:"additionalMinipools"`
+    RplPrice *big.Int                   `json:"rplPrice"`
+    RplStake *big.Int                   `json:"rplStake"`
+    RequiredRplStake *big.Int           `json:"requiredRplStake"`
+    AdditionalRplStake *big.Int         `json:"additionalRplStake"`
+}
+
+
+type NodeRegistrationResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Registered bool                     `json:"registered"`
+    Found bool                          `json:"found"`
+    Block uint64                        `json:"block"`
+    Time uint64                         `json:"time"`
+    TxHash common.Hash                  `json:"txHash"`
+}
+
+
+type NodeBeaconClientVersionResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    ClientName string                   `json:"clientName"`
+    Version string                      `json:"version"`
+    RawVersion string                   `json:"rawVersion"`
+}
+
+
+type NodeTestConnectivityResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    Eth1Reachable bool                  `json:"eth1Reachable"`
+    Eth1Error string                    `json:"eth1Error"`
+    Eth1NetworkID uint64                `json:"eth1NetworkId"`
+    Eth1ChainID uint64                  `json:"eth1ChainId"`
+    Eth1ExpectedChainID uint64          `json:"eth1ExpectedChainId"`
+    Eth1ChainIDMatch bool               `json:"eth1ChainIdMatch"`
+    Eth1ClientType string               `json:"eth1ClientType"`
+    Eth1ClientVersion string            `json:"eth1ClientVersion"`
+    Eth1Latency time.Duration           `json:"eth1Latency"`
+    Eth2Reachable bool                  `json:"eth2Reachable"`
+    Eth2Error string                    `json:"eth2Error"`
+    Eth2Latency time.Duration           `json:"eth2Latency"`
+}
+
+
+type NodeCheckDuplicateKeysResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    CurrentEpoch uint64                 `json:"currentEpoch"`
+    CheckEpochs uint64                  `json:"checkEpochs"`
+    WaitEpochs uint64                   `json:"waitEpochs"`
+    Validators []DuplicateKeyValidator  `json:"validators"`
+}
+type DuplicateKeyValidator struct {
+    Pubkey types.ValidatorPubkey        `json:"pubkey"`
+    Index uint64                        `json:"index"`
+    Active bool                         `json:"active"`
+    Attesting bool                      `json:"attesting"`
+    BalanceChange int64                 `json:"balanceChange"`
 }
 
 
 type CanNodeClaimRplResponse struct {
     Status string                       `json:"status"`
     Error string                        `json:"error"`
+    CanClaim bool                       `json:"canClaim"`
     RplAmount *big.Int                  `json:"rplAmount"`
     GasInfo rocketpool.GasInfo          `json:"gasInfo"`
 }
@@ -209,3 +321,37 @@ type NodeClaimRplResponse struct {
     TxHash common.Hash                  `json:"txHash"`
 }
 
+
+
+type NodePendingTransactionsResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    LatestNonce uint64                  `json:"latestNonce"`
+    PendingNonce uint64                 `json:"pendingNonce"`
+    PendingNonces []uint64              `json:"pendingNonces"`
+}
+
+
+type CanCancelTransactionResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    CanCancel bool                      `json:"canCancel"`
+    NotPending bool                     `json:"notPending"`
+    NotPendingReason string             `json:"notPendingReason"`
+    PendingGasPrice *big.Int            `json:"pendingGasPrice"`
+    GasPrice *big.Int                   `json:"gasPrice"`
+    GasPriceBumpPercent uint64          `json:"gasPriceBumpPercent"`
+    GasInfo rocketpool.GasInfo          `json:"gasInfo"`
+}
+type CancelTransactionResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    TxHash common.Hash                  `json:"txHash"`
+}
+
+
+type SendRawTransactionResponse struct {
+    Status string                       `json:"status"`
+    Error string                        `json:"error"`
+    TxHash common.Hash                  `json:"txHash"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 )
 
 
@@ -17,6 +18,26 @@ type QueueStatusResponse struct {
 }
 
 
+
+type QueuePositionResponse struct {
+    Status string                   `json:"status"`
+    Error string                    `json:"error"`
+    DepositPoolBalance *big.Int     `json:"depositPoolBalance"`
+    QueueLengths struct {
+        Full uint64                     `json:"full"`
+        Half uint64                     `json:"half"`
+        Empty uint64                    `json:"empty"`
+    }                               `json:"queueLengths"`
+    DepositType types.MinipoolDeposit `json:"depositType"`
+    Position uint64                 `json:"position"`
+    RequiredBalance *big.Int        `json:"requiredBalance"`
+    Shortfall *big.Int              `json:"shortfall"`
+    DepositRate *big.Int            `json:"depositRate"`
+    DepositRatePeriod uint64        `json:"depositRatePeriod"`
+    WaitEstimated bool              `json:"waitEstimated"`
+    EstimatedWait uint64            `json:"estimatedWait"`
+}
+
 type CanProcessQueueResponse struct {
     Status string                   `json:"status"`
     Error string                    `json:"error"`
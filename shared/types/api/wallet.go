@@ -1,6 +1,8 @@
 package api
 
 import (
+    "math/big"
+
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/types"
 )
@@ -12,6 +14,10 @@ type WalletStatusResponse struct {
     PasswordSet bool                        `json:"passwordSet"`
     WalletInitialized bool                  `json:"walletInitialized"`
     AccountAddress common.Address           `json:"accountAddress"`
+    NodeKeyPath string                      `json:"nodeKeyPath"`
+    NodeKeyIndex uint                       `json:"nodeKeyIndex"`
+    NodeKeyDefaultPath string               `json:"nodeKeyDefaultPath"`
+    NodeKeyImported bool                    `json:"nodeKeyImported"`
 }
 
 
@@ -37,6 +43,13 @@ type RecoverWalletResponse struct {
 }
 
 
+type RebuildFromKeystoreResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    AccountAddress common.Address           `json:"accountAddress"`
+}
+
+
 type RebuildWalletResponse struct {
     Status string                           `json:"status"`
     Error string                            `json:"error"`
@@ -44,6 +57,19 @@ type RebuildWalletResponse struct {
 }
 
 
+type DerivedAddressesResponse struct {
+    Status string                           `json:"status"`
+    Error string                            `json:"error"`
+    Addresses []DerivedAddressBalance       `json:"addresses"`
+}
+type DerivedAddressBalance struct {
+    Path string                             `json:"path"`
+    Address common.Address                  `json:"address"`
+    EthBalance *big.Int                     `json:"ethBalance"`
+    RplBalance *big.Int                     `json:"rplBalance"`
+}
+
+
 type ExportWalletResponse struct {
     Status string                           `json:"status"`
     Error string                            `json:"error"`
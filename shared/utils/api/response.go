@@ -54,12 +54,38 @@ func PrintResponse(response interface{}, responseError error) {
         return
     }
 
+    // Add the error code for coded errors
+    var codedErr *api.CodedError
+    if errors.As(responseError, &codedErr) {
+        responseBytes, err = addErrorCode(responseBytes, codedErr.Code)
+        if err != nil {
+            PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+            return
+        }
+    }
+
     // Print
     fmt.Println(string(responseBytes))
 
 }
 
 
+// Add an error code field to an encoded API response
+// Response types do not declare the field, so it is added to the encoded object
+func addErrorCode(responseBytes []byte, code string) ([]byte, error) {
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(responseBytes, &fields); err != nil {
+        return nil, err
+    }
+    codeBytes, err := json.Marshal(code)
+    if err != nil {
+        return nil, err
+    }
+    fields["errorCode"] = codeBytes
+    return json.Marshal(fields)
+}
+
+
 // Print an API error response
 func PrintErrorResponse(err error) {
     PrintResponse(&api.APIResponse{}, err)
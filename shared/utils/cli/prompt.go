@@ -3,25 +3,43 @@ package cli
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// Writer for prompts & other output which isn't machine-readable
+var output io.Writer = os.Stdout
+
+
+// Get the writer for prompts & other output which isn't machine-readable
+// This is stdout unless it has been reserved for machine-readable output
+func Output() io.Writer {
+    return output
+}
+
+
+// Reserve stdout for machine-readable output (e.g. JSON), so prompts & other output are written to stderr
+func ReserveStdout() {
+    output = os.Stderr
+}
+
+
 // Prompt for user input
 func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
     // Print initial prompt
-    fmt.Println(initialPrompt)
+    fmt.Fprintln(output, initialPrompt)
 
     // Get valid user input
     scanner := bufio.NewScanner(os.Stdin)
     for scanner.Scan(); !regexp.MustCompile(expectedFormat).MatchString(scanner.Text()); scanner.Scan() {
-        fmt.Println("")
-        fmt.Println(incorrectFormatPrompt)
+        fmt.Fprintln(output, "")
+        fmt.Fprintln(output, incorrectFormatPrompt)
     }
-    fmt.Println("")
+    fmt.Fprintln(output, "")
 
     // Return user input
     return scanner.Text()
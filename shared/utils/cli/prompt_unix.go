@@ -15,7 +15,7 @@ import (
 func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
     // Print initial prompt
-    fmt.Println(initialPrompt)
+    fmt.Fprintln(output, initialPrompt)
 
     // Get valid user input
     var input string
@@ -24,21 +24,21 @@ func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormat
 
         // Incorrect format
         if init {
-            fmt.Println("")
-            fmt.Println(incorrectFormatPrompt)
+            fmt.Fprintln(output, "")
+            fmt.Fprintln(output, incorrectFormatPrompt)
         } else {
             init = true
         }
 
         // Read password
         if bytes, err := term.ReadPassword(syscall.Stdin); err != nil {
-            fmt.Println(fmt.Errorf("Could not read password: %w", err))
+            fmt.Fprintln(output, fmt.Errorf("Could not read password: %w", err))
         } else {
             input = string(bytes)
         }
 
     }
-    fmt.Println("")
+    fmt.Fprintln(output, "")
 
     // Return user input
     return input
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/exit"
 )
 
 const colorReset string = "\033[0m"
@@ -30,9 +31,9 @@ func PrintTransactionHashNoCancel(rp *rocketpool.Client, hash common.Hash) {
 
 
 // Print a warning to the console if the user set a custom nonce, but this operation involves multiple transactions
-func PrintMultiTransactionNonceWarning() {
+func PrintMultiTransactionNonceWarning(rp *rocketpool.Client) {
 
-    fmt.Printf("%sNOTE: You have specified the `nonce` flag to indicate a custom nonce for this transaction.\n" +
+    fmt.Fprintf(rp.Output(), "%sNOTE: You have specified the `nonce` flag to indicate a custom nonce for this transaction.\n" +
         "However, this operation requires multiple transactions.\n" +
         "Rocket Pool will use your custom value as a basis, and increment it for each additional transaction.\n" +
         "If you have multiple pending transactions, this MAY OVERRIDE more than the one that you specified.%s\n\n", colorYellow, colorReset)
@@ -40,6 +41,17 @@ func PrintMultiTransactionNonceWarning() {
 }
 
 
+// Print that the node's balance is insufficient for an action it can't make, if it is
+// Returns the error to exit with once any other reasons have been printed, so that scripts can tell an insufficient balance apart
+func PrintInsufficientBalance(rp *rocketpool.Client, insufficientBalance bool, message string) error {
+    if !insufficientBalance {
+        return nil
+    }
+    fmt.Fprintln(rp.Output(), message)
+    return exit.NewError(exit.InsufficientBalance, nil)
+}
+
+
 // Implementation of PrintTransactionHash and PrintTransactionHashNoCancel
 func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) {
 
@@ -47,19 +59,19 @@ func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMess
 
     config, err := rp.LoadGlobalConfig()
     if err != nil {
-        fmt.Printf("Warning: couldn't read config file so the transaction URL will be unavailable (%s).\n", err)
+        fmt.Fprintf(rp.Output(), "Warning: couldn't read config file so the transaction URL will be unavailable (%s).\n", err)
     } else {
         txWatchUrl = config.Smartnode.TxWatchUrl
     } 
 
     hashString := hash.String()
 
-    fmt.Printf("Transaction has been submitted with hash %s.\n", hashString)
+    fmt.Fprintf(rp.Output(), "Transaction has been submitted with hash %s.\n", hashString)
     if txWatchUrl != "" {
-        fmt.Printf("You may follow its progress by visiting:\n")
-        fmt.Printf("%s/%s\n\n", txWatchUrl, hashString)
+        fmt.Fprintf(rp.Output(), "You may follow its progress by visiting:\n")
+        fmt.Fprintf(rp.Output(), "%s/%s\n\n", txWatchUrl, hashString)
     }
-    fmt.Print(finalMessage)
+    fmt.Fprint(rp.Output(), finalMessage)
     
 }
 
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/tyler-smith/go-bip39"
 	"github.com/urfave/cli"
 
@@ -110,10 +112,14 @@ func ValidatePercentage(name, value string) (float64, error) {
 
 
 // Validate a token type
+// ERC-20 token contract addresses are also accepted, and returned checksummed
 func ValidateTokenType(name, value string) (string, error) {
+    if common.IsHexAddress(value) {
+        return common.HexToAddress(value).Hex(), nil
+    }
     val := strings.ToLower(value)
     if !(val == "eth" || val == "rpl" || val == "fsrpl" || val == "neth" || val == "reth") {
-        return "", fmt.Errorf("Invalid %s '%s' - valid types are 'ETH', 'RPL', 'fsRPL', 'nETH' and 'rETH'", name, value)
+        return "", fmt.Errorf("Invalid %s '%s' - valid types are 'ETH', 'RPL', 'fsRPL', 'nETH', 'rETH' or an ERC-20 token contract address", name, value)
     }
     return val, nil
 }
@@ -199,6 +205,16 @@ func ValidateBurnableTokenType(name, value string) (string, error) {
 }
 
 
+// Validate a status output format
+func ValidateStatusFormat(name, value string) (string, error) {
+    val := strings.ToLower(value)
+    if !(val == "text" || val == "markdown") {
+        return "", fmt.Errorf("Invalid %s '%s' - valid formats are 'text' and 'markdown'", name, value)
+    }
+    return val, nil
+}
+
+
 // Validate a node password
 func ValidateNodePassword(name, value string) (string, error) {
     if len(value) < passwords.MinPasswordLength {
@@ -272,3 +288,56 @@ func ValidateTxHash(name, value string) (common.Hash, error) {
 
 }
 
+
+// Validate a validator pubkey
+func ValidatePubkey(name, value string) (rptypes.ValidatorPubkey, error) {
+
+    // Remove a 0x prefix if present
+    if strings.HasPrefix(value, "0x") {
+        value = value[2:]
+    }
+
+    // Pubkey should be 96 characters long
+    if len(value) != hex.EncodedLen(rptypes.ValidatorPubkeyLength) {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Invalid %s '%s': it must have %d characters.", name, value, hex.EncodedLen(rptypes.ValidatorPubkeyLength))
+    }
+
+    // Try to parse the string
+    bytes, err := hex.DecodeString(value)
+    if err != nil {
+        return rptypes.ValidatorPubkey{}, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+    }
+    return rptypes.BytesToValidatorPubkey(bytes), nil
+
+}
+
+
+// Validate a sha256 checksum
+func ValidateSHA256Checksum(name, value string) (string, error) {
+    if len(value) != hex.EncodedLen(sha256.Size) {
+        return "", fmt.Errorf("Invalid %s '%s': it must have %d characters.", name, value, hex.EncodedLen(sha256.Size))
+    }
+    if _, err := hex.DecodeString(value); err != nil {
+        return "", fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+    }
+    return value, nil
+}
+
+
+// Validate hex-encoded bytes (e.g. transaction data)
+func ValidateHexBytes(name, value string) ([]byte, error) {
+
+    // Remove a 0x prefix if present
+    if strings.HasPrefix(value, "0x") {
+        value = value[2:]
+    }
+
+    // Try to parse the string
+    bytes, err := hex.DecodeString(value)
+    if err != nil {
+        return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+    }
+    return bytes, nil
+
+}
+
@@ -0,0 +1,43 @@
+package eth1
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+)
+
+// Eth1 client types
+type ClientType string
+const (
+    Geth ClientType = "geth"
+    Nethermind ClientType = "nethermind"
+    Besu ClientType = "besu"
+    OpenEthereum ClientType = "openethereum"
+    UnknownClient ClientType = "unknown"
+)
+
+
+// Get the type & raw version of the eth1 client
+func GetClientType(c *cli.Context) (ClientType, string, error) {
+    version, err := services.GetEth1ClientVersion(c)
+    if err != nil {
+        return UnknownClient, "", err
+    }
+    return ParseClientType(version), version, nil
+}
+
+
+// Parse the client type from a web3_clientVersion string (e.g. "Geth/v1.10.1-stable/linux-amd64/go1.16")
+func ParseClientType(version string) ClientType {
+    name := strings.ToLower(strings.SplitN(version, "/", 2)[0])
+    switch name {
+        case "geth":         return Geth
+        case "nethermind":   return Nethermind
+        case "besu":         return Besu
+        case "openethereum": return OpenEthereum
+    }
+    return UnknownClient
+}
+
@@ -2,14 +2,26 @@ package eth1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/urfave/cli"
 )
 
+// Config
+var confirmationCheckInterval, _ = time.ParseDuration("5s")
+const ConfirmationRetryMaxDelay = time.Minute
+const ConfirmationRetryTimeout = 5 * time.Minute
+
 // Sets the nonce of the provided transaction options to the latest nonce if requested
 func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
@@ -47,3 +59,94 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
 }
 
+
+// Wait for a transaction to be mined and then until its block is a number of confirmations deep
+// Once deep enough the receipt is re-checked; if the transaction was reorged into another block or out of the chain, waiting starts over
+func WaitForTransaction(client *ethclient.Client, hash common.Hash, confirmations uint64) (*types.Receipt, error) {
+
+    // Wait for transaction to be mined
+    txReceipt, err := utils.WaitForTransaction(client, hash)
+    if txReceipt == nil || confirmations <= 1 {
+        return txReceipt, err
+    }
+
+    // Wait for confirmations
+    // Errors getting the latest block or the receipt are retried with backoff, and only returned once they have persisted for the retry timeout
+    var retry confirmationRetry
+    for {
+
+        // Check block depth
+        header, err := client.HeaderByNumber(context.Background(), nil)
+        if err != nil {
+            if err := retry.wait(fmt.Errorf("Could not get latest block: %w", err)); err != nil {
+                return nil, err
+            }
+            continue
+        }
+        if header.Number.Uint64() + 1 < txReceipt.BlockNumber.Uint64() + confirmations {
+            retry.reset()
+            time.Sleep(confirmationCheckInterval)
+            continue
+        }
+
+        // Re-check receipt
+        currentReceipt, err := client.TransactionReceipt(context.Background(), hash)
+        if errors.Is(err, ethereum.NotFound) {
+            txReceipt, err = utils.WaitForTransaction(client, hash)
+            if txReceipt == nil {
+                return nil, err
+            }
+            continue
+        }
+        if err != nil {
+            if err := retry.wait(fmt.Errorf("Could not get transaction receipt: %w", err)); err != nil {
+                return nil, err
+            }
+            continue
+        }
+        retry.reset()
+        if currentReceipt.BlockHash != txReceipt.BlockHash {
+            txReceipt = currentReceipt
+            continue
+        }
+
+        // Check transaction status
+        if currentReceipt.Status == types.ReceiptStatusFailed {
+            return currentReceipt, errors.New("Transaction failed with status 0")
+        }
+        return currentReceipt, nil
+
+    }
+
+}
+
+
+// Retries failed confirmation checks with exponential backoff, until they have been failing for the retry timeout
+type confirmationRetry struct {
+    delay time.Duration
+    deadline time.Time
+}
+
+
+// Wait before retrying a failed check, or return its error if the retry timeout would be exceeded
+func (r *confirmationRetry) wait(err error) error {
+    if r.deadline.IsZero() {
+        r.delay = confirmationCheckInterval
+        r.deadline = time.Now().Add(ConfirmationRetryTimeout)
+    }
+    if time.Now().Add(r.delay).After(r.deadline) {
+        return err
+    }
+    time.Sleep(r.delay)
+    r.delay *= 2
+    if r.delay > ConfirmationRetryMaxDelay {
+        r.delay = ConfirmationRetryMaxDelay
+    }
+    return nil
+}
+
+
+// Reset the backoff after a successful check
+func (r *confirmationRetry) reset() {
+    r.deadline = time.Time{}
+}
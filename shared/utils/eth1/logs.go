@@ -0,0 +1,76 @@
+package eth1
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/utils/poll"
+)
+
+// Config
+const (
+    LogChunkSize = 10000
+    MinLogChunkSize = 100
+)
+
+
+// Get the logs matching a filter query from a block range, in block chunks which are halved if the provider rejects a query
+// Rate-limited queries are retried with backoff instead
+// Each chunk's logs are passed to the handler in order, from the end block backwards if reverse is set; the handler returns false to stop the scan
+func FilterLogs(client ethereum.LogFilterer, query ethereum.FilterQuery, fromBlock, toBlock uint64, reverse bool, handler func(logs []types.Log) bool) error {
+
+    // Check block range
+    if fromBlock > toBlock {
+        return fmt.Errorf("The start block %d is after the end block %d.", fromBlock, toBlock)
+    }
+
+    // Get logs in block chunks
+    chunkSize := uint64(LogChunkSize)
+    for {
+
+        // Get chunk block range
+        start, end := fromBlock, toBlock
+        if end - start >= chunkSize {
+            if reverse {
+                start = end - chunkSize + 1
+            } else {
+                end = start + chunkSize - 1
+            }
+        }
+
+        // Get chunk logs
+        query.FromBlock = new(big.Int).SetUint64(start)
+        query.ToBlock = new(big.Int).SetUint64(end)
+        var logs []types.Log
+        err := RetryRateLimited(func() error {
+            var err error
+            logs, err = client.FilterLogs(context.Background(), query)
+            return err
+        })
+        if err != nil {
+            if blocks := end - start + 1; blocks > MinLogChunkSize && !poll.IsRateLimitError(err) {
+                chunkSize = blocks / 2
+                continue
+            }
+            return fmt.Errorf("Could not get logs for blocks %d to %d: %w", start, end, err)
+        }
+        if !handler(logs) {
+            return nil
+        }
+
+        // Next chunk
+        if reverse {
+            if start == fromBlock { return nil }
+            toBlock = start - 1
+        } else {
+            if end == toBlock { return nil }
+            fromBlock = end + 1
+        }
+
+    }
+
+}
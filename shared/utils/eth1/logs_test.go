@@ -0,0 +1,139 @@
+package eth1
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+
+// A log filterer with a log in every block, which rejects queries over more than a maximum number of blocks
+type testLogFilterer struct {
+    maxBlocks uint64
+    queries [][2]uint64
+}
+func (f *testLogFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+    from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+    f.queries = append(f.queries, [2]uint64{from, to})
+    if to - from + 1 > f.maxBlocks {
+        return nil, errors.New("query returned more than 10000 results")
+    }
+    logs := []types.Log{}
+    for block := from; block <= to; block++ {
+        logs = append(logs, types.Log{BlockNumber: block})
+    }
+    return logs, nil
+}
+func (f *testLogFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+    return nil, errors.New("Not supported")
+}
+
+
+func TestFilterLogs(t *testing.T) {
+    tests := []struct {
+        name string
+        maxBlocks uint64
+        fromBlock uint64
+        toBlock uint64
+        reverse bool
+        stopAt uint64
+        queries [][2]uint64
+        err bool
+    }{
+        {
+            name: "single chunk",
+            maxBlocks: LogChunkSize,
+            fromBlock: 5,
+            toBlock: 5,
+            queries: [][2]uint64{{5, 5}},
+        },
+        {
+            name: "forward chunks",
+            maxBlocks: LogChunkSize,
+            fromBlock: 100,
+            toBlock: 25099,
+            queries: [][2]uint64{{100, 10099}, {10100, 20099}, {20100, 25099}},
+        },
+        {
+            name: "reverse chunks to block 0",
+            maxBlocks: LogChunkSize,
+            fromBlock: 0,
+            toBlock: 15000,
+            reverse: true,
+            queries: [][2]uint64{{5001, 15000}, {0, 5000}},
+        },
+        {
+            name: "reverse chunks stopped by the handler",
+            maxBlocks: LogChunkSize,
+            fromBlock: 0,
+            toBlock: 25000,
+            reverse: true,
+            stopAt: 12000,
+            queries: [][2]uint64{{15001, 25000}, {5001, 15000}},
+        },
+        {
+            name: "rejected queries halve the chunk size",
+            maxBlocks: 3000,
+            fromBlock: 0,
+            toBlock: 4999,
+            queries: [][2]uint64{{0, 4999}, {0, 2499}, {2500, 4999}},
+        },
+        {
+            name: "queries rejected at the minimum chunk size",
+            maxBlocks: MinLogChunkSize / 2,
+            fromBlock: 0,
+            toBlock: 999,
+            err: true,
+        },
+        {
+            name: "invalid block range",
+            maxBlocks: LogChunkSize,
+            fromBlock: 10,
+            toBlock: 9,
+            err: true,
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            client := &testLogFilterer{maxBlocks: test.maxBlocks}
+            blocks := []uint64{}
+            err := FilterLogs(client, ethereum.FilterQuery{}, test.fromBlock, test.toBlock, test.reverse, func(logs []types.Log) bool {
+                for _, log := range logs {
+                    blocks = append(blocks, log.BlockNumber)
+                    if test.stopAt != 0 && log.BlockNumber == test.stopAt {
+                        return false
+                    }
+                }
+                return true
+            })
+            if test.err {
+                if err == nil {
+                    t.Fatal("Expected an error")
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Could not filter logs: %s", err)
+            }
+            if !reflect.DeepEqual(client.queries, test.queries) {
+                t.Errorf("Expected queries %v, got %v", test.queries, client.queries)
+            }
+
+            // Check every block in range was handled once
+            if test.stopAt == 0 && uint64(len(blocks)) != test.toBlock - test.fromBlock + 1 {
+                t.Errorf("Expected logs for %d blocks, got %d", test.toBlock - test.fromBlock + 1, len(blocks))
+            }
+            if !test.reverse {
+                for i := 1; i < len(blocks); i++ {
+                    if blocks[i] != blocks[i - 1] + 1 {
+                        t.Fatalf("Expected block %d after block %d, got %d", blocks[i - 1] + 1, blocks[i - 1], blocks[i])
+                    }
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,25 @@
+package eth1
+
+import (
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/poll"
+)
+
+// Config
+const RateLimitRetries = 5
+var rateLimitInitialDelay = time.Second
+
+
+// Make a provider request, retrying it with exponential backoff while the provider is rate-limiting requests
+func RetryRateLimited(request func() error) error {
+    delay := rateLimitInitialDelay
+    for attempt := 0; ; attempt++ {
+        err := request()
+        if err == nil || !poll.IsRateLimitError(err) || attempt == RateLimitRetries {
+            return err
+        }
+        time.Sleep(delay)
+        delay *= 2
+    }
+}
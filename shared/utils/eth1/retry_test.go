@@ -0,0 +1,60 @@
+package eth1
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+
+func TestRetryRateLimited(t *testing.T) {
+    rateLimitInitialDelay = time.Millisecond
+    defer func() { rateLimitInitialDelay = time.Second }()
+
+    tests := []struct {
+        name string
+        errs []error
+        attempts int
+        err bool
+    }{
+        {
+            name: "success",
+            errs: []error{nil},
+            attempts: 1,
+        },
+        {
+            name: "success after rate limiting",
+            errs: []error{errors.New("429 Too Many Requests"), errors.New("daily request limit reached"), nil},
+            attempts: 3,
+        },
+        {
+            name: "other errors are not retried",
+            errs: []error{errors.New("missing trie node")},
+            attempts: 1,
+            err: true,
+        },
+        {
+            name: "rate limited on every attempt",
+            attempts: RateLimitRetries + 1,
+            err: true,
+        },
+    }
+    for _, test := range tests {
+        t.Run(test.name, func(t *testing.T) {
+            attempts := 0
+            err := RetryRateLimited(func() error {
+                attempts++
+                if attempts <= len(test.errs) {
+                    return test.errs[attempts - 1]
+                }
+                return errors.New("429 Too Many Requests")
+            })
+            if attempts != test.attempts {
+                t.Errorf("Expected %d attempt(s), got %d", test.attempts, attempts)
+            }
+            if test.err != (err != nil) {
+                t.Errorf("Unexpected error result: %v", err)
+            }
+        })
+    }
+}
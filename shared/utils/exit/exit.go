@@ -0,0 +1,78 @@
+package exit
+
+import (
+    "errors"
+)
+
+
+// CLI exit codes
+// These are stable and may be relied upon by scripts:
+//   0 - success
+//   1 - general error
+//   2 - the Eth 1.0 or Eth 2.0 client is not synced
+//   3 - the node has an insufficient balance for the requested action
+//   4 - the action was cancelled by the user
+//   5 - a submitted transaction was reverted
+//   6 - a node health check passed with warnings
+//   7 - a node health check failed
+const (
+    Success = 0
+    GeneralError = 1
+    NotSynced = 2
+    InsufficientBalance = 3
+    Cancelled = 4
+    TransactionReverted = 5
+    HealthWarning = 6
+    HealthFailure = 7
+)
+
+
+// Common errors
+var (
+    ErrCancelled = NewError(Cancelled, errors.New("Cancelled."))
+)
+
+
+// An error with an associated exit code
+type Error struct {
+    Code int
+    Err error
+}
+
+
+// Create a new exit code error
+// err may be nil for failures which have already been reported to the user
+func NewError(code int, err error) *Error {
+    return &Error{
+        Code: code,
+        Err: err,
+    }
+}
+
+
+// Get the error message
+func (e *Error) Error() string {
+    if e.Err == nil {
+        return ""
+    }
+    return e.Err.Error()
+}
+
+
+// Get the wrapped error
+func (e *Error) Unwrap() error {
+    return e.Err
+}
+
+
+// Get the exit code for an error returned from a command
+func GetCode(err error) int {
+    if err == nil {
+        return Success
+    }
+    var exitErr *Error
+    if errors.As(err, &exitErr) {
+        return exitErr.Code
+    }
+    return GeneralError
+}
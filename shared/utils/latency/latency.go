@@ -0,0 +1,61 @@
+package latency
+
+import (
+    "sort"
+    "time"
+)
+
+
+// Config
+const P95 = 0.95
+
+
+// A summary of request latencies
+type Summary struct {
+    Requests int
+    Errors []error
+    Min time.Duration
+    Avg time.Duration
+    P95 time.Duration
+    Throughput float64
+}
+
+
+// Make count sequential calls and summarize their round-trip latencies
+// Only successful calls are included in the latency figures; throughput is in successful calls per second
+func Measure(count int, call func() error) Summary {
+
+    // Make calls
+    summary := Summary{Requests: count}
+    latencies := []time.Duration{}
+    var total time.Duration
+    for i := 0; i < count; i++ {
+        start := time.Now()
+        err := call()
+        elapsed := time.Since(start)
+        total += elapsed
+        if err != nil {
+            summary.Errors = append(summary.Errors, err)
+            continue
+        }
+        latencies = append(latencies, elapsed)
+    }
+    if len(latencies) == 0 {
+        return summary
+    }
+
+    // Summarize latencies
+    sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+    var sum time.Duration
+    for _, latency := range latencies {
+        sum += latency
+    }
+    summary.Min = latencies[0]
+    summary.Avg = sum / time.Duration(len(latencies))
+    summary.P95 = latencies[int(float64(len(latencies) - 1) * P95)]
+    if total > 0 {
+        summary.Throughput = float64(len(latencies)) / total.Seconds()
+    }
+    return summary
+
+}
@@ -0,0 +1,101 @@
+package poll
+
+import (
+    "math/rand"
+    "net/http"
+    "regexp"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+
+// Config
+const MaxBackoffShift = 3
+
+
+// Error message fragments which indicate that a provider is rate-limiting requests
+var rateLimitMessages = []string{"too many requests", "rate limit", "rate-limit", "request limit", "daily limit"}
+
+// The HTTP status code of a provider error response
+// Eth1 clients return the response status line as the error (e.g. "429 Too Many Requests"), and eth2 clients include it as e.g. "HTTP status 429"
+var httpStatusRegex = regexp.MustCompile(`(?:^|: )(\d{3}) [A-Z]|HTTP status (\d{3})\b`)
+
+
+// A polling schedule with random jitter, which backs off while a provider is rate-limiting requests
+type Schedule struct {
+    interval time.Duration
+    jitter time.Duration
+    backoffShift uint
+    random *rand.Rand
+    lock sync.Mutex
+}
+
+
+// Create a new polling schedule
+// Each delay is the interval plus or minus a random amount up to jitter
+func NewSchedule(interval, jitter time.Duration) *Schedule {
+    return &Schedule{
+        interval: interval,
+        jitter: jitter,
+        random: rand.New(rand.NewSource(time.Now().UnixNano())),
+    }
+}
+
+
+// Update the schedule after a poll
+// The interval is doubled (up to 8 times) while polls are rate-limited, and reset once they are not
+func (s *Schedule) Update(rateLimited bool) {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    if !rateLimited {
+        s.backoffShift = 0
+    } else if s.backoffShift < MaxBackoffShift {
+        s.backoffShift++
+    }
+}
+
+
+// Get the delay until the next poll
+func (s *Schedule) Next() time.Duration {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    delay := s.interval << s.backoffShift
+    if s.jitter > 0 {
+        delay += time.Duration(s.random.Int63n(int64(s.jitter) * 2 + 1)) - s.jitter
+    }
+    if delay < 0 {
+        return 0
+    }
+    return delay
+}
+
+
+// Check whether the schedule is currently backing off
+func (s *Schedule) IsBackingOff() bool {
+    s.lock.Lock()
+    defer s.lock.Unlock()
+    return s.backoffShift > 0
+}
+
+
+// Check whether an error indicates that a provider is rate-limiting requests, by its HTTP status code or message
+func IsRateLimitError(err error) bool {
+    if err == nil {
+        return false
+    }
+    for _, match := range httpStatusRegex.FindAllStringSubmatch(err.Error(), -1) {
+        status, _ := strconv.Atoi(match[1] + match[2])
+        if status == http.StatusTooManyRequests {
+            return true
+        }
+    }
+    message := strings.ToLower(err.Error())
+    for _, rateLimitMessage := range rateLimitMessages {
+        if strings.Contains(message, rateLimitMessage) {
+            return true
+        }
+    }
+    return false
+}
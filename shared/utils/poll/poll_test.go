@@ -0,0 +1,53 @@
+package poll
+
+import (
+    "errors"
+    "fmt"
+    "testing"
+    "time"
+)
+
+
+func TestIsRateLimitError(t *testing.T) {
+    for message, expected := range map[string]bool{
+        "429 Too Many Requests": true,
+        "Could not get latest block: 429 Too Many Requests": true,
+        "Could not get node sync status: HTTP status 429; response body: ''": true,
+        "daily request limit reached": true,
+        "project ID rate limit exceeded": true,
+        "Could not get node sync status: HTTP status 500; response body: '429 validators'": false,
+        "Could not get block 4290000: not found": false,
+        "Could not get minipool 0x4291234567890123456789012345678901234567 status": false,
+        "503 Service Unavailable": false,
+        "execution reverted": false,
+    } {
+        if isRateLimitError := IsRateLimitError(errors.New(message)); isRateLimitError != expected {
+            t.Errorf("Expected %q to be a rate-limit error: %t, got %t", message, expected, isRateLimitError)
+        }
+    }
+    if !IsRateLimitError(fmt.Errorf("Could not get logs: %w", errors.New("429 Too Many Requests"))) {
+        t.Error("Expected a wrapped rate-limit error to be detected")
+    }
+    if IsRateLimitError(nil) {
+        t.Error("Expected a nil error not to be a rate-limit error")
+    }
+}
+
+
+// The schedule backs off while polls are rate-limited, up to the maximum, and resets once they aren't
+func TestScheduleBackoff(t *testing.T) {
+    s := NewSchedule(time.Minute, 0)
+    if s.IsBackingOff() || s.Next() != time.Minute {
+        t.Fatalf("Expected a 1m delay without backoff, got %s", s.Next())
+    }
+    for i := 0; i < MaxBackoffShift + 2; i++ {
+        s.Update(true)
+    }
+    if !s.IsBackingOff() || s.Next() != time.Minute << MaxBackoffShift {
+        t.Errorf("Expected a %s delay while backing off, got %s", time.Minute << MaxBackoffShift, s.Next())
+    }
+    s.Update(false)
+    if s.IsBackingOff() || s.Next() != time.Minute {
+        t.Errorf("Expected a 1m delay after backing off, got %s", s.Next())
+    }
+}
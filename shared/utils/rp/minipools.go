@@ -2,10 +2,14 @@ package rp
 
 import (
     "bytes"
+    "fmt"
+    "math/big"
+    "time"
 
     "github.com/ethereum/go-ethereum/accounts/abi/bind"
     "github.com/ethereum/go-ethereum/common"
     "github.com/rocket-pool/rocketpool-go/minipool"
+    "github.com/rocket-pool/rocketpool-go/settings/protocol"
     "github.com/rocket-pool/rocketpool-go/rocketpool"
     "github.com/rocket-pool/rocketpool-go/types"
     "golang.org/x/sync/errgroup"
@@ -15,7 +19,10 @@ import (
 
 
 // Settings
-const MinipoolPubkeyBatchSize = 50
+const (
+    MinipoolPubkeyBatchSize = 50
+    AverageBlockTime = 13 * time.Second
+)
 
 
 // Get minipool validator statuses
@@ -87,3 +94,27 @@ func GetMinipoolValidators(rp *rocketpool.RocketPool, bc beacon.Client, addresse
 
 }
 
+
+// Get the delay in blocks after a minipool becomes withdrawable before its withdrawal can be processed
+// Not yet bound by rocketpool-go, so the protocol minipool settings contract is called directly
+func GetMinipoolWithdrawalDelay(rp *rocketpool.RocketPool, opts *bind.CallOpts) (uint64, error) {
+    minipoolSettingsContract, err := rp.GetContract(protocol.MinipoolSettingsContractName)
+    if err != nil {
+        return 0, err
+    }
+    value := new(*big.Int)
+    if err := minipoolSettingsContract.Call(opts, value, "getWithdrawalDelay"); err != nil {
+        return 0, fmt.Errorf("Could not get minipool withdrawal delay: %w", err)
+    }
+    return (*value).Uint64(), nil
+}
+
+
+// Get the number of blocks remaining in a withdrawable minipool's withdrawal delay
+func GetMinipoolWithdrawalDelayRemaining(statusBlock, withdrawalDelay, currentBlock uint64) uint64 {
+    if currentBlock >= statusBlock + withdrawalDelay {
+        return 0
+    }
+    return statusBlock + withdrawalDelay - currentBlock
+}
+
@@ -0,0 +1,160 @@
+package rp
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/rocket-pool/rocketpool-go/rocketpool"
+    "github.com/rocket-pool/rocketpool-go/tokens"
+    "golang.org/x/sync/errgroup"
+
+    "github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+
+// ERC-20 token ABI
+const erc20Abi = `[
+    {"constant":true,"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+    {"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},
+    {"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"type":"function"},
+    {"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"type":"function"}
+]`
+
+
+// Get an ERC-20 token contract by address
+func GetERC20Contract(rp *rocketpool.RocketPool, tokenAddress common.Address) (*rocketpool.Contract, error) {
+    tokenAbi, err := abi.JSON(strings.NewReader(erc20Abi))
+    if err != nil {
+        return nil, fmt.Errorf("Could not decode ERC-20 ABI: %w", err)
+    }
+    return &rocketpool.Contract{
+        Contract: bind.NewBoundContract(tokenAddress, tokenAbi, rp.Client, rp.Client, rp.Client),
+        Address: &tokenAddress,
+        ABI: &tokenAbi,
+        Client: rp.Client,
+    }, nil
+}
+
+
+// Get the ERC-20 token balance of an address
+func GetERC20Balance(rp *rocketpool.RocketPool, tokenAddress, address common.Address, opts *bind.CallOpts) (*big.Int, error) {
+    tokenContract, err := GetERC20Contract(rp, tokenAddress)
+    if err != nil {
+        return nil, err
+    }
+    balance := new(*big.Int)
+    if err := tokenContract.Call(opts, balance, "balanceOf", address); err != nil {
+        return nil, fmt.Errorf("Could not get token %s balance of %s: %w", tokenAddress.Hex(), address.Hex(), err)
+    }
+    return *balance, nil
+}
+
+
+// Get the symbol & decimals of an ERC-20 token
+func GetERC20Details(rp *rocketpool.RocketPool, tokenAddress common.Address, opts *bind.CallOpts) (string, uint8, error) {
+    tokenContract, err := GetERC20Contract(rp, tokenAddress)
+    if err != nil {
+        return "", 0, err
+    }
+    symbol := new(string)
+    if err := tokenContract.Call(opts, symbol, "symbol"); err != nil {
+        return "", 0, fmt.Errorf("Could not get token %s symbol: %w", tokenAddress.Hex(), err)
+    }
+    decimals := new(uint8)
+    if err := tokenContract.Call(opts, decimals, "decimals"); err != nil {
+        return "", 0, fmt.Errorf("Could not get token %s decimals: %w", tokenAddress.Hex(), err)
+    }
+    return *symbol, *decimals, nil
+}
+
+
+// Estimate the gas of an ERC-20 token transfer
+func EstimateTransferERC20Gas(rp *rocketpool.RocketPool, tokenAddress, to common.Address, amount *big.Int, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+    tokenContract, err := GetERC20Contract(rp, tokenAddress)
+    if err != nil {
+        return rocketpool.GasInfo{}, err
+    }
+    return tokenContract.GetTransactionGasInfo(opts, "transfer", to, amount)
+}
+
+
+// Transfer ERC-20 tokens to an address
+func TransferERC20(rp *rocketpool.RocketPool, tokenAddress, to common.Address, amount *big.Int, opts *bind.TransactOpts) (common.Hash, error) {
+    tokenContract, err := GetERC20Contract(rp, tokenAddress)
+    if err != nil {
+        return common.Hash{}, err
+    }
+    hash, err := tokenContract.Transact(opts, "transfer", to, amount)
+    if err != nil {
+        return common.Hash{}, fmt.Errorf("Could not transfer token %s: %w", tokenAddress.Hex(), err)
+    }
+    return hash, nil
+}
+
+
+// Get the token balances of an address
+// RPL & fixed-supply RPL balances are read from the token addresses in the config if set, e.g. for private deployments of the protocol,
+// and from the token contracts registered with Rocket Pool otherwise
+func GetBalances(rp *rocketpool.RocketPool, cfg config.RocketPoolConfig, address common.Address, opts *bind.CallOpts) (tokens.Balances, error) {
+
+    // Use registered token contracts if no token addresses are set
+    if cfg.Rocketpool.RplTokenAddress == "" && cfg.Rocketpool.FixedSupplyRplTokenAddress == "" {
+        return tokens.GetBalances(rp, address, opts)
+    }
+
+    // Check token addresses
+    if cfg.Rocketpool.RplTokenAddress != "" && !common.IsHexAddress(cfg.Rocketpool.RplTokenAddress) {
+        return tokens.Balances{}, fmt.Errorf("Invalid RPL token address '%s'", cfg.Rocketpool.RplTokenAddress)
+    }
+    if cfg.Rocketpool.FixedSupplyRplTokenAddress != "" && !common.IsHexAddress(cfg.Rocketpool.FixedSupplyRplTokenAddress) {
+        return tokens.Balances{}, fmt.Errorf("Invalid fixed-supply RPL token address '%s'", cfg.Rocketpool.FixedSupplyRplTokenAddress)
+    }
+
+    // Get call options block number
+    var blockNumber *big.Int
+    if opts != nil { blockNumber = opts.BlockNumber }
+
+    // Load data
+    var balances tokens.Balances
+    var wg errgroup.Group
+    wg.Go(func() error {
+        var err error
+        balances.ETH, err = rp.Client.BalanceAt(context.Background(), address, blockNumber)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        balances.RETH, err = tokens.GetRETHBalance(rp, address, opts)
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        if cfg.Rocketpool.RplTokenAddress != "" {
+            balances.RPL, err = GetERC20Balance(rp, common.HexToAddress(cfg.Rocketpool.RplTokenAddress), address, opts)
+        } else {
+            balances.RPL, err = tokens.GetRPLBalance(rp, address, opts)
+        }
+        return err
+    })
+    wg.Go(func() error {
+        var err error
+        if cfg.Rocketpool.FixedSupplyRplTokenAddress != "" {
+            balances.FixedSupplyRPL, err = GetERC20Balance(rp, common.HexToAddress(cfg.Rocketpool.FixedSupplyRplTokenAddress), address, opts)
+        } else {
+            balances.FixedSupplyRPL, err = tokens.GetFixedSupplyRPLBalance(rp, address, opts)
+        }
+        return err
+    })
+
+    // Wait for data
+    if err := wg.Wait(); err != nil {
+        return tokens.Balances{}, err
+    }
+    return balances, nil
+
+}